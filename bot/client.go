@@ -1,10 +1,13 @@
 package bot
 
 import (
+	"sync"
+	"time"
+
 	"github.com/Tnze/go-mc/bot/world"
-	"github.com/Tnze/go-mc/bot/world/entity"
 	"github.com/Tnze/go-mc/bot/world/entity/player"
 	"github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
 )
 
 // Client is used to access Minecraft server
@@ -12,16 +15,42 @@ type Client struct {
 	conn *net.Conn
 	Auth
 
+	// Protocol is the protocol version used to join servers.
+	// If it's zero, JoinServer pings the server and use the version
+	// in the server's status response. See SupportedProtocols.
+	Protocol int
+
+	// ReadTimeout and WriteTimeout are applied to the connection as deadlines
+	// for reading each packet in HandleGame and writing each packet.
+	// Zero means no timeout. The server sends KeepAlive every 15 seconds,
+	// so ReadTimeout should be longer than that.
+	ReadTimeout, WriteTimeout time.Duration
+	watcher                   ctxWatcher
+	writeMu                   sync.Mutex // serializes the packets sent by any goroutine
+
+	// The player state is updated by HandleGame and the methods like SetPosition.
+	// Read the fields directly only in the goroutine running HandleGame,
+	// and use GetPlayer and GetPlayInfo in the others.
 	player.Player
 	PlayInfo
 	abilities PlayerAbilities
+	physics   physics
+	effects   map[int]int  // the amplifiers of the status effects on the player, by the effect IDs
+	stateMu   sync.RWMutex // guards Player, PlayInfo, abilities, physics and effects
 	settings  Settings
 	Wd        world.World //the map data
+	inv       inventory   // the player's inventory and the opened window
+	recipes   recipeBook  // the recipes declared by the server
 
 	// Delegate allows you push a function to let HandleGame run.
+	// The methods of Client are safe to call from any goroutine,
+	// Delegate is only needed when the function reads the fields directly.
 	// Do not send at the same goroutine!
 	Delegate chan func() error
-	Events   eventBroker
+	// Events are the handlers called synchronously in HandleGame.
+	// Bus delivers the same events to any number of listeners asynchronously.
+	Events eventBroker
+	Bus    EventBus
 }
 
 // NewClient init and return a new Client.
@@ -38,15 +67,50 @@ func NewClient() (c *Client) {
 	c.settings = DefaultSettings
 	c.Name = "Steve"
 	c.Delegate = make(chan func() error)
-
-	c.Wd = world.World{
-		Entities: make(map[int32]entity.Entity),
-		Chunks:   make(map[world.ChunkLoc]*world.Chunk),
-	}
+	c.resetState()
 
 	return
 }
 
+// resetState clear the world and the player state, before joining a server.
+func (c *Client) resetState() {
+	c.stateMu.Lock()
+	c.Player = player.Player{}
+	c.PlayInfo = PlayInfo{}
+	c.abilities = PlayerAbilities{}
+	c.physics = physics{}
+	c.effects = make(map[int]int)
+	c.stateMu.Unlock()
+	c.Wd.Reset()
+	c.inv.reset()
+	c.recipes.set(nil)
+}
+
+// GetPlayer return a copy of the player state.
+func (c *Client) GetPlayer() player.Player {
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	return c.Player
+}
+
+// GetPlayInfo return a copy of the game info.
+func (c *Client) GetPlayInfo() PlayInfo {
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	return c.PlayInfo
+}
+
+// sendPacket write a packet to the server with WriteTimeout.
+// It's safe to be called concurrently, the packets are written one by one.
+func (c *Client) sendPacket(p pk.Packet) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.watcher.setDeadline(c.conn.Socket.SetWriteDeadline, c.WriteTimeout); err != nil {
+		return err
+	}
+	return c.conn.WritePacket(p)
+}
+
 //PlayInfo content player info in server.
 type PlayInfo struct {
 	Gamemode         int    //游戏模式
@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"context"
+	"crypto/aes"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	mcnet "github.com/Tnze/go-mc/net"
+	"github.com/Tnze/go-mc/net/CFB8"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestClient_concurrentSend(t *testing.T) {
+	c, server, closeFunc := newTestClient(readNothing)
+	defer closeFunc()
+	c.conn.SetThreshold(64)
+	key, encoStream, decoStream := newSymmetricEncryption()
+	c.conn.SetCipher(encoStream, decoStream) // the cipher streams are stateful
+
+	const senders, count = 8, 50
+	const padding = " makes the chat message longer than the compression threshold "
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				if j%2 == 0 {
+					c.SetPosition(float64(i), float64(j), 0, true)
+				} else if err := c.Chat(strconv.Itoa(i) + padding + strconv.Itoa(j)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+
+	conn := mcnet.WrapConn(server)
+	conn.SetThreshold(64)
+	b, _ := aes.NewCipher(key)
+	conn.SetCipher(CFB8.NewCFB8Encrypt(b, key), CFB8.NewCFB8Decrypt(b, key))
+	_ = server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	chatID, positionID := c.packetID("chat_message"), c.packetID("player_position")
+	for i := 0; i < senders*count; i++ {
+		p, err := conn.ReadPacket()
+		if err != nil {
+			t.Fatalf("packet %d: %v", i, err)
+		}
+		switch p.ID {
+		case chatID:
+			var msg pk.String
+			err = p.Scan(&msg)
+		case positionID:
+			var x, y, z pk.Double
+			var onGround pk.Boolean
+			err = p.Scan(&x, &y, &z, &onGround)
+		default:
+			t.Fatalf("unexpected packet 0x%02X", p.ID)
+		}
+		if err != nil {
+			t.Fatalf("packet %d is broken: %v", i, err)
+		}
+	}
+	wg.Wait()
+	_ = c.GetPlayer()
+}
+
+func TestClient_negotiateTables(t *testing.T) {
+	c := NewClient()
+	c.Protocol = 578
+	if err := c.negotiate(context.Background(), nil, "localhost", 25565); err != nil {
+		t.Errorf("the tables of 1.15.2 are built in: %v", err)
+	}
+	c.Protocol = 735 // 1.16
+	if err := c.negotiate(context.Background(), nil, "localhost", 25565); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("joined without the tables of 1.16: %v", err)
+	}
+}
+
+func TestClient_stalledConn(t *testing.T) {
+	c, _, closeFunc := newTestClient(readNothing) // nothing reads from the server side
+	defer closeFunc()
+
+	done := make(chan error)
+	go func() { done <- c.SetPosition(1, 2, 3, true) }()
+	moved := make(chan struct{})
+	go func() {
+		for c.GetPlayer().X != 1 {
+			time.Sleep(time.Millisecond)
+		}
+		close(moved)
+	}()
+	select {
+	case <-moved:
+	case <-time.After(time.Second):
+		t.Fatal("the state is locked while sending")
+	}
+
+	closeFunc()
+	if err := <-done; err == nil {
+		t.Error("sending to a closed connection succeeded")
+	}
+}
+
+func TestClient_LookAtFromEyes(t *testing.T) {
+	c, _, closeFunc := newTestClient(discardPackets)
+	defer closeFunc()
+	c.X, c.Y, c.Z = 0.5, 64, 0.5
+
+	if err := c.LookAtFromEyes(0.5, 64+eyeHeight, 5.5); err != nil {
+		t.Fatal(err)
+	}
+	if p := c.GetPlayer(); p.Yaw != 0 || p.Pitch != 0 {
+		t.Errorf("the eyes look at yaw %v, pitch %v, want 0, 0", p.Yaw, p.Pitch)
+	}
+	if err := c.LookAt(0.5, 64+eyeHeight, 5.5); err != nil {
+		t.Fatal(err)
+	}
+	if p := c.GetPlayer(); p.Pitch >= 0 {
+		t.Errorf("the feet look at pitch %v, want upward", p.Pitch)
+	}
+
+	closeFunc()
+	if err := c.LookAtFromEyes(0, 0, 0); err == nil {
+		t.Error("the send error is dropped")
+	}
+}
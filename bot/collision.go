@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"math"
+
+	"github.com/Tnze/go-mc/bot/world"
+)
+
+// aabb is an axis-aligned bounding box
+type aabb world.Box
+
+// collisionEpsilon is the tolerance of touching boxes, same as vanilla
+const collisionEpsilon = 1e-7
+
+func (b aabb) offset(x, y, z float64) aabb {
+	return aabb{b.MinX + x, b.MinY + y, b.MinZ + z, b.MaxX + x, b.MaxY + y, b.MaxZ + z}
+}
+
+// expand the box to cover the movement (x, y, z)
+func (b aabb) expand(x, y, z float64) aabb {
+	e := b
+	if x < 0 {
+		e.MinX += x
+	} else {
+		e.MaxX += x
+	}
+	if y < 0 {
+		e.MinY += y
+	} else {
+		e.MaxY += y
+	}
+	if z < 0 {
+		e.MinZ += z
+	} else {
+		e.MaxZ += z
+	}
+	return e
+}
+
+func (b aabb) intersects(o aabb) bool {
+	return b.MinX < o.MaxX && b.MaxX > o.MinX &&
+		b.MinY < o.MaxY && b.MaxY > o.MinY &&
+		b.MinZ < o.MaxZ && b.MaxZ > o.MinZ
+}
+
+func overlaps(min1, max1, min2, max2 float64) bool {
+	return min1 < max2-collisionEpsilon && max1 > min2+collisionEpsilon
+}
+
+// clipX return how far the box b can move along X axis before hitting o, at most dx.
+func (b aabb) clipX(o aabb, dx float64) float64 {
+	if !overlaps(b.MinY, b.MaxY, o.MinY, o.MaxY) || !overlaps(b.MinZ, b.MaxZ, o.MinZ, o.MaxZ) {
+		return dx
+	}
+	if dx > 0 && o.MinX >= b.MaxX-collisionEpsilon {
+		dx = math.Min(dx, o.MinX-b.MaxX)
+	} else if dx < 0 && o.MaxX <= b.MinX+collisionEpsilon {
+		dx = math.Max(dx, o.MaxX-b.MinX)
+	}
+	return dx
+}
+
+// clipY return how far the box b can move along Y axis before hitting o, at most dy.
+func (b aabb) clipY(o aabb, dy float64) float64 {
+	if !overlaps(b.MinX, b.MaxX, o.MinX, o.MaxX) || !overlaps(b.MinZ, b.MaxZ, o.MinZ, o.MaxZ) {
+		return dy
+	}
+	if dy > 0 && o.MinY >= b.MaxY-collisionEpsilon {
+		dy = math.Min(dy, o.MinY-b.MaxY)
+	} else if dy < 0 && o.MaxY <= b.MinY+collisionEpsilon {
+		dy = math.Max(dy, o.MaxY-b.MinY)
+	}
+	return dy
+}
+
+// clipZ return how far the box b can move along Z axis before hitting o, at most dz.
+func (b aabb) clipZ(o aabb, dz float64) float64 {
+	if !overlaps(b.MinX, b.MaxX, o.MinX, o.MaxX) || !overlaps(b.MinY, b.MaxY, o.MinY, o.MaxY) {
+		return dz
+	}
+	if dz > 0 && o.MinZ >= b.MaxZ-collisionEpsilon {
+		dz = math.Min(dz, o.MinZ-b.MaxZ)
+	} else if dz < 0 && o.MaxZ <= b.MinZ+collisionEpsilon {
+		dz = math.Max(dz, o.MaxZ-b.MinZ)
+	}
+	return dz
+}
+
+// collisionBoxes return the collision boxes of the blocks which intersect with area.
+func (c *Client) collisionBoxes(area aabb) (boxes []aabb) {
+	// fences and walls are 1.5 blocks high
+	for y := int(math.Floor(area.MinY)) - 1; y <= int(math.Floor(area.MaxY)); y++ {
+		for x := int(math.Floor(area.MinX)); x <= int(math.Floor(area.MaxX)); x++ {
+			for z := int(math.Floor(area.MinZ)); z <= int(math.Floor(area.MaxZ)); z++ {
+				for _, b := range c.Wd.BlockInfoAt(x, y, z).Boxes {
+					if s := aabb(b).offset(float64(x), float64(y), float64(z)); s.intersects(area) {
+						boxes = append(boxes, s)
+					}
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCanceled is returned when the context passed to the bot is done.
+// The returned errors also wrap the error of the context,
+// so errors.Is(err, context.DeadlineExceeded) tells if it's timeout.
+var ErrCanceled = errors.New("bot: canceled")
+
+type canceledError struct{ err error }
+
+func (e canceledError) Error() string        { return "bot: canceled: " + e.err.Error() }
+func (e canceledError) Is(target error) bool { return target == ErrCanceled }
+func (e canceledError) Unwrap() error        { return e.err }
+
+// contextError replace err by a canceledError if ctx is done,
+// because the I/O is aborted by the ctxWatcher in this case.
+func contextError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return canceledError{ctx.Err()}
+	}
+	return err
+}
+
+// aLongTimeAgo is a non-zero time in the past, used to abort the blocking I/O immediately.
+var aLongTimeAgo = time.Unix(1, 0)
+
+// A ctxWatcher aborts the blocking I/O of a connection when the context is done,
+// by setting the deadline of the connection to the past.
+type ctxWatcher struct {
+	canceled int32 // set to 1 before the deadline is set to the past
+}
+
+// watch the ctx until stop is called. The stop function waits for the watching goroutine,
+// and clears the deadline of conn if it's set by the watcher, so conn can be used again.
+func (w *ctxWatcher) watch(ctx context.Context, conn net.Conn) (stop func()) {
+	atomic.StoreInt32(&w.canceled, 0)
+	if ctx.Done() == nil {
+		return func() {} // never canceled
+	}
+
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			atomic.StoreInt32(&w.canceled, 1)
+			_ = conn.SetDeadline(aLongTimeAgo)
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+		if atomic.CompareAndSwapInt32(&w.canceled, 1, 0) {
+			_ = conn.SetDeadline(time.Time{})
+		}
+	}
+}
+
+// setDeadline set the read or write deadline to timeout later, if timeout is positive.
+// If the watched context is done, it doesn't override the deadline set by the watcher.
+func (w *ctxWatcher) setDeadline(set func(time.Time) error, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+	if err := set(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	// The watcher sets the flag before the deadline,
+	// so if the flag isn't set here, the deadline above will be overridden.
+	if atomic.LoadInt32(&w.canceled) == 1 {
+		return set(aLongTimeAgo)
+	}
+	return nil
+}
+
+// A ContextDialer dials with a context, such as *net.Dialer.
+// If the Dialer passed to JoinServerWithDialerContext implements it,
+// the dialing can be canceled too.
+type ContextDialer interface {
+	DialContext(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func dialContext(ctx context.Context, d Dialer, addr string) (net.Conn, error) {
+	if cd, ok := d.(ContextDialer); ok {
+		return cd.DialContext(ctx, "tcp", addr)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d.Dial("tcp", addr)
+}
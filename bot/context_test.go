@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	mcnet "github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// a server accepts the connections but never responds
+func silentServer(t *testing.T) (addr string, port int, closeFunc func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	a := l.Addr().(*net.TCPAddr)
+	return a.IP.String(), a.Port, func() { l.Close() }
+}
+
+func TestClient_JoinServerContext(t *testing.T) {
+	addr, port, closeServer := silentServer(t)
+	defer closeServer()
+
+	c := NewClient()
+	c.Protocol = ProtocolVersion
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := c.JoinServerContext(ctx, addr, port)
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("join should be canceled by the deadline, get %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, err = PingAndListContext(ctx, addr, port)
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("ping should be canceled, get %v", err)
+	}
+}
+
+func TestClient_HandleGameContext(t *testing.T) {
+	c, server, closeFunc := newTestClient(readNothing)
+	defer closeFunc()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	err := c.HandleGameContext(ctx)
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("game should be canceled, get %v", err)
+	}
+
+	// The connection is closed, a packet might be cut in the middle
+	if err := mcnet.WrapConn(server).WritePacket(pk.Marshal(0x00)); err == nil {
+		t.Error("the connection isn't closed after canceled")
+	}
+}
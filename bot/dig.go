@@ -0,0 +1,297 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Tnze/go-mc/bot/world"
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// The IDs of the status effects changing the dig speed
+const (
+	EffectHaste         = 3
+	EffectMiningFatigue = 4
+)
+
+// ErrDigRejected is returned by MineBlock when the server doesn't allow the digging,
+// such as the block is too far or in the spawn protection.
+var ErrDigRejected = errors.New("bot: digging rejected")
+
+// Effect return the amplifier of the status effect on the player,
+// and whether the player has the effect. The amplifier is 0 for level I.
+func (c *Client) Effect(id int) (amplifier int, ok bool) {
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	amplifier, ok = c.effects[id]
+	return
+}
+
+// digger is what affects the dig speed of the player.
+type digger struct {
+	item       string // the held item, empty for the hand
+	efficiency int    // the level of Efficiency
+	haste      int    // the level of the effects, 0 if not affected
+	fatigue    int
+
+	creative     bool
+	onGround     bool
+	underwater   bool // the eyes are in water
+	aquaAffinity bool // the helmet is enchanted with Aqua Affinity
+}
+
+// breakTicks return the ticks to break the block, 0 for the instant breaking
+// and -1 for the unbreakable blocks. It's the same as the vanilla client.
+func (d digger) breakTicks(s *data.BlockState) int {
+	if s.Hardness < 0 {
+		return -1
+	}
+	if d.creative || s.Hardness == 0 {
+		return 0
+	}
+	speed, harvest := s.DigSpeed(d.item)
+	if speed > 1 && d.efficiency > 0 {
+		speed += float64(d.efficiency*d.efficiency + 1)
+	}
+	if d.haste > 0 {
+		speed *= 1 + 0.2*float64(d.haste)
+	}
+	if d.fatigue > 0 {
+		speed *= [...]float64{0.3, 0.09, 0.0027, 0.00081}[min(d.fatigue, 4)-1]
+	}
+	if d.underwater && !d.aquaAffinity {
+		speed /= 5
+	}
+	if !d.onGround {
+		speed /= 5
+	}
+
+	damage := speed / s.Hardness / 30 // the damage per tick
+	if !harvest {
+		damage = speed / s.Hardness / 100
+	}
+	if damage >= 1 {
+		return 0
+	}
+	return int(math.Ceil(1 / damage))
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// digger return the current digger of the player.
+func (c *Client) digger() (d digger) {
+	held := c.MainHandItem()
+	if held.Present {
+		d.item = data.Items(c.Protocol).Name(int(held.ItemID))
+		d.efficiency = held.ItemTag().EnchantmentLevel("minecraft:efficiency")
+	}
+	c.inv.mu.Lock()
+	helmet := c.inv.slots[5]
+	c.inv.mu.Unlock()
+	if helmet.Present {
+		d.aquaAffinity = helmet.ItemTag().EnchantmentLevel("minecraft:aqua_affinity") > 0
+	}
+
+	c.stateMu.RLock()
+	if amp, ok := c.effects[EffectHaste]; ok {
+		d.haste = amp + 1
+	}
+	if amp, ok := c.effects[EffectMiningFatigue]; ok {
+		d.fatigue = amp + 1
+	}
+	d.creative = c.Gamemode == 1
+	d.onGround = c.OnGround
+	x, y, z := c.X, c.Y+eyeHeight, c.Z
+	c.stateMu.RUnlock()
+
+	d.underwater = c.Wd.BlockInfoAt(int(math.Floor(x)), int(math.Floor(y)), int(math.Floor(z))).Water
+	return
+}
+
+// BreakTicks return the ticks the player needs to break the block in the position,
+// by the held item and the current state of the player.
+// It returns 0 if the block is broken instantly,
+// and -1 if the block is unbreakable or unknown.
+func (c *Client) BreakTicks(x, y, z int) int {
+	if c.Wd.BlockStates == nil {
+		return -1
+	}
+	s := c.Wd.BlockStates.State(int(c.Wd.GetBlock(x, y, z).ID))
+	if s == nil {
+		return -1
+	}
+	return c.digger().breakTicks(s)
+}
+
+// MineBlock looks at the block in the position and breaks it by the held item.
+// It waits for the break time, and returns when the server acknowledges the digging.
+// The player should stay still and near the block before it returns.
+//
+// It returns an error wrapping ErrDigRejected if the server rejects the digging.
+// When ctx is done, the digging is canceled and the returned error matches ErrCanceled.
+func (c *Client) MineBlock(ctx context.Context, x, y, z int) error {
+	if c.Wd.GetBlock(x, y, z).ID == 0 {
+		return fmt.Errorf("bot: no block to mine at (%d, %d, %d)", x, y, z)
+	}
+	ticks := c.BreakTicks(x, y, z)
+	if ticks < 0 {
+		return fmt.Errorf("bot: the block at (%d, %d, %d) is unbreakable", x, y, z)
+	}
+
+	acks := make(chan Event, 16)
+	sub := c.Bus.SubscribeChan(EventDiggingAck, acks)
+	defer sub.Unsubscribe()
+
+	face := c.faceTo(x, y, z)
+	if err := c.LookAtFromEyes(float64(x)+0.5, float64(y)+0.5, float64(z)+0.5); err != nil {
+		return err
+	}
+	if err := c.Dig(0, x, y, z, int(face)); err != nil {
+		return err
+	}
+	if err := c.SwingArm(0); err != nil {
+		return err
+	}
+
+	// the last action is acknowledged at the end
+	status := 0
+	var finish <-chan time.Time
+	if ticks > 0 {
+		t := time.NewTimer(time.Duration(ticks) * time.Second / TPS)
+		defer t.Stop()
+		finish = t.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			if status == 0 && ticks > 0 {
+				_ = c.Dig(1, x, y, z, int(face))
+			}
+			return canceledError{ctx.Err()}
+		case <-finish:
+			status, finish = 2, nil
+			if err := c.Dig(2, x, y, z, int(face)); err != nil {
+				return err
+			}
+		case e := <-acks:
+			ack := e.(DiggingAckEvent)
+			if ack.X != x || ack.Y != y || ack.Z != z {
+				continue
+			}
+			if !ack.Successful {
+				return fmt.Errorf("bot: mine block at (%d, %d, %d) fail: %w", x, y, z, ErrDigRejected)
+			}
+			if finish == nil && ack.Status == status {
+				return nil
+			}
+		}
+	}
+}
+
+// faceTo return the face of the block facing the player's eyes.
+func (c *Client) faceTo(x, y, z int) world.Face {
+	p := c.GetPlayer()
+	dx := p.X - (float64(x) + 0.5)
+	dy := p.Y + eyeHeight - (float64(y) + 0.5)
+	dz := p.Z - (float64(z) + 0.5)
+	switch ax, ay, az := math.Abs(dx), math.Abs(dy), math.Abs(dz); {
+	case ay >= ax && ay >= az:
+		if dy > 0 {
+			return world.Top
+		}
+		return world.Bottom
+	case ax >= az:
+		if dx > 0 {
+			return world.East
+		}
+		return world.West
+	default:
+		if dz > 0 {
+			return world.South
+		}
+		return world.North
+	}
+}
+
+func handleEntityEffectPacket(c *Client, p pk.Packet) error {
+	var (
+		entityID  pk.VarInt
+		effectID  pk.Byte
+		amplifier pk.Byte
+	)
+	if err := p.Scan(&entityID, &effectID, &amplifier); err != nil {
+		return err
+	}
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	if int(entityID) == c.EntityID {
+		c.effects[int(effectID)] = int(amplifier)
+	}
+	return nil
+}
+
+func handleRemoveEntityEffectPacket(c *Client, p pk.Packet) error {
+	var (
+		entityID pk.VarInt
+		effectID pk.Byte
+	)
+	if err := p.Scan(&entityID, &effectID); err != nil {
+		return err
+	}
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	if int(entityID) == c.EntityID {
+		delete(c.effects, int(effectID))
+	}
+	return nil
+}
+
+func handleAcknowledgePlayerDiggingPacket(c *Client, p pk.Packet) error {
+	var (
+		pos        pk.Position
+		block      pk.VarInt
+		status     pk.VarInt
+		successful pk.Boolean
+	)
+	if err := p.Scan(&pos, &block, &status, &successful); err != nil {
+		return err
+	}
+	// the block the server thinks it is
+	c.Wd.SetBlock(pos.X, pos.Y, pos.Z, world.Block{ID: uint(block)})
+	c.Bus.Publish(DiggingAckEvent{
+		X: pos.X, Y: pos.Y, Z: pos.Z,
+		Block:      uint32(block),
+		Status:     int(status),
+		Successful: bool(successful),
+	})
+	return nil
+}
+
+func handleBlockBreakAnimationPacket(c *Client, p pk.Packet) error {
+	if !c.Bus.Subscribed(EventBlockBreakAnimation) {
+		return nil
+	}
+	var (
+		entityID pk.VarInt
+		pos      pk.Position
+		stage    pk.Byte
+	)
+	if err := p.Scan(&entityID, &pos, &stage); err != nil {
+		return err
+	}
+	c.Bus.Publish(BlockBreakAnimationEvent{
+		EntityID: int(entityID),
+		X:        pos.X, Y: pos.Y, Z: pos.Z,
+		Stage: int8(stage),
+	})
+	return nil
+}
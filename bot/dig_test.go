@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/bot/world"
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestDigger_breakTicks(t *testing.T) {
+	bs := data.Blocks(ProtocolVersion)
+	stone := bs.State(bs.DefaultByName["minecraft:stone"])
+	dirt := bs.State(bs.DefaultByName["minecraft:dirt"])
+	bedrock := bs.State(bs.DefaultByName["minecraft:bedrock"])
+	for _, tt := range []struct {
+		d     digger
+		block *data.BlockState
+		ticks int
+	}{
+		{digger{onGround: true}, stone, 150},
+		{digger{onGround: true}, dirt, 15},
+		{digger{item: "minecraft:diamond_pickaxe", onGround: true}, stone, 6},
+		{digger{item: "minecraft:diamond_pickaxe", efficiency: 5, onGround: true}, stone, 2},
+		{digger{item: "minecraft:diamond_pickaxe", efficiency: 5, haste: 2, onGround: true}, stone, 0},
+		{digger{item: "minecraft:diamond_pickaxe", fatigue: 1, onGround: true}, stone, 19},
+		{digger{item: "minecraft:diamond_pickaxe"}, stone, 29},
+		{digger{item: "minecraft:diamond_pickaxe", onGround: true, underwater: true}, stone, 29},
+		{digger{item: "minecraft:diamond_pickaxe", onGround: true, underwater: true, aquaAffinity: true}, stone, 6},
+		{digger{creative: true}, stone, 0},
+		{digger{item: "minecraft:diamond_pickaxe", onGround: true}, bedrock, -1},
+	} {
+		if ticks := tt.d.breakTicks(tt.block); ticks != tt.ticks {
+			t.Errorf("break %s by %+v: %d ticks, want %d", tt.block.Name, tt.d, ticks, tt.ticks)
+		}
+	}
+}
+
+func TestClient_MineBlock(t *testing.T) {
+	c, s, closeFunc := newTestClient(readPackets)
+	defer closeFunc()
+	bs := data.Blocks(ProtocolVersion)
+	c.Wd.BlockStates = bs
+	c.Wd.LoadChunk(0, 0, new(world.Chunk))
+	dirt := world.Block{ID: uint(bs.DefaultByName["minecraft:dirt"])}
+	c.Wd.SetBlock(2, 64, 0, dirt)
+	c.X, c.Y, c.Z, c.OnGround = 0.5, 64, 0.5, true
+	c.Gamemode = 1
+
+	ackID, _ := data.Packets(ProtocolVersion, data.Play, data.Clientbound).ID("acknowledge_player_digging")
+	ack := func(block, status int, successful bool) {
+		p := pk.Marshal(ackID, pk.Position{X: 2, Y: 64, Z: 0}, pk.VarInt(block), pk.VarInt(status), pk.Boolean(successful))
+		if _, err := c.handlePacket(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// wait for the digging packet
+	dig := func() (status pk.VarInt, face pk.Byte) {
+		for p := range s.Sent {
+			if p.ID == c.packetID("player_digging") {
+				var pos pk.Position
+				if err := p.Scan(&status, &pos, &face); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+		}
+		t.Fatal("connection closed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- c.MineBlock(ctx, 2, 64, 0) }()
+	if status, face := dig(); status != 0 || face != pk.Byte(world.West) {
+		t.Errorf("wrong digging: status %d, face %d", status, face)
+	}
+	ack(0, 0, true)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if b := c.Wd.GetBlock(2, 64, 0); b.ID != 0 {
+		t.Errorf("the block isn't broken: %v", b)
+	}
+
+	// dirt by hand in survival mode, 15 ticks
+	c.Wd.SetBlock(2, 64, 0, dirt)
+	c.Gamemode = 0
+	go func() { done <- c.MineBlock(ctx, 2, 64, 0) }()
+	start := time.Now()
+	dig()
+	ack(int(dirt.ID), 0, true)
+	if status, _ := dig(); status != 2 || time.Since(start) < 700*time.Millisecond {
+		t.Errorf("wrong finish digging: status %d after %v", status, time.Since(start))
+	}
+	ack(0, 2, true)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	c.Wd.SetBlock(2, 64, 0, dirt)
+	go func() { done <- c.MineBlock(ctx, 2, 64, 0) }()
+	dig()
+	ack(int(dirt.ID), 0, false)
+	if err := <-done; !errors.Is(err, ErrDigRejected) {
+		t.Errorf("the rejected digging returns %v", err)
+	}
+}
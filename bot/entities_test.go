@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Tnze/go-mc/bot/world"
+	"github.com/Tnze/go-mc/bot/world/entity"
+	"github.com/Tnze/go-mc/data"
+	"github.com/Tnze/go-mc/nbt"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestClient_trackEntities(t *testing.T) {
+	c := NewClient()
+	c.Protocol = ProtocolVersion
+	c.setTables()
+	zombie := c.Wd.EntityTypeID("minecraft:zombie")
+	packets := data.Packets(ProtocolVersion, data.Play, data.Clientbound)
+	handle := func(name string, fields ...pk.FieldEncoder) {
+		t.Helper()
+		id, _ := packets.ID(name)
+		if _, err := c.handlePacket(pk.Marshal(id, fields...)); err != nil {
+			t.Fatalf("handle %s: %v", name, err)
+		}
+	}
+
+	handle("spawn_mob", pk.VarInt(7), pk.UUID{1}, pk.VarInt(zombie),
+		pk.Double(10), pk.Double(64), pk.Double(-3),
+		pk.Angle(64), pk.Angle(0), pk.Angle(64),
+		pk.Short(800), pk.Short(0), pk.Short(0))
+	handle("spawn_player", pk.VarInt(8), pk.UUID{2},
+		pk.Double(0), pk.Double(64), pk.Double(0), pk.Angle(0), pk.Angle(0))
+	handle("entity_look_and_relative_move", pk.VarInt(7),
+		pk.Short(4096), pk.Short(-2048), pk.Short(0), pk.Angle(-64), pk.Angle(32), pk.Boolean(true))
+	handle("entity_velocity", pk.VarInt(7), pk.Short(0), pk.Short(-8000), pk.Short(0))
+	handle("entity_equipment", pk.VarInt(7), pk.VarInt(entity.Helmet),
+		pk.Boolean(true), pk.VarInt(1), pk.Byte(1), pk.Byte(0)) // a stone without NBT
+
+	e, ok := c.Wd.GetEntity(7)
+	if !ok {
+		t.Fatal("entity not spawned")
+	}
+	if e.Type != zombie || e.UUID != (pk.UUID{1}) || e.X != 11 || e.Y != 63.5 || e.Z != -3 {
+		t.Errorf("wrong entity: %+v", e)
+	}
+	if e.Yaw != -90 || e.Pitch != 45 || e.HeadYaw != 90 || !e.OnGround || e.VelY != -1 {
+		t.Errorf("wrong rotation or velocity: %+v", e)
+	}
+	if h := e.Equipment[entity.Helmet]; !h.Present || h.ItemID != 1 {
+		t.Errorf("wrong helmet: %+v", h)
+	}
+
+	if n, ok := c.Wd.NearestEntity(10, 64, -3, 5, nil); !ok || n.EntityID != 7 {
+		t.Errorf("nearest entity should be the zombie, get %v", n.EntityID)
+	}
+	if n, ok := c.Wd.NearestEntity(10, 64, -3, 20, world.OfType(c.Wd.EntityTypeID("minecraft:player"))); !ok || n.EntityID != 8 {
+		t.Errorf("nearest player should be 8, get %v", n.EntityID)
+	}
+	if list := c.Wd.EntitiesWithin(0, 64, 0, 5, nil); len(list) != 1 {
+		t.Errorf("only the player is within 5 blocks: %v", list)
+	}
+
+	handle("entity_teleport", pk.VarInt(8), pk.Double(1), pk.Double(2), pk.Double(3), pk.Angle(0), pk.Angle(0), pk.Boolean(false))
+	if e, _ := c.Wd.GetEntity(8); e.X != 1 || e.Y != 2 || e.Z != 3 {
+		t.Errorf("entity not teleported: %+v", e)
+	}
+	handle("destroy_entities", pk.VarInt(2), pk.VarInt(7), pk.VarInt(8))
+	if len(c.Wd.EntityList()) != 0 {
+		t.Error("entities not destroyed")
+	}
+}
+
+func TestClient_entityMetadata(t *testing.T) {
+	c := NewClient()
+	c.Protocol = ProtocolVersion
+	c.setTables()
+	packets := data.Packets(ProtocolVersion, data.Play, data.Clientbound)
+	handle := func(name string, fields ...pk.FieldEncoder) {
+		t.Helper()
+		id, _ := packets.ID(name)
+		if _, err := c.handlePacket(pk.Marshal(id, fields...)); err != nil {
+			t.Fatalf("handle %s: %v", name, err)
+		}
+	}
+
+	handle("spawn_player", pk.VarInt(8), pk.UUID{2},
+		pk.Double(0), pk.Double(64), pk.Double(0), pk.Angle(0), pk.Angle(0))
+	handle("entity_metadata", pk.VarInt(8),
+		pk.UnsignedByte(entity.MetaFlags), pk.VarInt(0), pk.Byte(entity.FlagCrouching),
+		pk.UnsignedByte(entity.MetaPose), pk.VarInt(18), pk.VarInt(entity.Crouching),
+		pk.UnsignedByte(entity.MetaHealth), pk.VarInt(2), pk.Float(20),
+		pk.UnsignedByte(0xFF))
+	handle("entity_metadata", pk.VarInt(8),
+		pk.UnsignedByte(entity.MetaHealth), pk.VarInt(2), pk.Float(15),
+		pk.UnsignedByte(0xFF))
+
+	e, _ := c.Wd.GetEntity(8)
+	if !e.Metadata.Sneaking() || e.Metadata.Pose() != entity.Crouching {
+		t.Errorf("player should be sneaking: %v", e.Metadata)
+	}
+	if h, ok := e.Metadata.Health(); !ok || h != 15 {
+		t.Errorf("wrong health: %v", h)
+	}
+
+	handle("spawn_object", pk.VarInt(9), pk.UUID{3}, pk.VarInt(c.Wd.EntityTypeID("minecraft:item")),
+		pk.Double(0), pk.Double(64), pk.Double(0), pk.Angle(0), pk.Angle(0),
+		pk.Int(1), pk.Short(0), pk.Short(0), pk.Short(0))
+	handle("entity_metadata", pk.VarInt(9),
+		pk.UnsignedByte(entity.MetaItem), pk.VarInt(6),
+		pk.Boolean(true), pk.VarInt(1), pk.Byte(3), pk.Byte(0), // 3 stones
+		pk.UnsignedByte(0xFF))
+	e, _ = c.Wd.GetEntity(9)
+	if item, ok := e.Metadata.Item(); !ok || item.ItemID != 1 || item.Count != 3 {
+		t.Errorf("wrong dropped item: %+v", item)
+	}
+}
+
+// nbtField encodes the value as NBT in the packets sent by the test server.
+type nbtField struct{ v interface{} }
+
+func (n nbtField) Encode() []byte {
+	var buf bytes.Buffer
+	if err := nbt.Marshal(&buf, n.v); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+func TestClient_blockEntities(t *testing.T) {
+	c := NewClient()
+	c.Protocol = ProtocolVersion
+	c.setTables()
+	packets := data.Packets(ProtocolVersion, data.Play, data.Clientbound)
+	handle := func(name string, fields ...pk.FieldEncoder) {
+		t.Helper()
+		id, _ := packets.ID(name)
+		if _, err := c.handlePacket(pk.Marshal(id, fields...)); err != nil {
+			t.Fatalf("handle %s: %v", name, err)
+		}
+	}
+	events := make(chan Event, 16)
+	c.Bus.SubscribeChan(EventBlockEntityChange, events)
+	actions := make(chan Event, 16)
+	c.Bus.SubscribeChan(EventBlockAction, actions)
+
+	// a chunk of air with a chest, and the biomes before the data since 1.15
+	chunk := []pk.FieldEncoder{pk.Int(0), pk.Int(0), pk.Boolean(true), pk.VarInt(0), nbtField{struct{}{}}}
+	for i := 0; i < 1024; i++ {
+		chunk = append(chunk, pk.Int(1))
+	}
+	chunk = append(chunk, pk.VarInt(0), pk.VarInt(1), nbtField{map[string]interface{}{
+		"id": "minecraft:chest", "x": int32(1), "y": int32(64), "z": int32(2),
+		"CustomName": `{"text":"Loot"}`,
+	}})
+	handle("chunk_data", chunk...)
+	if e, ok := c.Wd.BlockEntity(1, 64, 2); !ok {
+		t.Fatal("the chest isn't loaded")
+	} else if ch, err := e.Container(); err != nil || ch.CustomName == nil || ch.CustomName.Text != "Loot" {
+		t.Errorf("wrong chest: %+v, %v", ch, err)
+	}
+	if e := (<-events).(BlockEntityChangeEvent); e.X != 1 || e.BlockEntity.ID != "minecraft:chest" {
+		t.Errorf("wrong event: %+v", e)
+	}
+
+	handle("update_block_entity", pk.Position{X: 3, Y: 10, Z: 4}, pk.UnsignedByte(1),
+		nbtField{map[string]interface{}{
+			"id": "minecraft:mob_spawner", "x": int32(3), "y": int32(10), "z": int32(4),
+			"SpawnData": map[string]interface{}{"id": "minecraft:skeleton"},
+		}})
+	e, _ := c.Wd.BlockEntity(3, 10, 4)
+	if s, err := e.Spawner(); err != nil || s.Entity != "minecraft:skeleton" {
+		t.Errorf("wrong spawner: %+v, %v", s, err)
+	}
+	<-events
+
+	// an empty NBT removes the block entity
+	handle("update_block_entity", pk.Position{X: 3, Y: 10, Z: 4}, pk.UnsignedByte(1), pk.Byte(nbt.TagEnd))
+	if _, ok := c.Wd.BlockEntity(3, 10, 4); ok {
+		t.Error("the spawner isn't removed")
+	}
+	if e := (<-events).(BlockEntityChangeEvent); !e.Removed || e.X != 3 {
+		t.Errorf("wrong removal event: %+v", e)
+	}
+
+	handle("block_change", pk.Position{X: 1, Y: 64, Z: 2}, pk.VarInt(1))
+	if e := (<-events).(BlockEntityChangeEvent); !e.Removed || e.X != 1 {
+		t.Errorf("the chest isn't removed with the block: %+v", e)
+	}
+
+	handle("block_action", pk.Position{X: 5, Y: 64, Z: 5}, pk.UnsignedByte(1), pk.UnsignedByte(1), pk.VarInt(142))
+	if e := (<-actions).(BlockActionEvent); e.X != 5 || e.Action != 1 || e.Param != 1 || e.Block != 142 {
+		t.Errorf("wrong block action: %+v", e)
+	}
+}
@@ -23,6 +23,9 @@ type eventBroker struct {
 	SpawnEntity        func(entityID int, UUID pk.UUID, mobType int, x, y, z float64, yaw, pitch, headPitch int8, velocityX, velocitY, velocityZ int16) error
 	DestroyEntities    func(entityIDs []int) error
 	EntityRelativeMove func(EntityID, DeltaX, DeltaY, DeltaZ int, onGround bool) error
+	// Reconnect will be called when the Supervisor joined the server again.
+	// The reason is the error ended the last game session, nil if disconnected by the server.
+	Reconnect func(reason error) error
 	// ReceivePacket will be called when new packet arrive.
 	// Default handler will run only if pass == false.
 	ReceivePacket func(p pk.Packet) (pass bool, err error)
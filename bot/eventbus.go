@@ -0,0 +1,387 @@
+package bot
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/Tnze/go-mc/bot/world"
+	"github.com/Tnze/go-mc/bot/world/entity"
+	"github.com/Tnze/go-mc/chat"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// EventKind identifies a type of Event.
+type EventKind int
+
+// The kinds of the events published by the Client
+const (
+	EventGameStart EventKind = iota
+	EventChat
+	EventDisconnect
+	EventHealthChange
+	EventExperienceChange
+	EventDie
+	EventSoundPlay
+	EventPluginMessage
+	EventHeldItemChange
+	EventWindowItems
+	EventWindowItemChange
+	EventSpawnObject
+	EventSpawnEntity
+	EventDestroyEntities
+	EventEntityRelativeMove
+	EventBlockChange
+	EventReconnect
+	EventOpenWindow
+	EventCloseWindow
+	EventDiggingAck
+	EventBlockBreakAnimation
+	EventBlockEntityChange
+	EventBlockAction
+)
+
+// An Event is published by the Client to the EventBus.
+// The listeners get the concrete type by a type assertion,
+// such as e.(ChatEvent) for EventChat.
+type Event interface {
+	Kind() EventKind
+}
+
+// GameStartEvent is published after the JoinGame packet is handled.
+type GameStartEvent struct{}
+
+// ChatEvent is a received chat message.
+type ChatEvent struct {
+	Msg chat.Message
+	Pos byte // 0: chat box, 1: system message, 2: game info
+}
+
+// DisconnectEvent is published when the server kicks the client.
+type DisconnectEvent struct {
+	Reason chat.Message
+}
+
+// HealthChangeEvent is published when the health or food is updated.
+type HealthChangeEvent struct {
+	Health         float32
+	Food           int32
+	FoodSaturation float32
+}
+
+// ExperienceChangeEvent is published when the experience is updated.
+type ExperienceChangeEvent struct {
+	ExperienceBar   float32
+	Level           int32
+	TotalExperience int32
+}
+
+// DieEvent is published when the player's health is updated to zero.
+type DieEvent struct{}
+
+// SoundPlayEvent is a sound played by the server.
+// The Name is empty if the sound ID is unknown in this version.
+type SoundPlayEvent struct {
+	Name          string
+	Category      int
+	X, Y, Z       float64
+	Volume, Pitch float32
+}
+
+// PluginMessageEvent is a plugin message received in play state.
+type PluginMessageEvent struct {
+	Channel string
+	Data    []byte
+}
+
+// HeldItemChangeEvent is published when the server changes the held hotbar slot.
+type HeldItemChangeEvent struct {
+	Slot int
+}
+
+// WindowItemsEvent is published when all slots of a window are set.
+type WindowItemsEvent struct {
+	WindowID byte
+	Slots    []entity.Slot
+}
+
+// WindowItemChangeEvent is published when a slot of a window is set.
+type WindowItemChangeEvent struct {
+	WindowID byte
+	SlotID   int
+	Slot     entity.Slot
+}
+
+// SpawnObjectEvent is published when an object or a vehicle is spawned.
+type SpawnObjectEvent struct {
+	EntityID                        int
+	UUID                            [16]byte
+	Type                            int
+	X, Y, Z                         float64
+	Pitch, Yaw                      float32
+	Data                            int
+	VelocityX, VelocityY, VelocityZ int16
+}
+
+// SpawnEntityEvent is published when a living entity is spawned.
+type SpawnEntityEvent struct {
+	EntityID                        int
+	UUID                            pk.UUID
+	Type                            int
+	X, Y, Z                         float64
+	Yaw, Pitch, HeadPitch           int8
+	VelocityX, VelocityY, VelocityZ int16
+}
+
+// DestroyEntitiesEvent is published when entities are removed.
+type DestroyEntitiesEvent struct {
+	EntityIDs []int
+}
+
+// EntityRelativeMoveEvent is published when an entity moves.
+// The deltas are in 1/4096 blocks.
+type EntityRelativeMoveEvent struct {
+	EntityID               int
+	DeltaX, DeltaY, DeltaZ int
+	OnGround               bool
+}
+
+// BlockChangeEvent is published for each block changed by
+// the BlockChange and the MultiBlockChange packets.
+type BlockChangeEvent struct {
+	X, Y, Z int
+	StateID uint32
+}
+
+// ReconnectEvent is published when the Supervisor joined the server again.
+// The Reason is the error ended the last game session, nil if disconnected by the server.
+type ReconnectEvent struct {
+	Reason error
+}
+
+// OpenWindowEvent is published when the server opens a window.
+type OpenWindowEvent struct {
+	WindowID int
+	Type     WindowType
+	Title    chat.Message
+}
+
+// CloseWindowEvent is published when the server closes the window.
+type CloseWindowEvent struct {
+	WindowID int
+}
+
+// DiggingAckEvent is published when the server acknowledges a digging action of the player.
+// Status is the same as Client.Dig, and Block is the state ID of the block after the action.
+type DiggingAckEvent struct {
+	X, Y, Z    int
+	Block      uint32
+	Status     int
+	Successful bool
+}
+
+// BlockBreakAnimationEvent is published when another player is digging a block.
+// Stage is 0 to 9, or other values to remove the animation.
+type BlockBreakAnimationEvent struct {
+	EntityID int
+	X, Y, Z  int
+	Stage    int8
+}
+
+// BlockEntityChangeEvent is published when a block entity is loaded with the chunk,
+// updated by the server, or removed with its block.
+// BlockEntity is the zero value if Removed.
+type BlockEntityChangeEvent struct {
+	X, Y, Z     int
+	BlockEntity world.BlockEntity
+	Removed     bool
+}
+
+// BlockActionEvent is published when a block does an action, like a chest opening
+// or a note block playing. The meaning of Action and Param depends on the Block,
+// which is the block type ID instead of the state ID.
+type BlockActionEvent struct {
+	X, Y, Z       int
+	Action, Param int
+	Block         int
+}
+
+// Kind implements Event
+func (GameStartEvent) Kind() EventKind           { return EventGameStart }
+func (ChatEvent) Kind() EventKind                { return EventChat }
+func (DisconnectEvent) Kind() EventKind          { return EventDisconnect }
+func (HealthChangeEvent) Kind() EventKind        { return EventHealthChange }
+func (ExperienceChangeEvent) Kind() EventKind    { return EventExperienceChange }
+func (DieEvent) Kind() EventKind                 { return EventDie }
+func (SoundPlayEvent) Kind() EventKind           { return EventSoundPlay }
+func (PluginMessageEvent) Kind() EventKind       { return EventPluginMessage }
+func (HeldItemChangeEvent) Kind() EventKind      { return EventHeldItemChange }
+func (WindowItemsEvent) Kind() EventKind         { return EventWindowItems }
+func (WindowItemChangeEvent) Kind() EventKind    { return EventWindowItemChange }
+func (SpawnObjectEvent) Kind() EventKind         { return EventSpawnObject }
+func (SpawnEntityEvent) Kind() EventKind         { return EventSpawnEntity }
+func (DestroyEntitiesEvent) Kind() EventKind     { return EventDestroyEntities }
+func (EntityRelativeMoveEvent) Kind() EventKind  { return EventEntityRelativeMove }
+func (BlockChangeEvent) Kind() EventKind         { return EventBlockChange }
+func (ReconnectEvent) Kind() EventKind           { return EventReconnect }
+func (OpenWindowEvent) Kind() EventKind          { return EventOpenWindow }
+func (CloseWindowEvent) Kind() EventKind         { return EventCloseWindow }
+func (DiggingAckEvent) Kind() EventKind          { return EventDiggingAck }
+func (BlockBreakAnimationEvent) Kind() EventKind { return EventBlockBreakAnimation }
+func (BlockEntityChangeEvent) Kind() EventKind   { return EventBlockEntityChange }
+func (BlockActionEvent) Kind() EventKind         { return EventBlockAction }
+
+// An EventBus delivers the events to the subscribed listeners.
+// The zero value is ready to use, and it's safe for concurrent use.
+//
+// Publishing never blocks: each listener receives the events on its own
+// goroutine or channel, so a slow listener cannot stall HandleGame,
+// which answers the KeepAlive packets.
+// Unlike the Events, the listeners cannot stop HandleGame by returning an error.
+type EventBus struct {
+	mu   sync.RWMutex
+	subs map[EventKind][]*Subscription
+}
+
+// Subscribe calls fn with the events of the kind on a new goroutine,
+// one by one in the published order, until unsubscribed.
+//
+// The events are queued without limit while fn is running.
+// Use SubscribeChan with a buffered channel to limit the memory.
+func (b *EventBus) Subscribe(kind EventKind, fn func(Event)) *Subscription {
+	s := &Subscription{bus: b, kind: kind, fn: fn}
+	s.cond.L = &s.mu
+	b.add(s)
+	go s.run()
+	return s
+}
+
+// SubscribeChan sends the events of the kind to ch until unsubscribed.
+// If ch isn't ready to receive, the event is dropped and counted by Dropped.
+// The ch is never closed by the EventBus.
+func (b *EventBus) SubscribeChan(kind EventKind, ch chan<- Event) *Subscription {
+	s := &Subscription{bus: b, kind: kind, ch: ch}
+	b.add(s)
+	return s
+}
+
+// Subscribed reports whether any listener subscribes the kind.
+// It's used for skipping the decoding of unwatched packets.
+func (b *EventBus) Subscribed(kind EventKind) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs[kind]) > 0
+}
+
+// Publish delivers e to the listeners of its kind without blocking.
+func (b *EventBus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, s := range b.subs[e.Kind()] {
+		s.deliver(e)
+	}
+}
+
+func (b *EventBus) add(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[EventKind][]*Subscription)
+	}
+	b.subs[s.kind] = append(b.subs[s.kind], s)
+}
+
+func (b *EventBus) remove(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.subs[s.kind]
+	for i := range subs {
+		if subs[i] == s {
+			b.subs[s.kind] = append(subs[:i], subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// A Subscription is a listener registered to an EventBus.
+type Subscription struct {
+	bus  *EventBus
+	kind EventKind
+
+	ch      chan<- Event
+	dropped uint64
+
+	fn     func(Event)
+	mu     sync.Mutex
+	cond   sync.Cond
+	queue  []Event
+	closed bool
+}
+
+// Unsubscribe stops the delivery. The queued events are discarded,
+// but an event being handled by the listener is not interrupted.
+// It's fine to call Unsubscribe more than once, or in the listener.
+func (s *Subscription) Unsubscribe() {
+	s.bus.remove(s)
+	if s.fn != nil {
+		s.mu.Lock()
+		s.closed = true
+		s.queue = nil
+		s.mu.Unlock()
+		s.cond.Signal()
+	}
+}
+
+// Dropped returns the count of events dropped because the channel isn't ready.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+func (s *Subscription) deliver(e Event) {
+	if s.ch != nil {
+		select {
+		case s.ch <- e:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	if !s.closed {
+		s.queue = append(s.queue, e)
+	}
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+// run is the goroutine calling fn.
+func (s *Subscription) run() {
+	var events []Event
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		// swap the buffers, so the queue doesn't grow forever
+		events, s.queue = s.queue, events[:0]
+		s.mu.Unlock()
+
+		for i, e := range events {
+			if s.isClosed() {
+				return
+			}
+			s.fn(e)
+			events[i] = nil
+		}
+	}
+}
+
+func (s *Subscription) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/data"
+	mcnet "github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestEventBus(t *testing.T) {
+	var bus EventBus
+	got1, got2 := make(chan Event, 10), make(chan Event, 10)
+	s1 := bus.Subscribe(EventHeldItemChange, func(e Event) { got1 <- e })
+	s2 := bus.SubscribeChan(EventHeldItemChange, got2)
+	full := bus.SubscribeChan(EventHeldItemChange, make(chan Event))
+
+	if !bus.Subscribed(EventHeldItemChange) || bus.Subscribed(EventChat) {
+		t.Error("wrong Subscribed result")
+	}
+	for i := 0; i < 3; i++ {
+		bus.Publish(HeldItemChangeEvent{Slot: i})
+	}
+	bus.Publish(ChatEvent{}) // no listener
+	for i := 0; i < 3; i++ {
+		for _, ch := range []chan Event{got1, got2} {
+			select {
+			case e := <-ch:
+				if e.(HeldItemChangeEvent).Slot != i {
+					t.Errorf("events out of order: want %d, get %v", i, e)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("event not received")
+			}
+		}
+	}
+	if full.Dropped() != 3 {
+		t.Errorf("the events to the full channel should be dropped, get %d", full.Dropped())
+	}
+
+	s1.Unsubscribe()
+	s2.Unsubscribe()
+	s2.Unsubscribe()
+	full.Unsubscribe()
+	bus.Publish(HeldItemChangeEvent{})
+	if bus.Subscribed(EventHeldItemChange) || len(got2) != 0 {
+		t.Error("event delivered after unsubscribed")
+	}
+}
+
+// A blocked listener shouldn't stop the client answering the KeepAlive.
+func TestEventBus_slowListener(t *testing.T) {
+	c, server, closeFunc := newTestClient(readNothing)
+	defer closeFunc()
+	block := make(chan struct{})
+	defer close(block)
+	c.Bus.Subscribe(EventChat, func(Event) { <-block })
+	go c.HandleGame()
+
+	ids := data.Packets(ProtocolVersion, data.Play, data.Clientbound)
+	chatID, _ := ids.ID("chat_message")
+	keepAliveID, _ := ids.ID("keep_alive")
+	conn := mcnet.WrapConn(server)
+	_ = server.SetDeadline(time.Now().Add(time.Second))
+	for i := 0; i < 3; i++ {
+		if err := conn.WritePacket(pk.Marshal(chatID, pk.String(`{"text":"hi"}`), pk.Byte(0))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := conn.WritePacket(pk.Marshal(keepAliveID, pk.Long(42))); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := conn.ReadPacket()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id pk.Long
+	if err := p.Scan(&id); err != nil || p.ID != c.packetID("keep_alive") || id != 42 {
+		t.Errorf("want KeepAlive 42, get %v: %v", p, err)
+	}
+}
@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+
+	mcnet "github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// serverReader is how the server side of a test client reads the data sent by the client.
+type serverReader int
+
+const (
+	readNothing    serverReader = iota // the test reads it by itself, or a stalled server
+	discardPackets                     // drop everything sent by the client
+	readPackets                        // read the packets into testServer.Sent
+)
+
+// testServer is the server side of the connection of a test client.
+type testServer struct {
+	net.Conn
+	// Sent are the packets sent by the client, only with readPackets.
+	// It's closed when the connection is closed.
+	Sent <-chan pk.Packet
+}
+
+// newTestClient return a client in the play state of ProtocolVersion,
+// connected to a testServer by a pipe.
+// closeFunc closes both sides of the pipe, it can be called more than once.
+func newTestClient(r serverReader) (c *Client, s testServer, closeFunc func()) {
+	client, server := net.Pipe()
+	c = NewClient()
+	c.Protocol = ProtocolVersion
+	c.setTables()
+	c.conn = mcnet.WrapConn(client)
+	s.Conn = server
+
+	switch r {
+	case discardPackets:
+		go io.Copy(ioutil.Discard, server)
+	case readPackets:
+		ch := make(chan pk.Packet, 64)
+		go func() {
+			conn := mcnet.WrapConn(server)
+			for {
+				p, err := conn.ReadPacket()
+				if err != nil {
+					close(ch)
+					return
+				}
+				ch <- p
+			}
+		}()
+		s.Sent = ch
+	}
+	return c, s, func() {
+		client.Close()
+		server.Close()
+	}
+}
@@ -2,10 +2,10 @@ package bot
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"strings"
 
 	"github.com/Tnze/go-mc/bot/world"
 	"github.com/Tnze/go-mc/bot/world/entity"
@@ -28,19 +28,37 @@ import (
 // HandleGame receive server packet and response them correctly.
 // Note that HandleGame will block if you don't receive from Events.
 func (c *Client) HandleGame() error {
+	return c.HandleGameContext(context.Background())
+}
+
+// HandleGameContext is like HandleGame but returns when ctx is done.
+// In that case, the returned error matches ErrCanceled, and the connection is closed,
+// because the packet being read or written may be cut in the middle.
+func (c *Client) HandleGameContext(ctx context.Context) (err error) {
+	stop := c.watcher.watch(ctx, c.conn.Socket)
+	defer func() {
+		stop()
+		err = contextError(ctx, err)
+		if errors.Is(err, ErrCanceled) {
+			c.conn.Close()
+		}
+	}()
+
 	for {
 		select {
+		case <-ctx.Done():
+			return ctx.Err()
 		case task := <-c.Delegate:
 			if err := task(); err != nil {
 				return err
 			}
 		default:
 			//Read packets
+			if err := c.watcher.setDeadline(c.conn.Socket.SetReadDeadline, c.ReadTimeout); err != nil {
+				return fmt.Errorf("bot: set read deadline fail: %w", err)
+			}
 			p, err := c.conn.ReadPacket()
 			if err != nil {
-				if strings.Contains(err.Error(), "read len of packet fail") {
-					continue
-				}
 				return fmt.Errorf("bot: read packet fail: %w", err)
 			}
 			//handle packets
@@ -66,25 +84,29 @@ func (c *Client) handlePacket(p pk.Packet) (disconnect bool, err error) {
 		}
 	}
 
-	switch p.ID {
-	case data.JoinGame:
+	name, _ := c.packetName(p.ID)
+	switch name {
+	case "join_game":
 		err = handleJoinGamePacket(c, p)
 
-		if err == nil && c.Events.GameStart != nil {
-			err = c.Events.GameStart()
+		if err == nil {
+			c.Bus.Publish(GameStartEvent{})
+			if c.Events.GameStart != nil {
+				err = c.Events.GameStart()
+			}
 		}
-	case data.PluginMessageClientbound:
+	case "plugin_message":
 		err = handlePluginPacket(c, p)
-	case data.ServerDifficulty:
+	case "server_difficulty":
 		err = handleServerDifficultyPacket(c, p)
-	case data.SpawnPosition:
+	case "spawn_position":
 		err = handleSpawnPositionPacket(c, p)
-	case data.PlayerAbilitiesClientbound:
+	case "player_abilities":
 		err = handlePlayerAbilitiesPacket(c, p)
-		_ = c.conn.WritePacket(
+		_ = c.sendPacket(
 			//ClientSettings packet (serverbound)
 			pk.Marshal(
-				data.ClientSettings,
+				c.packetID("client_settings"),
 				pk.String(c.settings.Locale),
 				pk.Byte(c.settings.ViewDistance),
 				pk.VarInt(c.settings.ChatMode),
@@ -93,56 +115,93 @@ func (c *Client) handlePacket(p pk.Packet) (disconnect bool, err error) {
 				pk.VarInt(c.settings.MainHand),
 			),
 		)
-	case data.HeldItemChangeClientbound:
+	case "held_item_change":
 		err = handleHeldItemPacket(c, p)
-	case data.ChunkData:
+	case "chunk_data":
 		err = handleChunkDataPacket(c, p)
-	case data.PlayerPositionAndLookClientbound:
+	case "unload_chunk":
+		err = handleUnloadChunkPacket(c, p)
+	case "update_light":
+		err = handleUpdateLightPacket(c, p)
+	case "player_position_and_look":
 		err = handlePlayerPositionAndLookPacket(c, p)
-		sendPlayerPositionAndLookPacket(c) // to confirm the position
-	case data.DeclareRecipes:
-		// handleDeclareRecipesPacket(g, reader)
-	case data.EntityLookAndRelativeMove:
-		err = handleEntityRelativeMove(c, p)
-	//case data.EntityHeadLook:
-	//	err = handleEntityRelativeMove(c, p)
-	case data.EntityRelativeMove:
-		err = handleEntityRelativeMove(c, p)
-	case data.KeepAliveClientbound:
+	case "declare_recipes":
+		err = handleDeclareRecipesPacket(c, p)
+	case "entity_look_and_relative_move":
+		err = handleEntityRelativeMove(c, p, true)
+	case "entity_relative_move":
+		err = handleEntityRelativeMove(c, p, false)
+	case "entity_look":
+		err = handleEntityLookPacket(c, p)
+	case "entity_head_look":
+		err = handleEntityHeadLookPacket(c, p)
+	case "entity_teleport":
+		err = handleEntityTeleportPacket(c, p)
+	case "entity_velocity":
+		err = handleEntityVelocityPacket(c, p)
+	case "entity_equipment":
+		err = handleEntityEquipmentPacket(c, p)
+	case "keep_alive":
 		err = handleKeepAlivePacket(c, p)
-	case data.Entity:
+	case "entity":
 		//handleEntityPacket(g, reader)
-	case data.SpawnPlayer:
-		// err = handleSpawnPlayerPacket(g, reader)
-	case data.WindowItems:
+	case "spawn_player":
+		err = handleSpawnPlayerPacket(c, p)
+	case "spawn_experience_orb":
+		err = handleSpawnExperienceOrbPacket(c, p)
+	case "spawn_painting":
+		err = handleSpawnPaintingPacket(c, p)
+	case "window_items":
 		err = handleWindowItemsPacket(c, p)
-	case data.UpdateHealth:
+	case "update_health":
 		err = handleUpdateHealthPacket(c, p)
-	case data.ChatMessageClientbound:
+	case "chat_message":
 		err = handleChatMessagePacket(c, p)
-	case data.BlockChange:
+	case "block_change":
 		err = handleBlockChangePacket(c, p)
-	case data.MultiBlockChange:
+	case "multi_block_change":
 		err = handleMultiBlockChangePacket(c, p)
-	case data.DisconnectPlay:
+	case "disconnect":
 		err = handleDisconnectPacket(c, p)
 		disconnect = true
-	case data.SetSlot:
+	case "set_slot":
 		err = handleSetSlotPacket(c, p)
-	case data.SoundEffect:
+	case "open_window":
+		err = handleOpenWindowPacket(c, p)
+	case "open_horse_window":
+		err = handleOpenHorseWindowPacket(c, p)
+	case "close_window":
+		err = handleCloseWindowPacket(c, p)
+	case "window_property":
+		err = handleWindowPropertyPacket(c, p)
+	case "confirm_transaction":
+		err = handleConfirmTransactionPacket(c, p)
+	case "sound_effect":
 		err = handleSoundEffect(c, p)
-	case data.NamedSoundEffect:
+	case "named_sound_effect":
 		err = handleNamedSoundEffect(c, p)
-	case data.SetExperience:
+	case "set_experience":
 		err = handleSetExperience(c, p)
-	case data.SpawnObject:
+	case "spawn_object":
 		err = handleSpawnObjectPacket(c, p)
-	case data.SpawnMob:
+	case "spawn_mob":
 		err = handleSpawnEntitiesPacket(c, p)
-	case data.DestroyEntities:
+	case "destroy_entities":
 		err = handleDestroyEntitiesPacket(c, p)
-	//case data.EntityMetadata:
-	//	err = handleEntityMetadata(c, p)
+	case "entity_metadata":
+		err = handleEntityMetadata(c, p)
+	case "entity_effect":
+		err = handleEntityEffectPacket(c, p)
+	case "remove_entity_effect":
+		err = handleRemoveEntityEffectPacket(c, p)
+	case "acknowledge_player_digging":
+		err = handleAcknowledgePlayerDiggingPacket(c, p)
+	case "block_break_animation":
+		err = handleBlockBreakAnimationPacket(c, p)
+	case "update_block_entity":
+		err = handleUpdateBlockEntityPacket(c, p)
+	case "block_action":
+		err = handleBlockActionPacket(c, p)
 	default:
 		// fmt.Printf("ignore pack id %X\n", p.ID)
 	}
@@ -150,9 +209,6 @@ func (c *Client) handlePacket(p pk.Packet) (disconnect bool, err error) {
 }
 
 func handleSpawnEntitiesPacket(c *Client, p pk.Packet) error {
-	if c.Events.SpawnEntity == nil {
-		return nil
-	}
 	var (
 		entityID                        pk.VarInt
 		UUID                            pk.UUID
@@ -161,22 +217,48 @@ func handleSpawnEntitiesPacket(c *Client, p pk.Packet) error {
 		yaw, pitch, headPitch           pk.Angle
 		velocityX, velocityY, velocityZ pk.Short
 	)
-	err := p.Scan(&entityID, &UUID, &mobType, &x, &y, &z, &yaw, &pitch, &headPitch, &velocityX, &velocityY, &velocityZ)
-	if err != nil {
+	fields := []pk.FieldDecoder{&entityID, &UUID, &mobType, &x, &y, &z, &yaw, &pitch, &headPitch, &velocityX, &velocityY, &velocityZ}
+	var meta entity.Metadata
+	if c.Protocol < protocol1_15 {
+		fields = append(fields, metadataField{c.Protocol, &meta})
+	}
+	if err := p.Scan(fields...); err != nil {
 		return err
 	}
-	return c.Events.SpawnEntity(int(entityID), UUID, int(mobType),
-		float64(x), float64(y), float64(z), int8(yaw), int8(pitch), int8(headPitch),
-		int16(velocityX), int16(velocityY), int16(velocityZ))
+	c.Wd.SetEntity(entity.Entity{
+		EntityID: int(entityID), Type: int(mobType), UUID: UUID, Metadata: meta,
+		X: float64(x), Y: float64(y), Z: float64(z),
+		Yaw:     world.AngleToDegrees(int8(yaw)),
+		Pitch:   world.AngleToDegrees(int8(pitch)),
+		HeadYaw: world.AngleToDegrees(int8(headPitch)),
+		VelX:    float64(velocityX) / 8000,
+		VelY:    float64(velocityY) / 8000,
+		VelZ:    float64(velocityZ) / 8000,
+	})
+
+	if c.Events.SpawnEntity == nil && !c.Bus.Subscribed(EventSpawnEntity) {
+		return nil
+	}
+	e := SpawnEntityEvent{
+		EntityID: int(entityID), UUID: UUID, Type: int(mobType),
+		X: float64(x), Y: float64(y), Z: float64(z),
+		Yaw: int8(yaw), Pitch: int8(pitch), HeadPitch: int8(headPitch),
+		VelocityX: int16(velocityX), VelocityY: int16(velocityY), VelocityZ: int16(velocityZ),
+	}
+	c.Bus.Publish(e)
+	if c.Events.SpawnEntity == nil {
+		return nil
+	}
+	return c.Events.SpawnEntity(e.EntityID, e.UUID, e.Type,
+		e.X, e.Y, e.Z, e.Yaw, e.Pitch, e.HeadPitch,
+		e.VelocityX, e.VelocityY, e.VelocityZ)
 }
 
 func handleDestroyEntitiesPacket(c *Client, p pk.Packet) error {
-	if c.Events.DestroyEntities == nil {
-		return nil
-	}
 	var (
 		count     pk.VarInt
 		entityIDs []int
+		ids       []int32
 	)
 	r := bytes.NewReader(p.Data)
 	if err := count.Decode(r); err != nil {
@@ -188,6 +270,13 @@ func handleDestroyEntitiesPacket(c *Client, p pk.Packet) error {
 			return err
 		}
 		entityIDs = append(entityIDs, int(entityID))
+		ids = append(ids, int32(entityID))
+	}
+	c.Wd.RemoveEntities(ids...)
+
+	c.Bus.Publish(DestroyEntitiesEvent{EntityIDs: entityIDs})
+	if c.Events.DestroyEntities == nil {
+		return nil
 	}
 	return c.Events.DestroyEntities(entityIDs)
 }
@@ -203,10 +292,17 @@ func handleSetExperience(c *Client, p pk.Packet) error {
 		return err
 	}
 
+	c.stateMu.Lock()
 	c.ExperienceBar = float32(ExperienceBar)
 	c.Level = int32(Level)
 	c.TotalExperience = int32(TotalExperience)
+	c.stateMu.Unlock()
 
+	c.Bus.Publish(ExperienceChangeEvent{
+		ExperienceBar:   float32(ExperienceBar),
+		Level:           int32(Level),
+		TotalExperience: int32(TotalExperience),
+	})
 	if c.Events.ExperienceChange != nil {
 		err = c.Events.ExperienceChange()
 		if err != nil {
@@ -229,9 +325,18 @@ func handleSoundEffect(c *Client, p pk.Packet) error {
 		return err
 	}
 
+	var name string // the sound IDs are only known for 1.15
+	if c.Protocol >= protocol1_15 && c.Protocol < protocol1_16 && int(SoundID) < len(data.SoundNames) {
+		name = data.SoundNames[SoundID]
+	}
+	c.Bus.Publish(SoundPlayEvent{
+		Name: name, Category: int(SoundCategory),
+		X: float64(x) / 8, Y: float64(y) / 8, Z: float64(z) / 8,
+		Volume: float32(Volume), Pitch: float32(Pitch),
+	})
 	if c.Events.SoundPlay != nil {
 		err = c.Events.SoundPlay(
-			data.SoundNames[SoundID], int(SoundCategory),
+			name, int(SoundCategory),
 			float64(x)/8, float64(y)/8, float64(z)/8,
 			float32(Volume), float32(Pitch))
 	}
@@ -251,6 +356,11 @@ func handleNamedSoundEffect(c *Client, p pk.Packet) error {
 		return err
 	}
 
+	c.Bus.Publish(SoundPlayEvent{
+		Name: string(SoundName), Category: int(SoundCategory),
+		X: float64(x) / 8, Y: float64(y) / 8, Z: float64(z) / 8,
+		Volume: float32(Volume), Pitch: float32(Pitch),
+	})
 	if c.Events.SoundPlay != nil {
 		err = c.Events.SoundPlay(
 			string(SoundName), int(SoundCategory),
@@ -269,6 +379,7 @@ func handleDisconnectPacket(c *Client, p pk.Packet) error {
 		return err
 	}
 
+	c.Bus.Publish(DisconnectEvent{Reason: reason})
 	if c.Events.Disconnect != nil {
 		return c.Events.Disconnect(reason)
 	}
@@ -276,9 +387,6 @@ func handleDisconnectPacket(c *Client, p pk.Packet) error {
 }
 
 func handleSetSlotPacket(c *Client, p pk.Packet) error {
-	if c.Events.WindowsItemChange == nil {
-		return nil
-	}
 	var (
 		windowID pk.Byte
 		slotI    pk.Short
@@ -287,12 +395,19 @@ func handleSetSlotPacket(c *Client, p pk.Packet) error {
 	if err := p.Scan(&windowID, &slotI, &slot); err != nil && !errors.Is(err, nbt.ErrEND) {
 		return err
 	}
+	c.inv.mu.Lock()
+	c.inv.setSlot(int(windowID), int(slotI), slot)
+	c.inv.mu.Unlock()
 
+	c.Bus.Publish(WindowItemChangeEvent{WindowID: byte(windowID), SlotID: int(slotI), Slot: slot})
+	if c.Events.WindowsItemChange == nil {
+		return nil
+	}
 	return c.Events.WindowsItemChange(byte(windowID), int(slotI), slot)
 }
 
 func handleMultiBlockChangePacket(c *Client, p pk.Packet) error {
-	if !c.settings.ReceiveMap {
+	if !c.settings.ReceiveMap && !c.Bus.Subscribed(EventBlockChange) {
 		return nil
 	}
 	r := bytes.NewReader(p.Data)
@@ -315,8 +430,7 @@ func handleMultiBlockChangePacket(c *Client, p pk.Packet) error {
 		return err
 	}
 
-	chunk := c.Wd.Chunks[world.ChunkLoc{int(cX), int(cZ)}]
-	if chunk != nil && int(RecordCount) != 0 {
+	if int(RecordCount) != 0 {
 		for i := int(0); i < int(RecordCount); i++ {
 
 			err := XZ.Decode(r)
@@ -331,8 +445,9 @@ func handleMultiBlockChangePacket(c *Client, p pk.Packet) error {
 			if err != nil {
 				return err
 			}
-			x, z := XZ>>4, XZ&0x0F
-			chunk.Sections[y/16].Blocks[x][y%16][z] = world.Block{ID: uint(BlockID)}
+			x, z := int(cX)*16+int(XZ>>4), int(cZ)*16+int(XZ&0x0F)
+			c.setBlock(x, int(y), z, world.Block{ID: uint(BlockID)})
+			c.Bus.Publish(BlockChangeEvent{X: x, Y: int(y), Z: z, StateID: uint32(BlockID)})
 		}
 	}
 
@@ -340,7 +455,7 @@ func handleMultiBlockChangePacket(c *Client, p pk.Packet) error {
 }
 
 func handleBlockChangePacket(c *Client, p pk.Packet) error {
-	if !c.settings.ReceiveMap {
+	if !c.settings.ReceiveMap && !c.Bus.Subscribed(EventBlockChange) {
 		return nil
 	}
 	var (
@@ -351,17 +466,24 @@ func handleBlockChangePacket(c *Client, p pk.Packet) error {
 	if err != nil {
 		return err
 	}
-	x := pos.X
-	y := pos.Y
-	z := pos.Z
-	chunk := c.Wd.Chunks[world.ChunkLoc{x >> 4, z >> 4}]
-	if chunk != nil {
-		chunk.Sections[y/16].Blocks[x&15][y&15][z&15] = world.Block{ID: uint(BlockID)}
-	}
+	c.setBlock(pos.X, pos.Y, pos.Z, world.Block{ID: uint(BlockID)})
+	c.Bus.Publish(BlockChangeEvent{X: pos.X, Y: pos.Y, Z: pos.Z, StateID: uint32(BlockID)})
 
 	return nil
 }
 
+// setBlock change the block in the world,
+// and publish the removal of the block entity if the block is replaced.
+func (c *Client) setBlock(x, y, z int, b world.Block) {
+	_, had := c.Wd.BlockEntity(x, y, z)
+	c.Wd.SetBlock(x, y, z, b)
+	if had {
+		if _, ok := c.Wd.BlockEntity(x, y, z); !ok {
+			c.Bus.Publish(BlockEntityChangeEvent{X: x, Y: y, Z: z, Removed: true})
+		}
+	}
+}
+
 func handleChatMessagePacket(c *Client, p pk.Packet) (err error) {
 	var (
 		s   chat.Message
@@ -373,6 +495,7 @@ func handleChatMessagePacket(c *Client, p pk.Packet) (err error) {
 		return err
 	}
 
+	c.Bus.Publish(ChatEvent{Msg: s, Pos: byte(pos)})
 	if c.Events.ChatMsg != nil {
 		err = c.Events.ChatMsg(s, byte(pos))
 	}
@@ -392,18 +515,27 @@ func handleUpdateHealthPacket(c *Client, p pk.Packet) (err error) {
 		return
 	}
 
+	c.stateMu.Lock()
 	c.Health = float32(Health)
 	c.Food = int32(Food)
 	c.FoodSaturation = float32(FoodSaturation)
+	c.stateMu.Unlock()
 
+	c.Bus.Publish(HealthChangeEvent{Health: float32(Health), Food: int32(Food), FoodSaturation: float32(FoodSaturation)})
 	if c.Events.HealthChange != nil {
 		err = c.Events.HealthChange()
 		if err != nil {
 			return
 		}
 	}
-	if c.Health < 1 { //player is dead
-		sendPlayerPositionAndLookPacket(c)
+	if Health < 1 { //player is dead
+		c.stateMu.Lock()
+		p := playerPositionAndLookPacket(c)
+		c.stateMu.Unlock()
+		if err = c.sendPacket(p); err != nil {
+			return
+		}
+		c.Bus.Publish(DieEvent{})
 		if c.Events.Die != nil {
 			err = c.Events.Die()
 			if err != nil {
@@ -425,12 +557,32 @@ func handleJoinGamePacket(c *Client, p pk.Packet) error {
 		viewDistance pk.VarInt
 		rdi          pk.Boolean // Reduced Debug Info
 		ers          pk.Boolean // Enable respawn screen
+		err          error
 	)
-	err := p.Scan(&eid, &gamemode, &dimension, &hashedSeed, &maxPlayers, &levelType, &rdi, &ers)
+	switch {
+	case c.Protocol >= protocol1_16:
+		var (
+			prevGamemode  pk.UnsignedByte
+			worldNames    worldNames
+			dimensionName pk.Identifier
+			worldName     pk.Identifier
+			codec         struct{}
+		)
+		err = p.Scan(&eid, &gamemode, &prevGamemode, &worldNames, pk.NBT{V: &codec},
+			&dimensionName, &worldName, &hashedSeed, &maxPlayers, &viewDistance, &rdi, &ers)
+		dimension = pk.Int(dimensionID(string(dimensionName)))
+		levelType = "default"
+	case c.Protocol >= protocol1_15:
+		err = p.Scan(&eid, &gamemode, &dimension, &hashedSeed, &maxPlayers, &levelType, &viewDistance, &rdi, &ers)
+	default:
+		err = p.Scan(&eid, &gamemode, &dimension, &maxPlayers, &levelType, &viewDistance, &rdi)
+	}
 	if err != nil {
 		return err
 	}
 
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
 	c.EntityID = int(eid)
 	c.Gamemode = int(gamemode & 0x7)
 	c.Hardcore = gamemode&0x8 != 0
@@ -441,6 +593,36 @@ func handleJoinGamePacket(c *Client, p pk.Packet) error {
 	return nil
 }
 
+// worldNames is the array of world names in JoinGame packet since 1.16
+type worldNames []pk.Identifier
+
+// Decode implement net.packet.FieldDecoder
+func (w *worldNames) Decode(r pk.DecodeReader) error {
+	var count pk.VarInt
+	if err := count.Decode(r); err != nil {
+		return err
+	}
+	*w = make(worldNames, count)
+	for i := range *w {
+		if err := (*w)[i].Decode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dimensionID convert the dimension names since 1.16 to the IDs used before.
+func dimensionID(name string) int {
+	switch name {
+	case "minecraft:the_nether":
+		return -1
+	case "minecraft:the_end":
+		return 1
+	default:
+		return 0
+	}
+}
+
 // The PluginMessageData only used in recive PluginMessage packet.
 // When decode it, read to end.
 type pluginMessageData []byte
@@ -468,6 +650,7 @@ func handlePluginPacket(c *Client, p pk.Packet) error {
 	if err := p.Scan(&Channel, &Data); err != nil {
 		return err
 	}
+	c.Bus.Publish(PluginMessageEvent{Channel: string(Channel), Data: []byte(Data)})
 	if c.Events.PluginMessage != nil {
 		return c.Events.PluginMessage(string(Channel), []byte(Data))
 	}
@@ -480,7 +663,9 @@ func handleServerDifficultyPacket(c *Client, p pk.Packet) error {
 	if err != nil {
 		return err
 	}
+	c.stateMu.Lock()
 	c.Difficulty = int(difficulty)
+	c.stateMu.Unlock()
 	return nil
 }
 
@@ -505,9 +690,11 @@ func handlePlayerAbilitiesPacket(g *Client, p pk.Packet) error {
 	if err != nil {
 		return err
 	}
+	g.stateMu.Lock()
 	g.abilities.Flags = int8(flags)
 	g.abilities.FlyingSpeed = float32(flySpeed)
 	g.abilities.FieldofViewModifier = float32(viewMod)
+	g.stateMu.Unlock()
 	return nil
 }
 
@@ -516,10 +703,13 @@ func handleHeldItemPacket(c *Client, p pk.Packet) error {
 	if err := p.Scan(&hi); err != nil {
 		return err
 	}
+	c.stateMu.Lock()
 	c.HeldItem = int(hi)
+	c.stateMu.Unlock()
 
+	c.Bus.Publish(HeldItemChangeEvent{Slot: int(hi)})
 	if c.Events.HeldItemChange != nil {
-		return c.Events.HeldItemChange(c.HeldItem)
+		return c.Events.HeldItemChange(int(hi))
 	}
 	return nil
 }
@@ -531,23 +721,153 @@ func handleChunkDataPacket(c *Client, p pk.Packet) error {
 	var (
 		X, Z           pk.Int
 		FullChunk      pk.Boolean
+		IgnoreOldData  pk.Boolean
 		PrimaryBitMask pk.VarInt
 		Heightmaps     struct{}
 		Biomes         = biomesData{fullChunk: (*bool)(&FullChunk)}
 		Data           chunkData
 		BlockEntities  blockEntities
+		err            error
 	)
-	if err := p.Scan(&X, &Z, &FullChunk, &PrimaryBitMask, pk.NBT{V: &Heightmaps}, &Biomes, &Data, &BlockEntities); err != nil {
+	switch {
+	case c.Protocol >= protocol1_16:
+		err = p.Scan(&X, &Z, &FullChunk, &IgnoreOldData, &PrimaryBitMask, pk.NBT{V: &Heightmaps}, &Biomes, &Data, &BlockEntities)
+	case c.Protocol >= protocol1_15:
+		err = p.Scan(&X, &Z, &FullChunk, &PrimaryBitMask, pk.NBT{V: &Heightmaps}, &Biomes, &Data, &BlockEntities)
+	default: // biomes are at the end of Data before 1.15
+		err = p.Scan(&X, &Z, &FullChunk, &PrimaryBitMask, pk.NBT{V: &Heightmaps}, &Data, &BlockEntities)
+	}
+	if err != nil {
 		return err
 	}
-	chunk, err := world.DecodeChunkColumn(int32(PrimaryBitMask), Data)
+	format := world.ChunkFormat{Compact: c.Protocol >= protocol1_16}
+	chunk, err := format.Decode(int32(PrimaryBitMask), Data)
 	if err != nil {
 		return fmt.Errorf("decode chunk column fail: %w", err)
 	}
 
-	c.Wd.LoadChunk(int(X), int(Z), chunk)
+	if !FullChunk {
+		c.Wd.UpdateSections(int(X), int(Z), int32(PrimaryBitMask), chunk)
+	} else {
+		if c.Protocol >= protocol1_15 {
+			chunk.Biomes = Biomes.data[:]
+		}
+		c.Wd.LoadChunk(int(X), int(Z), chunk)
+	}
 
-	return err
+	for _, data := range BlockEntities {
+		e := world.NewBlockEntity(data)
+		c.Wd.SetBlockEntity(e)
+		c.Bus.Publish(BlockEntityChangeEvent{X: e.X, Y: e.Y, Z: e.Z, BlockEntity: e})
+	}
+	return nil
+}
+
+func handleUpdateBlockEntityPacket(c *Client, p pk.Packet) error {
+	if !c.settings.ReceiveMap && !c.Bus.Subscribed(EventBlockEntityChange) {
+		return nil
+	}
+	var (
+		pos    pk.Position
+		action pk.UnsignedByte
+		data   map[string]interface{}
+	)
+	err := p.Scan(&pos, &action, pk.NBT{V: &data})
+	// an empty NBT removes the block entity
+	if errors.Is(err, nbt.ErrEND) {
+		c.Wd.RemoveBlockEntity(pos.X, pos.Y, pos.Z)
+		c.Bus.Publish(BlockEntityChangeEvent{X: pos.X, Y: pos.Y, Z: pos.Z, Removed: true})
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	e := world.NewBlockEntity(data)
+	e.X, e.Y, e.Z = pos.X, pos.Y, pos.Z
+	c.Wd.SetBlockEntity(e)
+	c.Bus.Publish(BlockEntityChangeEvent{X: pos.X, Y: pos.Y, Z: pos.Z, BlockEntity: e})
+	return nil
+}
+
+func handleBlockActionPacket(c *Client, p pk.Packet) error {
+	if !c.Bus.Subscribed(EventBlockAction) {
+		return nil
+	}
+	var (
+		pos           pk.Position
+		action, param pk.UnsignedByte
+		blockType     pk.VarInt
+	)
+	if err := p.Scan(&pos, &action, &param, &blockType); err != nil {
+		return err
+	}
+	c.Bus.Publish(BlockActionEvent{
+		X: pos.X, Y: pos.Y, Z: pos.Z,
+		Action: int(action), Param: int(param), Block: int(blockType),
+	})
+	return nil
+}
+
+func handleUnloadChunkPacket(c *Client, p pk.Packet) error {
+	var x, z pk.Int
+	if err := p.Scan(&x, &z); err != nil {
+		return err
+	}
+	c.Wd.UnloadChunk(int(x), int(z))
+	return nil
+}
+
+// emptyLight is the light of the sections in the empty masks, shared by them.
+var emptyLight = make([]byte, 2048)
+
+func handleUpdateLightPacket(c *Client, p pk.Packet) error {
+	if !c.settings.ReceiveMap {
+		return nil
+	}
+	var (
+		x, z                         pk.VarInt
+		trustEdges                   pk.Boolean
+		skyMask, blockMask           pk.VarInt
+		emptySkyMask, emptyBlockMask pk.VarInt
+	)
+	r := bytes.NewReader(p.Data)
+	fields := []pk.FieldDecoder{&x, &z, &skyMask, &blockMask, &emptySkyMask, &emptyBlockMask}
+	if c.Protocol >= protocol1_16 {
+		fields = []pk.FieldDecoder{&x, &z, &trustEdges, &skyMask, &blockMask, &emptySkyMask, &emptyBlockMask}
+	}
+	for _, f := range fields {
+		if err := f.Decode(r); err != nil {
+			return err
+		}
+	}
+
+	// the bit 0 is the section below the world
+	readLight := func(mask, emptyMask pk.VarInt) (map[int][]byte, error) {
+		light := make(map[int][]byte)
+		for i := 0; i < 18; i++ {
+			switch {
+			case mask&(1<<uint(i)) != 0:
+				var arr chunkData // a VarInt length and the bytes
+				if err := arr.Decode(r); err != nil {
+					return nil, err
+				}
+				light[i-1] = arr
+			case emptyMask&(1<<uint(i)) != 0:
+				light[i-1] = emptyLight
+			}
+		}
+		return light, nil
+	}
+	sky, err := readLight(skyMask, emptySkyMask)
+	if err != nil {
+		return fmt.Errorf("read sky light fail: %w", err)
+	}
+	block, err := readLight(blockMask, emptyBlockMask)
+	if err != nil {
+		return fmt.Errorf("read block light fail: %w", err)
+	}
+	c.Wd.UpdateLight(int(x), int(z), sky, block)
+	return nil
 }
 
 type biomesData struct {
@@ -569,9 +889,7 @@ func (b *biomesData) Decode(r pk.DecodeReader) error {
 }
 
 type chunkData []byte
-type blockEntities []blockEntitie
-type blockEntitie struct {
-}
+type blockEntities []map[string]interface{}
 
 // Decode implement net.packet.FieldDecoder
 func (c *chunkData) Decode(r pk.DecodeReader) error {
@@ -615,6 +933,7 @@ func handlePlayerPositionAndLookPacket(c *Client, p pk.Packet) error {
 		return err
 	}
 
+	c.stateMu.Lock()
 	if flags&0x01 == 0 {
 		c.X = float64(x)
 	} else {
@@ -641,11 +960,21 @@ func handlePlayerPositionAndLookPacket(c *Client, p pk.Packet) error {
 		c.Pitch += float32(pitch)
 	}
 
+	// teleported
+	c.physics.ready = true
+	c.physics.velX, c.physics.velY, c.physics.velZ = 0, 0, 0
+	pos := playerPositionAndLookPacket(c)
+	c.stateMu.Unlock()
+
 	//Confirm
-	return c.conn.WritePacket(pk.Marshal(
-		data.TeleportConfirm,
+	err = c.sendPacket(pk.Marshal(
+		c.packetID("teleport_confirm"),
 		pk.VarInt(TeleportID),
 	))
+	if err != nil {
+		return err
+	}
+	return c.sendPacket(pos)
 }
 
 func handleKeepAlivePacket(c *Client, p pk.Packet) error {
@@ -654,17 +983,13 @@ func handleKeepAlivePacket(c *Client, p pk.Packet) error {
 		return err
 	}
 	//Response
-	return c.conn.WritePacket(pk.Marshal(
-		data.KeepAliveServerbound,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("keep_alive"),
 		KeepAliveID,
 	))
 }
 
 func handleWindowItemsPacket(c *Client, p pk.Packet) (err error) {
-	if c.Events.WindowsItem == nil {
-		return nil
-	}
-
 	r := bytes.NewReader(p.Data)
 	var (
 		windowID pk.Byte
@@ -684,26 +1009,142 @@ func handleWindowItemsPacket(c *Client, p pk.Packet) (err error) {
 		}
 		slots = append(slots, slot)
 	}
+	c.inv.mu.Lock()
+	for i, slot := range slots {
+		c.inv.setSlot(int(windowID), i, slot)
+	}
+	c.inv.mu.Unlock()
 
+	c.Bus.Publish(WindowItemsEvent{WindowID: byte(windowID), Slots: slots})
+	if c.Events.WindowsItem == nil {
+		return nil
+	}
 	return c.Events.WindowsItem(byte(windowID), slots)
 }
 
-func sendPlayerPositionAndLookPacket(c *Client) {
-	c.conn.WritePacket(pk.Marshal(
-		data.PlayerPositionAndLookServerbound,
+func handleOpenWindowPacket(c *Client, p pk.Packet) error {
+	var (
+		windowID, windowType pk.VarInt
+		title                chat.Message
+	)
+	if err := p.Scan(&windowID, &windowType, &title); err != nil {
+		return err
+	}
+	types := windowTypes1_14
+	if c.Protocol >= protocol1_16 {
+		types = windowTypes1_16
+	}
+	if windowType < 0 || int(windowType) >= len(types) {
+		return fmt.Errorf("bot: unknown window type %d", windowType)
+	}
+	w := &Window{ID: int(windowID), Type: types[windowType], Title: title}
+	w.Slots = make([]entity.Slot, w.Type.ContainerSlots())
+	c.inv.mu.Lock()
+	c.inv.openWindow(w)
+	c.inv.mu.Unlock()
+
+	c.Bus.Publish(OpenWindowEvent{WindowID: w.ID, Type: w.Type, Title: title})
+	return nil
+}
+
+func handleOpenHorseWindowPacket(c *Client, p pk.Packet) error {
+	var (
+		windowID pk.UnsignedByte
+		slots    pk.VarInt
+		entityID pk.Int
+	)
+	if err := p.Scan(&windowID, &slots, &entityID); err != nil {
+		return err
+	}
+	if slots < 0 || slots > maxHorseSlots {
+		return fmt.Errorf("bot: invalid horse window slots %d", slots)
+	}
+	w := &Window{ID: int(windowID), Type: HorseWindow, Slots: make([]entity.Slot, slots)}
+	c.inv.mu.Lock()
+	c.inv.openWindow(w)
+	c.inv.mu.Unlock()
+
+	c.Bus.Publish(OpenWindowEvent{WindowID: w.ID, Type: w.Type})
+	return nil
+}
+
+func handleCloseWindowPacket(c *Client, p pk.Packet) error {
+	var windowID pk.UnsignedByte
+	if err := p.Scan(&windowID); err != nil {
+		return err
+	}
+	c.inv.mu.Lock()
+	if c.inv.windowID() == int(windowID) {
+		c.inv.openWindow(nil)
+		c.inv.cursor = entity.Slot{}
+	}
+	c.inv.mu.Unlock()
+
+	c.Bus.Publish(CloseWindowEvent{WindowID: int(windowID)})
+	return nil
+}
+
+func handleWindowPropertyPacket(c *Client, p pk.Packet) error {
+	var (
+		windowID        pk.UnsignedByte
+		property, value pk.Short
+	)
+	if err := p.Scan(&windowID, &property, &value); err != nil {
+		return err
+	}
+	c.inv.mu.Lock()
+	defer c.inv.mu.Unlock()
+	if w := c.inv.open; w != nil && w.ID == int(windowID) {
+		if w.Properties == nil {
+			w.Properties = make(map[int]int)
+		}
+		w.Properties[int(property)] = int(value)
+	}
+	return nil
+}
+
+func handleConfirmTransactionPacket(c *Client, p pk.Packet) error {
+	var (
+		windowID pk.Byte
+		action   pk.Short
+		accepted pk.Boolean
+	)
+	if err := p.Scan(&windowID, &action, &accepted); err != nil {
+		return err
+	}
+	c.inv.mu.Lock()
+	c.inv.confirm(int(windowID), int16(action), bool(accepted))
+	c.inv.mu.Unlock()
+
+	if accepted {
+		return nil
+	}
+	// the server ignores the clicks until the client apologizes
+	return c.sendPacket(pk.Marshal(
+		c.packetID("confirm_transaction"),
+		windowID, action, pk.Boolean(true),
+	))
+}
+
+// playerPositionAndLookPacket record the position and the look as sent to the server and return the packet.
+// The caller must hold c.stateMu.
+func playerPositionAndLookPacket(c *Client) pk.Packet {
+	c.physics.lastX, c.physics.lastY, c.physics.lastZ = c.X, c.Y, c.Z
+	c.physics.lastYaw, c.physics.lastPitch = c.Yaw, c.Pitch
+	c.physics.lastOnGround = c.OnGround
+	c.physics.positionTicks = 0
+	return pk.Marshal(
+		c.packetID("player_position_and_look"),
 		pk.Double(c.X),
 		pk.Double(c.Y),
 		pk.Double(c.Z),
 		pk.Float(c.Yaw),
 		pk.Float(c.Pitch),
 		pk.Boolean(c.OnGround),
-	))
+	)
 }
 
 func handleSpawnObjectPacket(c *Client, p pk.Packet) error {
-	if c.Events.SpawnObject == nil {
-		return nil
-	}
 	var (
 		EntityID, Type                  pk.VarInt
 		UUID                            pk.UUID
@@ -716,24 +1157,274 @@ func handleSpawnObjectPacket(c *Client, p pk.Packet) error {
 	if err != nil {
 		return err
 	}
+	c.Wd.SetEntity(entity.Entity{
+		EntityID: int(EntityID), Type: int(Type), UUID: UUID,
+		X: float64(x), Y: float64(y), Z: float64(z),
+		Yaw:   world.AngleToDegrees(int8(Yaw)),
+		Pitch: world.AngleToDegrees(int8(Pitch)),
+		Data:  int(Data),
+		VelX:  float64(VelocityX) / 8000,
+		VelY:  float64(VelocityY) / 8000,
+		VelZ:  float64(VelocityZ) / 8000,
+	})
+
+	if c.Events.SpawnObject == nil && !c.Bus.Subscribed(EventSpawnObject) {
+		return nil
+	}
+	e := SpawnObjectEvent{
+		EntityID: int(EntityID), UUID: [16]byte(UUID), Type: int(Type),
+		X: float64(x), Y: float64(y), Z: float64(z),
+		Pitch: float32(Pitch), Yaw: float32(Yaw), Data: int(Data),
+		VelocityX: int16(VelocityX), VelocityY: int16(VelocityY), VelocityZ: int16(VelocityZ),
+	}
+	c.Bus.Publish(e)
+	if c.Events.SpawnObject == nil {
+		return nil
+	}
 	return c.Events.SpawnObject(
-		int(EntityID), [16]byte(UUID), int(Type),
-		float64(x), float64(y), float64(z), float32(Pitch), float32(Yaw), int(Data),
-		int16(VelocityX), int16(VelocityY), int16(VelocityZ))
+		e.EntityID, e.UUID, e.Type,
+		e.X, e.Y, e.Z, e.Pitch, e.Yaw, e.Data,
+		e.VelocityX, e.VelocityY, e.VelocityZ)
 }
 
-func handleEntityRelativeMove(c *Client, p pk.Packet) error {
-	if c.Events.EntityRelativeMove == nil {
-		return nil
-	}
+func handleEntityRelativeMove(c *Client, p pk.Packet, look bool) error {
 	var (
 		EntityID               pk.VarInt
 		DeltaX, DeltaY, DeltaZ pk.Short
+		Yaw, Pitch             pk.Angle
 		OnGround               pk.Boolean
 	)
-	err := p.Scan(&EntityID, &DeltaX, &DeltaY, &DeltaZ, &OnGround)
+	var err error
+	if look {
+		err = p.Scan(&EntityID, &DeltaX, &DeltaY, &DeltaZ, &Yaw, &Pitch, &OnGround)
+	} else {
+		err = p.Scan(&EntityID, &DeltaX, &DeltaY, &DeltaZ, &OnGround)
+	}
 	if err != nil {
 		return err
 	}
+	c.Wd.UpdateEntity(int32(EntityID), func(e *entity.Entity) {
+		e.X += float64(DeltaX) / 4096
+		e.Y += float64(DeltaY) / 4096
+		e.Z += float64(DeltaZ) / 4096
+		if look {
+			e.Yaw = world.AngleToDegrees(int8(Yaw))
+			e.Pitch = world.AngleToDegrees(int8(Pitch))
+		}
+		e.OnGround = bool(OnGround)
+	})
+
+	if c.Events.EntityRelativeMove == nil && !c.Bus.Subscribed(EventEntityRelativeMove) {
+		return nil
+	}
+	c.Bus.Publish(EntityRelativeMoveEvent{
+		EntityID: int(EntityID),
+		DeltaX:   int(DeltaX), DeltaY: int(DeltaY), DeltaZ: int(DeltaZ),
+		OnGround: bool(OnGround),
+	})
+	if c.Events.EntityRelativeMove == nil {
+		return nil
+	}
 	return c.Events.EntityRelativeMove(int(EntityID), int(DeltaX), int(DeltaY), int(DeltaZ), bool(OnGround))
 }
+
+func handleEntityLookPacket(c *Client, p pk.Packet) error {
+	var (
+		EntityID   pk.VarInt
+		Yaw, Pitch pk.Angle
+		OnGround   pk.Boolean
+	)
+	if err := p.Scan(&EntityID, &Yaw, &Pitch, &OnGround); err != nil {
+		return err
+	}
+	c.Wd.UpdateEntity(int32(EntityID), func(e *entity.Entity) {
+		e.Yaw = world.AngleToDegrees(int8(Yaw))
+		e.Pitch = world.AngleToDegrees(int8(Pitch))
+		e.OnGround = bool(OnGround)
+	})
+	return nil
+}
+
+func handleEntityHeadLookPacket(c *Client, p pk.Packet) error {
+	var (
+		EntityID pk.VarInt
+		HeadYaw  pk.Angle
+	)
+	if err := p.Scan(&EntityID, &HeadYaw); err != nil {
+		return err
+	}
+	c.Wd.UpdateEntity(int32(EntityID), func(e *entity.Entity) {
+		e.HeadYaw = world.AngleToDegrees(int8(HeadYaw))
+	})
+	return nil
+}
+
+func handleEntityTeleportPacket(c *Client, p pk.Packet) error {
+	var (
+		EntityID   pk.VarInt
+		X, Y, Z    pk.Double
+		Yaw, Pitch pk.Angle
+		OnGround   pk.Boolean
+	)
+	if err := p.Scan(&EntityID, &X, &Y, &Z, &Yaw, &Pitch, &OnGround); err != nil {
+		return err
+	}
+	c.Wd.UpdateEntity(int32(EntityID), func(e *entity.Entity) {
+		e.X, e.Y, e.Z = float64(X), float64(Y), float64(Z)
+		e.Yaw = world.AngleToDegrees(int8(Yaw))
+		e.Pitch = world.AngleToDegrees(int8(Pitch))
+		e.OnGround = bool(OnGround)
+	})
+	return nil
+}
+
+func handleEntityVelocityPacket(c *Client, p pk.Packet) error {
+	var (
+		EntityID                        pk.VarInt
+		VelocityX, VelocityY, VelocityZ pk.Short
+	)
+	if err := p.Scan(&EntityID, &VelocityX, &VelocityY, &VelocityZ); err != nil {
+		return err
+	}
+	c.Wd.UpdateEntity(int32(EntityID), func(e *entity.Entity) {
+		e.VelX = float64(VelocityX) / 8000
+		e.VelY = float64(VelocityY) / 8000
+		e.VelZ = float64(VelocityZ) / 8000
+	})
+	return nil
+}
+
+func handleEntityEquipmentPacket(c *Client, p pk.Packet) error {
+	var EntityID pk.VarInt
+	r := bytes.NewReader(p.Data)
+	if err := EntityID.Decode(r); err != nil {
+		return err
+	}
+
+	type equipment struct {
+		slot int
+		item entity.Slot
+	}
+	var list []equipment
+	if c.Protocol >= protocol1_16 {
+		// an array of the slots, the top bit is set if another entry follows
+		for {
+			var slot pk.Byte
+			var item entity.Slot
+			if err := slot.Decode(r); err != nil {
+				return err
+			}
+			if err := item.Decode(r); err != nil {
+				return err
+			}
+			list = append(list, equipment{int(slot) & 0x7F, item})
+			if slot >= 0 {
+				break
+			}
+		}
+	} else {
+		var slot pk.VarInt
+		var item entity.Slot
+		if err := slot.Decode(r); err != nil {
+			return err
+		}
+		if err := item.Decode(r); err != nil {
+			return err
+		}
+		list = append(list, equipment{int(slot), item})
+	}
+
+	c.Wd.UpdateEntity(int32(EntityID), func(e *entity.Entity) {
+		for _, v := range list {
+			if v.slot >= 0 && v.slot < len(e.Equipment) {
+				e.Equipment[v.slot] = v.item
+			}
+		}
+	})
+	return nil
+}
+
+func handleSpawnPlayerPacket(c *Client, p pk.Packet) error {
+	var (
+		EntityID   pk.VarInt
+		UUID       pk.UUID
+		X, Y, Z    pk.Double
+		Yaw, Pitch pk.Angle
+	)
+	fields := []pk.FieldDecoder{&EntityID, &UUID, &X, &Y, &Z, &Yaw, &Pitch}
+	var meta entity.Metadata
+	if c.Protocol < protocol1_15 {
+		fields = append(fields, metadataField{c.Protocol, &meta})
+	}
+	if err := p.Scan(fields...); err != nil {
+		return err
+	}
+	c.Wd.SetEntity(entity.Entity{
+		EntityID: int(EntityID), Type: c.Wd.EntityTypeID("minecraft:player"), UUID: UUID, Metadata: meta,
+		X: float64(X), Y: float64(Y), Z: float64(Z),
+		Yaw:   world.AngleToDegrees(int8(Yaw)),
+		Pitch: world.AngleToDegrees(int8(Pitch)),
+	})
+	return nil
+}
+
+func handleEntityMetadata(c *Client, p pk.Packet) error {
+	var (
+		EntityID pk.VarInt
+		meta     entity.Metadata
+	)
+	if err := p.Scan(&EntityID, metadataField{c.Protocol, &meta}); err != nil {
+		return err
+	}
+	c.Wd.UpdateEntity(int32(EntityID), func(e *entity.Entity) {
+		e.Metadata = e.Metadata.Merge(meta)
+	})
+	return nil
+}
+
+// metadataField decodes the entity metadata as a field of the packets.
+type metadataField struct {
+	protocol int
+	meta     *entity.Metadata
+}
+
+func (f metadataField) Decode(r pk.DecodeReader) (err error) {
+	*f.meta, err = entity.ReadMetadata(r, f.protocol)
+	return
+}
+
+func handleSpawnExperienceOrbPacket(c *Client, p pk.Packet) error {
+	var (
+		EntityID pk.VarInt
+		X, Y, Z  pk.Double
+		Count    pk.Short
+	)
+	if err := p.Scan(&EntityID, &X, &Y, &Z, &Count); err != nil {
+		return err
+	}
+	c.Wd.SetEntity(entity.Entity{
+		EntityID: int(EntityID), Type: c.Wd.EntityTypeID("minecraft:experience_orb"),
+		X: float64(X), Y: float64(Y), Z: float64(Z),
+		Data: int(Count),
+	})
+	return nil
+}
+
+func handleSpawnPaintingPacket(c *Client, p pk.Packet) error {
+	var (
+		EntityID  pk.VarInt
+		UUID      pk.UUID
+		Motive    pk.VarInt
+		Location  pk.Position
+		Direction pk.Byte
+	)
+	if err := p.Scan(&EntityID, &UUID, &Motive, &Location, &Direction); err != nil {
+		return err
+	}
+	c.Wd.SetEntity(entity.Entity{
+		EntityID: int(EntityID), Type: c.Wd.EntityTypeID("minecraft:painting"), UUID: UUID,
+		X: float64(Location.X) + 0.5, Y: float64(Location.Y) + 0.5, Z: float64(Location.Z) + 0.5,
+		Data: int(Motive),
+	})
+	return nil
+}
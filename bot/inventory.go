@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Tnze/go-mc/bot/world/entity"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// ErrItemNotFound is returned by the inventory helpers if the item isn't in the window.
+var ErrItemNotFound = errors.New("bot: item not found")
+
+// Window return a copy of the window in focus,
+// which is the opened container, or the player's inventory if none is opened.
+// The clicks are applied to it before the server confirms them.
+func (c *Client) Window() Window {
+	c.inv.mu.Lock()
+	defer c.inv.mu.Unlock()
+	return c.inv.window()
+}
+
+// Inventory return a copy of the player's inventory, even if a container is opened.
+func (c *Client) Inventory() Window {
+	c.inv.mu.Lock()
+	defer c.inv.mu.Unlock()
+	return Window{Type: InventoryWindow, Slots: append([]entity.Slot(nil), c.inv.slots[:]...)}
+}
+
+// Cursor return the item held by the mouse cursor in the window.
+func (c *Client) Cursor() entity.Slot {
+	c.inv.mu.Lock()
+	defer c.inv.mu.Unlock()
+	return c.inv.cursor
+}
+
+// MainHandItem return the item in the selected hotbar slot.
+func (c *Client) MainHandItem() entity.Slot {
+	held := c.GetPlayer().HeldItem
+	c.inv.mu.Lock()
+	defer c.inv.mu.Unlock()
+	return c.inv.slots[36+held]
+}
+
+// ClickWindow clicks the slot of the window in focus, see ClickMode for the buttons.
+// Use SlotOutside to click outside the window.
+//
+// The click is applied to the Window immediately. If the server rejects it,
+// the Window is rolled back and then the server sends the correct slots.
+func (c *Client) ClickWindow(slot, button int, mode ClickMode) error {
+	creative := c.GetPlayInfo().Gamemode == 1
+	return c.withInventory(func() error {
+		return c.clickWindow(slot, button, mode, creative)
+	})
+}
+
+// withInventory calls f with c.inv.mu locked, and sends the packets queued by f after unlocking it,
+// so a slow connection doesn't block the readers of the inventory and the packet handlers.
+// The packets are sent even if f returns an error, because the clicks before it are applied.
+// c.inv.sendMu keeps the clicks of other goroutines after these ones.
+func (c *Client) withInventory(f func() error) error {
+	c.inv.sendMu.Lock()
+	defer c.inv.sendMu.Unlock()
+
+	c.inv.mu.Lock()
+	err := f()
+	packets := c.inv.queued
+	c.inv.queued = nil
+	c.inv.mu.Unlock()
+
+	for _, p := range packets {
+		if err := c.sendPacket(p); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// clickWindow applies the click and queues the Click Window packet.
+// It must be called in withInventory.
+func (c *Client) clickWindow(slot, button int, mode ClickMode, creative bool) error {
+	if c.inv.slot(slot) == nil && slot != SlotOutside {
+		return fmt.Errorf("bot: invalid slot %d", slot)
+	}
+	t, clicked := c.inv.click(slot, button, mode, creative)
+	c.inv.queued = append(c.inv.queued, pk.Marshal(
+		c.packetID("click_window"),
+		pk.UnsignedByte(t.windowID),
+		pk.Short(slot),
+		pk.Byte(button),
+		pk.Short(t.action),
+		pk.VarInt(mode),
+		clicked,
+	))
+	return nil
+}
+
+// DragItems spreads the items on the cursor to the slots.
+// button is 0 for splitting evenly, 1 for one item each slot
+// and 2 for a full stack each slot, which is only allowed in creative mode.
+func (c *Client) DragItems(button int, slots ...int) error {
+	if button < 0 || button > 2 {
+		return fmt.Errorf("bot: invalid drag button %d", button)
+	}
+	creative := c.GetPlayInfo().Gamemode == 1
+	return c.withInventory(func() error {
+		if err := c.clickWindow(SlotOutside, button<<2, ClickDrag, creative); err != nil {
+			return err
+		}
+		for _, s := range slots {
+			if err := c.clickWindow(s, button<<2|1, ClickDrag, creative); err != nil {
+				return err
+			}
+		}
+		return c.clickWindow(SlotOutside, button<<2|2, ClickDrag, creative)
+	})
+}
+
+// CloseWindow closes the opened container, or the player's inventory.
+func (c *Client) CloseWindow() error {
+	return c.withInventory(func() error {
+		id := c.inv.windowID()
+		c.inv.openWindow(nil)
+		c.inv.cursor = entity.Slot{} // the server puts it back to the inventory
+		c.inv.queued = append(c.inv.queued, pk.Marshal(
+			c.packetID("close_window"),
+			pk.UnsignedByte(id),
+		))
+		return nil
+	})
+}
+
+// SetCreativeSlot sets the slot of the player's inventory in creative mode.
+// An empty Slot deletes the item, and SlotOutside drops it.
+func (c *Client) SetCreativeSlot(slot int, item entity.Slot) error {
+	return c.withInventory(func() error {
+		if slot >= 0 && slot < len(c.inv.slots) {
+			c.inv.slots[slot] = item
+		} else if slot != SlotOutside {
+			return fmt.Errorf("bot: invalid slot %d", slot)
+		}
+		c.inv.queued = append(c.inv.queued, pk.Marshal(
+			c.packetID("creative_inventory_action"),
+			pk.Short(slot),
+			item,
+		))
+		return nil
+	})
+}
+
+// FindItem return the first slot holding the item in the window in focus.
+func (c *Client) FindItem(itemID int32) (slot int, ok bool) {
+	return c.Window().FindItem(itemID)
+}
+
+// MoveItem moves the stack in the slot from to the slot to in the window in focus.
+// If the slot to holds other items, they are swapped.
+// If the slot to can't hold all the items, the rest are left in the slot from.
+func (c *Client) MoveItem(from, to int) error {
+	return c.withInventory(func() error {
+		if s := c.inv.slot(from); s == nil || !s.Present {
+			return fmt.Errorf("bot: no item in slot %d", from)
+		}
+		if c.inv.cursor.Present {
+			return errors.New("bot: the cursor isn't empty")
+		}
+		for _, slot := range []int{from, to} {
+			if err := c.clickWindow(slot, 0, ClickPickup, false); err != nil {
+				return err
+			}
+		}
+		if c.inv.cursor.Present {
+			return c.clickWindow(from, 0, ClickPickup, false)
+		}
+		return nil
+	})
+}
+
+// EquipToHotbar moves the item to the hotbar slot (0-8) and selects it.
+func (c *Client) EquipToHotbar(itemID int32, hotbar int) error {
+	if hotbar < 0 || hotbar > 8 {
+		return fmt.Errorf("bot: invalid hotbar slot %d", hotbar)
+	}
+	if err := c.moveToHotbar(itemID, hotbar); err != nil {
+		return err
+	}
+	return c.SelectItem(hotbar)
+}
+
+func (c *Client) moveToHotbar(itemID int32, hotbar int) error {
+	return c.withInventory(func() error {
+		w := c.inv.window()
+		i := w.Hotbar(hotbar)
+		if i < 0 {
+			return errors.New("bot: the window doesn't show the hotbar")
+		}
+		if h := w.Slots[i]; h.Present && h.ItemID == itemID {
+			return nil
+		}
+		slot, ok := w.FindItem(itemID)
+		if !ok {
+			return ErrItemNotFound
+		}
+		return c.clickWindow(slot, hotbar, ClickNumberKey, false)
+	})
+}
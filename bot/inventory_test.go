@@ -0,0 +1,183 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/bot/world/entity"
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+const (
+	stoneItem   = 1
+	dirtItem    = 9
+	pickaxeItem = 543 // diamond pickaxe
+)
+
+func item(id int32, count int8) []pk.FieldEncoder {
+	return []pk.FieldEncoder{pk.Boolean(true), pk.VarInt(id), pk.Byte(count), pk.Byte(0)}
+}
+
+func TestClient_ClickWindow(t *testing.T) {
+	c, s, closeFunc := newTestClient(readPackets)
+	defer closeFunc()
+	packets := data.Packets(ProtocolVersion, data.Play, data.Clientbound)
+	handle := func(name string, fields ...pk.FieldEncoder) {
+		t.Helper()
+		id, _ := packets.ID(name)
+		if _, err := c.handlePacket(pk.Marshal(id, fields...)); err != nil {
+			t.Fatalf("handle %s: %v", name, err)
+		}
+	}
+	click := func(slot, button int, mode ClickMode) {
+		t.Helper()
+		if err := c.ClickWindow(slot, button, mode); err != nil {
+			t.Fatal(err)
+		}
+		p := <-s.Sent
+		var action pk.Short
+		if p.ID != c.packetID("click_window") {
+			t.Fatalf("unexpected packet 0x%X", p.ID)
+		}
+		if err := p.Scan(new(pk.UnsignedByte), new(pk.Short), new(pk.Byte), &action); err != nil {
+			t.Fatal(err)
+		}
+		if action != pk.Short(c.inv.action) {
+			t.Errorf("action number %d, want %d", action, c.inv.action)
+		}
+	}
+	count := func(slot int) int8 {
+		return c.Window().Slots[slot].Count
+	}
+
+	fields := []pk.FieldEncoder{pk.Byte(0), pk.Short(46)}
+	for i := 0; i < 46; i++ {
+		switch i {
+		case 9:
+			fields = append(fields, item(dirtItem, 5)...)
+		case 10:
+			fields = append(fields, item(pickaxeItem, 1)...)
+		case 36:
+			fields = append(fields, item(stoneItem, 10)...)
+		default:
+			fields = append(fields, pk.Boolean(false))
+		}
+	}
+	handle("window_items", fields...)
+
+	click(36, 0, ClickPickup) // pick up 10 stones
+	click(37, 1, ClickPickup) // put 1 stone
+	if cur := c.Cursor(); cur.ItemID != stoneItem || cur.Count != 9 || count(37) != 1 || count(36) != 0 {
+		t.Fatalf("wrong cursor %+v or slots %d, %d", cur, count(36), count(37))
+	}
+	click(37, 0, ClickPickup) // put all back
+	handle("confirm_transaction", pk.Byte(0), pk.Short(3), pk.Boolean(false))
+	if p := <-s.Sent; p.ID != c.packetID("confirm_transaction") {
+		t.Errorf("the rejected transaction isn't apologized: 0x%X", p.ID)
+	}
+	if cur := c.Cursor(); cur.Count != 9 || count(37) != 1 {
+		t.Errorf("the rejected click isn't rolled back: %+v, %d", cur, count(37))
+	}
+	handle("confirm_transaction", pk.Byte(0), pk.Short(1), pk.Boolean(true))
+	if len(c.inv.pending) != 1 {
+		t.Errorf("pending transactions: %v", c.inv.pending)
+	}
+
+	c.DragItems(0, 38, 39, 40)
+	for i := 0; i < 5; i++ {
+		<-s.Sent
+	}
+	if count(38) != 3 || count(39) != 3 || count(40) != 3 || c.Cursor().Present {
+		t.Errorf("wrong drag: %d, %d, %d, %+v", count(38), count(39), count(40), c.Cursor())
+	}
+
+	handle("open_window", pk.VarInt(1), pk.VarInt(2), pk.String(`{"text":"Chest"}`))
+	if w := c.Window(); w.Type != Generic9x3 || len(w.Slots) != 27+36 || w.Slots[27].ItemID != dirtItem {
+		t.Fatalf("wrong chest window: %v %d", w.Type, len(w.Slots))
+	}
+	click(27+27+2, 0, ClickShift) // the hotbar slot 2, 3 stones
+	if count(0) != 3 || count(27+27+2) != 0 {
+		t.Errorf("wrong shift-click: %d, %d", count(0), count(27+27+2))
+	}
+	handle("set_slot", pk.Byte(1), pk.Short(5), pk.Boolean(true), pk.VarInt(stoneItem), pk.Byte(64), pk.Byte(0))
+	if count(5) != 64 {
+		t.Error("the slot of the chest isn't set")
+	}
+
+	if err := c.EquipToHotbar(pickaxeItem, 4); err != nil {
+		t.Fatal(err)
+	}
+	<-s.Sent
+	<-s.Sent
+	if s := c.MainHandItem(); s.ItemID != pickaxeItem {
+		t.Errorf("the pickaxe isn't held: %+v", s)
+	}
+
+	handle("close_window", pk.UnsignedByte(1))
+	if w := c.Window(); w.Type != InventoryWindow || w.Slots[40].ItemID != pickaxeItem {
+		t.Errorf("wrong inventory: %v", w)
+	}
+	if err := c.MoveItem(40, 9); err != nil { // swap with the dirt
+		t.Fatal(err)
+	}
+	if w := c.Window(); w.Slots[9].ItemID != pickaxeItem || w.Slots[40].ItemID != dirtItem || c.Cursor().Present {
+		t.Errorf("items not swapped: %v, %v", w.Slots[9], w.Slots[40])
+	}
+}
+
+func TestInventory_collect(t *testing.T) {
+	var inv inventory
+	inv.slots[9] = entity.Slot{Present: true, ItemID: stoneItem, Count: 64}
+	inv.slots[10] = entity.Slot{Present: true, ItemID: stoneItem, Count: 20}
+	inv.slots[11] = entity.Slot{Present: true, ItemID: dirtItem, Count: 20}
+	inv.cursor = entity.Slot{Present: true, ItemID: stoneItem, Count: 30}
+
+	inv.click(12, 0, ClickDouble, false)
+	if inv.cursor.Count != 64 || inv.slots[10].Present || inv.slots[9].Count != 50 || inv.slots[11].Count != 20 {
+		t.Errorf("wrong double click: %+v, %v", inv.cursor, inv.slots[9:12])
+	}
+}
+
+func TestClient_ClickWindow_stalledConn(t *testing.T) {
+	c, _, closeFunc := newTestClient(readNothing) // nothing reads from the server side
+	defer closeFunc()
+	c.inv.slots[36] = entity.Slot{Present: true, ItemID: stoneItem, Count: 1}
+
+	done := make(chan error)
+	go func() { done <- c.ClickWindow(36, 0, ClickPickup) }()
+	picked := make(chan struct{})
+	go func() {
+		for !c.Cursor().Present {
+			time.Sleep(time.Millisecond)
+		}
+		close(picked)
+	}()
+	select {
+	case <-picked:
+	case <-time.After(time.Second):
+		t.Fatal("the inventory is locked while sending")
+	}
+
+	closeFunc()
+	if err := <-done; err == nil {
+		t.Error("sending to a closed connection succeeded")
+	}
+}
+
+func TestClient_openHorseWindow(t *testing.T) {
+	c, _, closeFunc := newTestClient(discardPackets)
+	defer closeFunc()
+	id, _ := data.Packets(ProtocolVersion, data.Play, data.Clientbound).ID("open_horse_window")
+	for _, slots := range []int32{-1, 1 << 30} {
+		if _, err := c.handlePacket(pk.Marshal(id, pk.UnsignedByte(1), pk.VarInt(slots), pk.Int(10))); err == nil {
+			t.Errorf("opened a horse window of %d slots", slots)
+		}
+	}
+	if _, err := c.handlePacket(pk.Marshal(id, pk.UnsignedByte(1), pk.VarInt(17), pk.Int(10))); err != nil {
+		t.Fatal(err)
+	}
+	if w := c.Window(); w.Type != HorseWindow || w.ID != 1 {
+		t.Errorf("wrong horse window: %v %d", w.Type, w.ID)
+	}
+}
@@ -4,17 +4,15 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/md5"
 	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha1"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
 
+	mcnet "github.com/Tnze/go-mc/net"
 	"github.com/Tnze/go-mc/net/CFB8"
 	pk "github.com/Tnze/go-mc/net/packet"
 	"github.com/google/uuid"
@@ -29,20 +27,21 @@ type Auth struct {
 
 // OfflineUUID return the UUID from player name in offline mode
 func OfflineUUID(name string) uuid.UUID {
-	var version = 3
-	h := md5.New()
-	h.Reset()
-	h.Write([]byte("OfflinePlayer:" + name))
-	s := h.Sum(nil)
-	var uuid uuid.UUID
-	copy(uuid[:], s)
-	uuid[6] = (uuid[6] & 0x0f) | uint8((version&0xf)<<4)
-	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
-	return uuid
+	return mcnet.OfflineUUID(name)
 }
 
 // 加密请求
 func handleEncryptionRequest(c *Client, pack pk.Packet) error {
+	return HandleEncryptionRequest(c.conn, c.Auth, pack)
+}
+
+// HandleEncryptionRequest answers the Encryption Request packet received in login state.
+// It authenticates the account with Mojang, sends the Encryption Response
+// and enables encryption on conn.
+//
+// JoinServer does this for you. It's only useful when you handle the login by yourself,
+// such as in a proxy.
+func HandleEncryptionRequest(conn *mcnet.Conn, auth Auth, pack pk.Packet) error {
 	//创建AES对称加密密钥
 	key, encoStream, decoStream := newSymmetricEncryption()
 
@@ -51,7 +50,7 @@ func handleEncryptionRequest(c *Client, pack pk.Packet) error {
 	if err := pack.Scan(&er); err != nil {
 		return err
 	}
-	err := loginAuth(c.AsTk, c.Name, c.Auth.UUID, key, er) //向Mojang验证
+	err := loginAuth(auth.AsTk, auth.Name, auth.UUID, key, er) //向Mojang验证
 	if err != nil {
 		return fmt.Errorf("login fail: %v", err)
 	}
@@ -62,13 +61,13 @@ func handleEncryptionRequest(c *Client, pack pk.Packet) error {
 	if err != nil {
 		return fmt.Errorf("gen encryption key response fail: %v", err)
 	}
-	err = c.conn.WritePacket(p)
+	err = conn.WritePacket(p)
 	if err != nil {
 		return err
 	}
 
 	// 设置连接加密
-	c.conn.SetCipher(encoStream, decoStream)
+	conn.SetCipher(encoStream, decoStream)
 	return nil
 }
 
@@ -108,47 +107,6 @@ func (e *encryptionRequest) Decode(r pk.DecodeReader) error {
 	return nil
 }
 
-// authDigest computes a special SHA-1 digest required for Minecraft web
-// authentication on Premium servers (online-mode=true).
-// Source: http://wiki.vg/Protocol_Encryption#Server
-//
-// Also many, many thanks to SirCmpwn and his wonderful gist (C#):
-// https://gist.github.com/SirCmpwn/404223052379e82f91e6
-func authDigest(serverID string, sharedSecret, publicKey []byte) string {
-	h := sha1.New()
-	h.Write([]byte(serverID))
-	h.Write(sharedSecret)
-	h.Write(publicKey)
-	hash := h.Sum(nil)
-
-	// Check for negative hashes
-	negative := (hash[0] & 0x80) == 0x80
-	if negative {
-		hash = twosComplement(hash)
-	}
-
-	// Trim away zeroes
-	res := strings.TrimLeft(fmt.Sprintf("%x", hash), "0")
-	if negative {
-		res = "-" + res
-	}
-
-	return res
-}
-
-// little endian
-func twosComplement(p []byte) []byte {
-	carry := true
-	for i := len(p) - 1; i >= 0; i-- {
-		p[i] = byte(^p[i])
-		if carry {
-			carry = p[i] == 0xff
-			p[i]++
-		}
-	}
-	return p
-}
-
 type profile struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -161,7 +119,7 @@ type request struct {
 }
 
 func loginAuth(AsTk, name, UUID string, shareSecret []byte, er encryptionRequest) error {
-	digest := authDigest(er.ServerID, shareSecret, er.PublicKey)
+	digest := mcnet.AuthDigest(er.ServerID, shareSecret, er.PublicKey)
 
 	client := http.Client{}
 	requestPacket, err := json.Marshal(
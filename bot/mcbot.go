@@ -5,9 +5,12 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"strconv"
 
+	"github.com/Tnze/go-mc/data"
 	mcnet "github.com/Tnze/go-mc/net"
 	pk "github.com/Tnze/go-mc/net/packet"
 )
@@ -16,37 +19,62 @@ import (
 const ProtocolVersion = 578
 
 // JoinServer connect a Minecraft server for playing the game.
+//
+// If c.Protocol is zero, the server is pinged first
+// for negotiating the protocol version.
 func (c *Client) JoinServer(addr string, port int) (err error) {
-	conn, err := net.Dial("tcp", fmt.Sprintf("%s:%d", addr, port))
-	if err != nil {
-		err = fmt.Errorf("bot: connect server fail: %v", err)
-		return
-	}
-	return c.join(conn, addr, port)
+	return c.JoinServerWithDialer(&net.Dialer{}, addr, port)
+}
+
+// JoinServerContext is like JoinServer but aborts when ctx is done.
+// In that case, the returned error matches ErrCanceled.
+func (c *Client) JoinServerContext(ctx context.Context, addr string, port int) error {
+	return c.JoinServerWithDialerContext(ctx, &net.Dialer{}, addr, port)
 }
 
 // JoinServerWithDialer is similar to JoinServer but using a Dialer.
 func (c *Client) JoinServerWithDialer(d Dialer, addr string, port int) (err error) {
-	conn, err := d.Dial("tcp", fmt.Sprintf("%s:%d", addr, port))
+	return c.JoinServerWithDialerContext(context.Background(), d, addr, port)
+}
+
+// JoinServerWithDialerContext is like JoinServerWithDialer but aborts when ctx is done.
+// The dialing is also canceled if d implements ContextDialer.
+func (c *Client) JoinServerWithDialerContext(ctx context.Context, d Dialer, addr string, port int) (err error) {
+	dial := func() (net.Conn, error) {
+		return dialContext(ctx, d, net.JoinHostPort(addr, strconv.Itoa(port)))
+	}
+	if err := c.negotiate(ctx, dial, addr, port); err != nil {
+		return contextError(ctx, err)
+	}
+
+	conn, err := dial()
 	if err != nil {
 		err = fmt.Errorf("bot: connect server fail: %v", err)
-		return
+		return contextError(ctx, err)
 	}
-	return c.join(conn, addr, port)
+
+	stop := c.watcher.watch(ctx, conn)
+	err = c.join(conn, addr, port)
+	stop()
+	if err != nil {
+		conn.Close()
+	}
+	return contextError(ctx, err)
 }
 
 // JoinConn join a Minecraft server through a connection for playing the game.
 func (c *Client) join(conn net.Conn, addr string, port int) (err error) {
 	//Set Conn
 	c.conn = mcnet.WrapConn(conn)
+	c.setTables()
 
 	//Handshake
-	err = c.conn.WritePacket(
+	err = c.sendPacket(
 		//Handshake Packet
 		pk.Marshal(
-			0x00,                       //Handshake packet ID
-			pk.VarInt(ProtocolVersion), //Protocol version
-			pk.String(addr),            //Server's address
+			packetID(c.Protocol, data.Handshaking, "handshake"),
+			pk.VarInt(c.Protocol), //Protocol version
+			pk.String(addr),       //Server's address
 			pk.UnsignedShort(port),
 			pk.Byte(2),
 		))
@@ -56,9 +84,9 @@ func (c *Client) join(conn net.Conn, addr string, port int) (err error) {
 	}
 
 	//Login
-	err = c.conn.WritePacket(
+	err = c.sendPacket(
 		//LoginStart Packet
-		pk.Marshal(0, pk.String(c.Name)))
+		pk.Marshal(packetID(c.Protocol, data.Login, "login_start"), pk.String(c.Name)))
 	if err != nil {
 		err = fmt.Errorf("bot: send login start packect fail: %v", err)
 		return
@@ -74,8 +102,12 @@ func (c *Client) join(conn net.Conn, addr string, port int) (err error) {
 		}
 
 		//Handle Packet
-		switch pack.ID {
-		case 0x00: //Disconnect
+		name, ok := data.Packets(c.Protocol, data.Login, data.Clientbound).Name(pack.ID)
+		if !ok {
+			return fmt.Errorf("bot: %w in login state: 0x%02X", data.ErrIllegalPacket, pack.ID)
+		}
+		switch name {
+		case "disconnect":
 			var reason pk.String
 			err = pack.Scan(&reason)
 			if err != nil {
@@ -84,21 +116,21 @@ func (c *Client) join(conn net.Conn, addr string, port int) (err error) {
 				err = fmt.Errorf("bot: connect disconnected by server: %s", reason)
 			}
 			return
-		case 0x01: //Encryption Request
+		case "encryption_request":
 			if err := handleEncryptionRequest(c, pack); err != nil {
 				return fmt.Errorf("bot: encryption fail: %v", err)
 			}
-		case 0x02: //Login Success
+		case "login_success":
 			// uuid, l := pk.UnpackString(pack.Data)
 			// name, _ := unpackString(pack.Data[l:])
 			return //switches the connection state to PLAY.
-		case 0x03: //Set Compression
+		case "set_compression":
 			var threshold pk.VarInt
 			if err := pack.Scan(&threshold); err != nil {
 				return fmt.Errorf("bot: set compression fail: %v", err)
 			}
 			c.conn.SetThreshold(int(threshold))
-		case 0x04: //Login Plugin Request
+		case "login_plugin_request":
 			if err := handlePluginPacket(c, pack); err != nil {
 				return fmt.Errorf("bot: handle plugin packet fail: %v", err)
 			}
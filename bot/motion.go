@@ -5,7 +5,6 @@ import (
 	"math"
 	"strconv"
 
-	"github.com/Tnze/go-mc/data"
 	pk "github.com/Tnze/go-mc/net/packet"
 )
 
@@ -13,16 +12,16 @@ import (
 // hand could be one of 0: main hand, 1: off hand.
 // It's just animation.
 func (c *Client) SwingArm(hand int) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.AnimationServerbound,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("animation"),
 		pk.VarInt(hand),
 	))
 }
 
 // Respawn the player when it was dead.
 func (c *Client) Respawn() error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.ClientStatus,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("client_status"),
 		pk.VarInt(0),
 	))
 }
@@ -30,8 +29,8 @@ func (c *Client) Respawn() error {
 // UseItem use the item player handing.
 // hand could be one of 0: main hand, 1: off hand
 func (c *Client) UseItem(hand int) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.UseItem,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("use_item"),
 		pk.VarInt(hand),
 	))
 }
@@ -42,8 +41,7 @@ func (c *Client) UseItem(hand int) error {
 // the entity being attacked/used is visible without obstruction
 // and within a 4-unit radius of the player's position.
 func (c *Client) UseEntity(entityID int32, hand int) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.UseEntity,
+	return c.sendPacket(c.useEntityPacket(
 		pk.VarInt(entityID),
 		pk.VarInt(0),
 		pk.VarInt(hand),
@@ -53,18 +51,15 @@ func (c *Client) UseEntity(entityID int32, hand int) error {
 // AttackEntity used by player to left-clicks another entity.
 // The attack version of UseEntity. Has the same limit.
 func (c *Client) AttackEntity(entityID int32, hand int) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.UseEntity,
+	return c.sendPacket(c.useEntityPacket(
 		pk.VarInt(entityID),
 		pk.VarInt(1),
-		pk.VarInt(hand),
 	))
 }
 
 // UseEntityAt is a variety of UseEntity with target location
 func (c *Client) UseEntityAt(entityID int32, x, y, z float32, hand int) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.UseEntity,
+	return c.sendPacket(c.useEntityPacket(
 		pk.VarInt(entityID),
 		pk.VarInt(2),
 		pk.Float(x), pk.Float(y), pk.Float(z),
@@ -72,22 +67,31 @@ func (c *Client) UseEntityAt(entityID int32, x, y, z float32, hand int) error {
 	))
 }
 
+// useEntityPacket generate the Interact Entity packet.
+// Since 1.16 it ends with whether the player is sneaking.
+func (c *Client) useEntityPacket(fields ...pk.FieldEncoder) pk.Packet {
+	if c.Protocol >= protocol1_16 {
+		fields = append(fields, pk.Boolean(false))
+	}
+	return pk.Marshal(c.packetID("use_entity"), fields...)
+}
+
 // Chat send chat as chat message or command at textbox.
 func (c *Client) Chat(msg string) error {
 	if len(msg) > 256 {
 		return errors.New("message too long")
 	}
 
-	return c.conn.WritePacket(pk.Marshal(
-		data.ChatMessageServerbound,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("chat_message"),
 		pk.String(msg),
 	))
 }
 
 // PluginMessage is used by mods and plugins to send their data.
 func (c *Client) PluginMessage(channal string, msg []byte) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.PluginMessageServerbound,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("plugin_message"),
 		pk.Identifier(channal),
 		pluginMessageData(msg),
 	))
@@ -103,9 +107,10 @@ func (c *Client) PluginMessage(channal string, msg []byte) error {
 // cursorZ, from 0 to 1 increasing from north to south.
 //
 // insideBlock is true when the player's head is inside of a block's collision.
+// To place a block without working out these, use PlaceBlock.
 func (c *Client) UseBlock(hand, locX, locY, locZ, face int, cursorX, cursorY, cursorZ float32, insideBlock bool) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.PlayerBlockPlacement,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("player_block_placement"),
 		pk.VarInt(hand),
 		pk.Position{X: locX, Y: locY, Z: locZ},
 		pk.VarInt(face),
@@ -120,9 +125,12 @@ func (c *Client) SelectItem(slot int) error {
 	if slot < 0 || slot > 8 {
 		return errors.New("invalid slot: " + strconv.Itoa(slot))
 	}
+	c.stateMu.Lock()
+	c.HeldItem = slot
+	c.stateMu.Unlock()
 
-	return c.conn.WritePacket(pk.Marshal(
-		data.HeldItemChangeServerbound,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("held_item_change"),
 		pk.Short(slot),
 	))
 }
@@ -138,15 +146,15 @@ func (c *Client) SelectItem(slot int) error {
 // use the currently selected slot. After finding the appropriate slot,
 // the server swaps the items and then change player's selected slot (cause the HeldItemChange event).
 func (c *Client) PickItem(slot int) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.PickItem,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("pick_item"),
 		pk.VarInt(slot),
 	))
 }
 
 func (c *Client) playerAction(status, locX, locY, locZ, face int) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.PlayerDigging,
+	return c.sendPacket(pk.Marshal(
+		c.packetID("player_digging"),
 		pk.VarInt(status),
 		pk.Position{X: locX, Y: locY, Z: locZ},
 		pk.Byte(face),
@@ -156,6 +164,7 @@ func (c *Client) playerAction(status, locX, locY, locZ, face int) error {
 // Dig used to start, end or cancel a digging
 // status is 0 for start digging, 1 for cancel and 2 if client think it done.
 // To digging a block without cancel, use status 0 and 2 once each.
+// MineBlock does it with the right break time.
 func (c *Client) Dig(status, locX, locY, locZ, face int) error {
 	return c.playerAction(status, locX, locY, locZ, face)
 }
@@ -188,48 +197,64 @@ func (c *Client) Disconnect() error {
 
 // SendPacket send the packet to server.
 func (c *Client) SendPacket(packet pk.Packet) error {
-	return c.conn.WritePacket(packet)
+	return c.sendPacket(packet)
 }
 
 // SetPosition method move your character around.
 // Server will ignore this if changes too much.
-func (c *Client) SetPosition(x, y, z float64, onGround bool) {
+func (c *Client) SetPosition(x, y, z float64, onGround bool) error {
+	c.stateMu.Lock()
 	c.Player.X, c.Player.Y, c.Player.Z = x, y, z
 	c.Player.OnGround = onGround
-	sendPlayerPositionPacket(c)
+	p := playerPositionPacket(c)
+	c.stateMu.Unlock()
+	return c.sendPacket(p)
 }
 
-func (c *Client) SetPositionAndLook(x, y, z float64, onGround bool, yaw, pitch float32) {
+// SetPositionAndLook is the combination of SetPosition and LookYawPitch.
+func (c *Client) SetPositionAndLook(x, y, z float64, onGround bool, yaw, pitch float32) error {
+	c.stateMu.Lock()
 	c.Player.X, c.Player.Y, c.Player.Z = x, y, z
 	c.Player.OnGround = onGround
 	c.Player.Yaw, c.Player.Pitch = yaw, pitch
-	sendPlayerPositionAndLook(c)
-}
-func sendPlayerPositionAndLook(c *Client) {
-	c.conn.WritePacket(pk.Marshal(
-		data.PlayerPositionAndLookServerbound,
-		pk.Double(c.X),
-		pk.Double(c.Y),
-		pk.Double(c.Z),
-		pk.Float(c.Yaw),
-		pk.Float(c.Pitch),
-		pk.Boolean(c.OnGround),
-	))
+	p := playerPositionAndLookPacket(c)
+	c.stateMu.Unlock()
+	return c.sendPacket(p)
 }
 
-func sendPlayerPositionPacket(c *Client) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.PlayerPosition,
+// playerPositionPacket record the position as sent to the server and return the packet.
+// Like the other player*Packet functions, the caller must hold c.stateMu
+// and send the packet after unlocking it, so a slow connection doesn't block the readers of the state.
+func playerPositionPacket(c *Client) pk.Packet {
+	c.physics.lastX, c.physics.lastY, c.physics.lastZ = c.X, c.Y, c.Z
+	c.physics.lastOnGround = c.OnGround
+	c.physics.positionTicks = 0
+	return pk.Marshal(
+		c.packetID("player_position"),
 		pk.Double(c.Player.X),
 		pk.Double(c.Player.Y),
 		pk.Double(c.Player.Z),
 		pk.Boolean(c.Player.OnGround),
-	))
+	)
 }
 
 // LookAt method turn player's hand and make it look at a point.
-func (c *Client) LookAt(x, y, z float64) {
-	x0, y0, z0 := c.Player.X, c.Player.Y, c.Player.Z
+// The direction is measured from the player's feet,
+// use LookAtFromEyes to aim at a block.
+func (c *Client) LookAt(x, y, z float64) error {
+	p := c.GetPlayer()
+	return c.lookFrom(p.X, p.Y, p.Z, x, y, z)
+}
+
+// LookAtFromEyes turn the player's head so that the eyes look at a point,
+// as the server checks when the player digs or uses a block.
+func (c *Client) LookAtFromEyes(x, y, z float64) error {
+	p := c.GetPlayer()
+	return c.lookFrom(p.X, p.Y+eyeHeight, p.Z, x, y, z)
+}
+
+// lookFrom turn the player to look from (x0, y0, z0) to (x, y, z).
+func (c *Client) lookFrom(x0, y0, z0, x, y, z float64) error {
 	x, y, z = x-x0, y-y0, z-z0
 
 	r := math.Sqrt(x*x + y*y + z*z)
@@ -239,22 +264,37 @@ func (c *Client) LookAt(x, y, z float64) {
 	}
 	pitch := -math.Asin(y/r) / math.Pi * 180
 
-	c.LookYawPitch(float32(yaw), float32(pitch))
+	return c.LookYawPitch(float32(yaw), float32(pitch))
 }
 
 // LookYawPitch set player's hand to the direct by yaw and pitch.
 // yaw can be [0, 360) and pitch can be (-180, 180).
 // if |pitch|>90 the player's hand will be very strange.
-func (c *Client) LookYawPitch(yaw, pitch float32) {
+func (c *Client) LookYawPitch(yaw, pitch float32) error {
+	c.stateMu.Lock()
 	c.Player.Yaw, c.Player.Pitch = yaw, pitch
-	sendPlayerLookPacket(c)
+	p := playerLookPacket(c)
+	c.stateMu.Unlock()
+	return c.sendPacket(p)
 }
 
-func sendPlayerLookPacket(c *Client) error {
-	return c.conn.WritePacket(pk.Marshal(
-		data.PlayerLook,
+// playerLookPacket record the look as sent to the server and return the packet.
+func playerLookPacket(c *Client) pk.Packet {
+	c.physics.lastYaw, c.physics.lastPitch = c.Yaw, c.Pitch
+	c.physics.lastOnGround = c.OnGround
+	return pk.Marshal(
+		c.packetID("player_look"),
 		pk.Float(c.Player.Yaw),
 		pk.Float(c.Player.Pitch),
 		pk.Boolean(c.Player.OnGround),
-	))
+	)
+}
+
+// playerMovementPacket only tells the server whether the player is on ground.
+func playerMovementPacket(c *Client) pk.Packet {
+	c.physics.lastOnGround = c.OnGround
+	return pk.Marshal(
+		c.packetID("player"),
+		pk.Boolean(c.Player.OnGround),
+	)
 }
@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Tnze/go-mc/bot/world"
+)
+
+// The limits of GoTo
+const (
+	maxReplans = 20
+	stuckTicks = 2 * TPS // replan if a step isn't reached in time
+)
+
+// GoTo moves the player to the goal by the path found by world.Pathfinder.
+// A new path is found when a block on the path is changed or the player gets stuck.
+// The physics must be running by RunPhysics, or the player never moves.
+//
+// It returns nil when the goal is reached, or an error wrapping world.ErrNoPath
+// if the goal is unreachable. When ctx is done, the returned error matches ErrCanceled.
+// The Controls are cleared before returning.
+func (c *Client) GoTo(ctx context.Context, goal world.Goal) error {
+	changes := make(chan Event, 64)
+	sub := c.Bus.SubscribeChan(EventBlockChange, changes)
+	defer sub.Unsubscribe()
+	defer c.SetControls(Controls{})
+
+	t := time.NewTicker(time.Second / TPS)
+	defer t.Stop()
+
+	pf := world.Pathfinder{World: &c.Wd}
+	var (
+		path    world.Path
+		replan  = true
+		replans int
+		stuck   int
+		dropped uint64
+	)
+	for {
+		if replan {
+			x, y, z := c.feetPosition()
+			if goal.Reached(x, y, z) {
+				return nil
+			}
+			if replans++; replans > maxReplans {
+				return fmt.Errorf("bot: go to goal fail: replanned %d times", maxReplans)
+			}
+			var err error
+			if path, err = pf.FindPath(x, y, z, goal); err != nil {
+				return fmt.Errorf("bot: find path fail: %w", err)
+			}
+			replan, stuck = false, 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return canceledError{ctx.Err()}
+		case e := <-changes:
+			b := e.(BlockChangeEvent)
+			replan = path.Affected(b.X, b.Y, b.Z)
+			continue
+		case <-t.C:
+		}
+		// the changes may be missed
+		if d := sub.Dropped(); d != dropped {
+			dropped, replan = d, true
+			continue
+		}
+
+		for len(path) > 0 && c.stepReached(path[0]) {
+			path, stuck = path[1:], 0
+		}
+		if len(path) == 0 {
+			replan = true // check the goal
+			continue
+		}
+		if stuck++; stuck > stuckTicks {
+			replan = true
+			continue
+		}
+		c.followStep(path[0])
+	}
+}
+
+// feetPosition return the block position of the player's feet.
+// The player standing on a slab is counted as on the block above the slab.
+func (c *Client) feetPosition() (x, y, z int) {
+	p := c.GetPlayer()
+	return int(math.Floor(p.X)), int(math.Floor(p.Y + 0.5)), int(math.Floor(p.Z))
+}
+
+// stepReached reports whether the player is in the block of the step,
+// and stays there instead of jumping or falling through it.
+func (c *Client) stepReached(s world.Step) bool {
+	x, y, z := c.feetPosition()
+	if x != s.X || y != s.Y || z != s.Z {
+		return false
+	}
+	if s.Move == world.Climb || s.Move == world.Swim {
+		return true
+	}
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	return c.OnGround || c.Wd.BlockInfoAt(s.X, s.Y, s.Z).Water
+}
+
+// followStep turns the player to the step and sets the Controls to move into it.
+func (c *Client) followStep(s world.Step) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	dx, dz := float64(s.X)+0.5-c.X, float64(s.Z)+0.5-c.Z
+	feetY := int(math.Floor(c.Y + 0.5))
+	var ctrl Controls
+	// climbing down or sinking needs no key pressed,
+	// walking into the ladders would climb up
+	if math.Abs(dx) > 0.2 || math.Abs(dz) > 0.2 {
+		c.Yaw = float32(-math.Atan2(dx, dz) * 180 / math.Pi)
+		ctrl.Forward = true
+	}
+	switch s.Move {
+	case world.Ascend:
+		ctrl.Jump = true
+	case world.Climb, world.Swim:
+		ctrl.Jump = s.Y >= feetY
+	}
+	c.physics.controls = ctrl
+}
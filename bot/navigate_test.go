@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/bot/world"
+)
+
+func TestClient_GoTo(t *testing.T) {
+	c, closeFunc := newPhysicsClient()
+	defer closeFunc()
+	// a platform one block higher
+	stone := blockByName("minecraft:stone")
+	for x := 3; x < 8; x++ {
+		for z := -4; z < 4; z++ {
+			c.Wd.SetBlock(x, 64, z, stone)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go c.RunPhysics(ctx)
+
+	if err := c.GoTo(ctx, world.GoalBlock{X: 5, Y: 65, Z: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if x, y, z := c.feetPosition(); x != 5 || y != 65 || z != 2 {
+		t.Errorf("player at (%d, %d, %d)", x, y, z)
+	}
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	if c.physics.controls != (Controls{}) {
+		t.Errorf("controls not cleared: %+v", c.physics.controls)
+	}
+}
@@ -0,0 +1,482 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/Tnze/go-mc/bot/world"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// TPS is how many times the physics is simulated per second, same as the server.
+const TPS = 20
+
+// The physics constants of the players in vanilla
+const (
+	playerWidth  = 0.6
+	playerHeight = 1.8
+	eyeHeight    = 1.62
+	stepHeight   = 0.6
+
+	gravity         = 0.08
+	verticalDrag    = 0.98
+	airFriction     = 0.91
+	defaultSlip     = 0.6
+	walkSpeed       = 0.1 // the movement speed attribute of players
+	sprintModifier  = 1.3
+	sneakModifier   = 0.3
+	airAcceleration = 0.02
+	sprintAirBonus  = 0.006
+	jumpVelocity    = 0.42
+	sprintJumpBoost = 0.2
+	jumpCooldown    = 10 // ticks between the jumps when holding the jump key
+	liquidAccel     = 0.02
+	swimUpSpeed     = 0.04
+	waterDrag       = 0.8
+	lavaDrag        = 0.5
+	climbSpeed      = 0.15
+	climbUpSpeed    = 0.2
+)
+
+// Controls are the inputs of the physics simulation, like the keys pressed by a player.
+type Controls struct {
+	Forward, Back, Left, Right bool
+
+	Jump   bool // jump, swim up or climb up the ladders
+	Sprint bool
+	Sneak  bool // also keeps the player from falling off the edges and holds on the ladders
+}
+
+// physics is the motion state of the player, guarded by Client.stateMu.
+type physics struct {
+	controls Controls
+
+	velX, velY, velZ     float64 // blocks per tick
+	ready                bool    // the position has been set by the server
+	collidedHorizontally bool
+	jumpTicks            int
+	sprint, sneak        bool
+
+	// the state last sent to the server
+	sentSprint, sentSneak bool
+	lastX, lastY, lastZ   float64
+	lastYaw, lastPitch    float32
+	lastOnGround          bool
+	positionTicks         int // ticks since the position is sent
+}
+
+// SetControls set the inputs of the physics simulation.
+// The player moves when the physics is running, see RunPhysics.
+func (c *Client) SetControls(ctrl Controls) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.physics.controls = ctrl
+}
+
+// Velocity return the velocity of the player in blocks per tick.
+func (c *Client) Velocity() (x, y, z float64) {
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	return c.physics.velX, c.physics.velY, c.physics.velZ
+}
+
+// RunPhysics calls PhysicsTick 20 times per second, until ctx is done or sending packets fail.
+// When ctx is done, the returned error matches ErrCanceled.
+//
+// It's usually run in a new goroutine after joining the game:
+//
+//	go c.RunPhysics(ctx)
+//	err = c.HandleGameContext(ctx)
+func (c *Client) RunPhysics(ctx context.Context) error {
+	t := time.NewTicker(time.Second / TPS)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return canceledError{ctx.Err()}
+		case <-t.C:
+			if err := c.PhysicsTick(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// PhysicsTick simulates the movement of the player in a tick by the Controls,
+// like the vanilla client, and sends the position to the server.
+// It does nothing before the server sets the player's position.
+// The player doesn't move if the chunk it in isn't loaded.
+func (c *Client) PhysicsTick() error {
+	c.stateMu.Lock()
+	if !c.physics.ready {
+		c.stateMu.Unlock()
+		return nil
+	}
+	if c.Wd.ChunkLoaded(int(math.Floor(c.X))>>4, int(math.Floor(c.Z))>>4) {
+		c.tickMotion()
+	}
+	packets := c.motionPackets()
+	c.stateMu.Unlock()
+
+	for _, p := range packets {
+		if err := c.sendPacket(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tickMotion update the velocity and the position of the player.
+// The caller must hold c.stateMu.
+func (c *Client) tickMotion() {
+	ph := &c.physics
+	ctrl := ph.controls
+
+	// too small velocity is cleared
+	if math.Abs(ph.velX) < 0.003 {
+		ph.velX = 0
+	}
+	if math.Abs(ph.velY) < 0.003 {
+		ph.velY = 0
+	}
+	if math.Abs(ph.velZ) < 0.003 {
+		ph.velZ = 0
+	}
+
+	var forward, strafe float64
+	if ctrl.Forward {
+		forward++
+	}
+	if ctrl.Back {
+		forward--
+	}
+	if ctrl.Left {
+		strafe++
+	}
+	if ctrl.Right {
+		strafe--
+	}
+	ph.sneak = ctrl.Sneak
+	if ph.sneak {
+		forward *= sneakModifier
+		strafe *= sneakModifier
+	}
+	ph.sprint = ctrl.Sprint && forward > 0 && !ph.sneak && (c.Food > 6 || c.Gamemode == 1)
+	forward *= 0.98
+	strafe *= 0.98
+
+	body := c.boundingBox()
+	inWater := c.inBlocks(body.grow(-0.001, -0.401, -0.001), isWater)
+	inLava := !inWater && c.inBlocks(body.grow(-0.1, -0.4, -0.1), isLava)
+
+	if ph.jumpTicks > 0 {
+		ph.jumpTicks--
+	}
+	if ctrl.Jump {
+		if inWater || inLava {
+			ph.velY += swimUpSpeed
+		} else if c.OnGround && ph.jumpTicks == 0 {
+			ph.velY = jumpVelocity * c.blockFactor(func(b world.BlockInfo) float64 { return b.JumpFactor })
+			if ph.sprint {
+				yaw := float64(c.Yaw) * math.Pi / 180
+				ph.velX -= math.Sin(yaw) * sprintJumpBoost
+				ph.velZ += math.Cos(yaw) * sprintJumpBoost
+			}
+			ph.jumpTicks = jumpCooldown
+		}
+	} else {
+		ph.jumpTicks = 0
+	}
+
+	switch {
+	case inWater || inLava:
+		y0 := c.Y
+		c.moveRelative(liquidAccel, strafe, forward)
+		c.move()
+		if inWater {
+			drag := waterDrag
+			if ph.sprint {
+				drag = 0.9
+			}
+			ph.velX *= drag
+			ph.velY *= waterDrag
+			ph.velZ *= drag
+			ph.velY -= gravity / 16
+		} else {
+			ph.velX *= lavaDrag
+			ph.velY *= lavaDrag
+			ph.velZ *= lavaDrag
+			ph.velY -= gravity / 4
+		}
+		// jump out of the liquid onto the bank
+		if ph.collidedHorizontally && c.isFree(ph.velX, ph.velY+0.6-c.Y+y0, ph.velZ) {
+			ph.velY = 0.3
+		}
+
+	default:
+		slip, friction := defaultSlip, airFriction
+		if c.OnGround {
+			slip = c.blockBelow().Slipperiness
+			friction = slip * airFriction
+		}
+		var accel float64
+		if c.OnGround {
+			speed := walkSpeed
+			if ph.sprint {
+				speed *= sprintModifier
+			}
+			accel = speed * (0.21600002 / (slip * slip * slip))
+		} else {
+			accel = airAcceleration
+			if ph.sprint {
+				accel += sprintAirBonus
+			}
+		}
+		c.moveRelative(accel, strafe, forward)
+
+		climbing := c.blockAtFeet().Climbable
+		if climbing {
+			ph.velX = math.Max(-climbSpeed, math.Min(climbSpeed, ph.velX))
+			ph.velZ = math.Max(-climbSpeed, math.Min(climbSpeed, ph.velZ))
+			ph.velY = math.Max(-climbSpeed, ph.velY)
+			if ph.sneak && ph.velY < 0 {
+				ph.velY = 0
+			}
+		}
+		c.move()
+		if climbing && (ph.collidedHorizontally || ctrl.Jump) {
+			ph.velY = climbUpSpeed
+		}
+
+		ph.velY = (ph.velY - gravity) * verticalDrag
+		ph.velX *= friction
+		ph.velZ *= friction
+	}
+}
+
+// moveRelative accelerate the player by the inputs in the direction it facing.
+func (c *Client) moveRelative(accel, strafe, forward float64) {
+	d := strafe*strafe + forward*forward
+	if d < 1e-7 {
+		return
+	}
+	if d > 1 {
+		d = math.Sqrt(d)
+		strafe, forward = strafe/d, forward/d
+	}
+	strafe, forward = strafe*accel, forward*accel
+	sin, cos := math.Sincos(float64(c.Yaw) * math.Pi / 180)
+	c.physics.velX += strafe*cos - forward*sin
+	c.physics.velZ += forward*cos + strafe*sin
+}
+
+// move the player by its velocity, and handle the collisions.
+func (c *Client) move() {
+	ph := &c.physics
+	dx, dy, dz := ph.velX, ph.velY, ph.velZ
+	box := c.boundingBox()
+	if ph.sneak && c.OnGround {
+		dx, dz = c.backOffFromEdge(box, dx, dz)
+	}
+	mx, my, mz := c.collide(box, dx, dy, dz)
+
+	c.X += mx
+	c.Y += my
+	c.Z += mz
+	ph.collidedHorizontally = math.Abs(mx-dx) > 1e-5 || math.Abs(mz-dz) > 1e-5
+	c.OnGround = my != dy && dy < 0
+	if mx != dx {
+		ph.velX = 0
+	}
+	if mz != dz {
+		ph.velZ = 0
+	}
+	if my != dy {
+		ph.velY = 0
+	}
+
+	f := c.blockFactor(func(b world.BlockInfo) float64 { return b.SpeedFactor })
+	ph.velX *= f
+	ph.velZ *= f
+}
+
+// collide return the movement of box limited by the blocks.
+// The player steps up the blocks lower than stepHeight.
+func (c *Client) collide(box aabb, dx, dy, dz float64) (float64, float64, float64) {
+	boxes := c.collisionBoxes(box.expand(dx, dy, dz).expand(0, stepHeight, 0))
+	mx, my, mz := collideBoxes(boxes, box, dx, dy, dz)
+
+	if (c.OnGround || my != dy && dy < 0) && (mx != dx || mz != dz) {
+		sx, sy, sz := collideBoxes(boxes, box, dx, stepHeight, dz)
+		if sx*sx+sz*sz > mx*mx+mz*mz {
+			// step down onto the block
+			stepped := box.offset(sx, sy, sz)
+			down := -sy + dy
+			for _, b := range boxes {
+				down = stepped.clipY(b, down)
+			}
+			return sx, sy + down, sz
+		}
+	}
+	return mx, my, mz
+}
+
+// collideBoxes clip the movement of box by boxes, along Y axis first.
+func collideBoxes(boxes []aabb, box aabb, dx, dy, dz float64) (float64, float64, float64) {
+	for _, b := range boxes {
+		dy = box.clipY(b, dy)
+	}
+	box = box.offset(0, dy, 0)
+
+	// the axis with larger movement is the last
+	xFirst := math.Abs(dx) >= math.Abs(dz)
+	if xFirst {
+		for _, b := range boxes {
+			dx = box.clipX(b, dx)
+		}
+		box = box.offset(dx, 0, 0)
+	}
+	for _, b := range boxes {
+		dz = box.clipZ(b, dz)
+	}
+	if !xFirst {
+		box = box.offset(0, 0, dz)
+		for _, b := range boxes {
+			dx = box.clipX(b, dx)
+		}
+	}
+	return dx, dy, dz
+}
+
+// backOffFromEdge limit the movement of a sneaking player,
+// so it won't fall off the edge.
+func (c *Client) backOffFromEdge(box aabb, dx, dz float64) (float64, float64) {
+	const step = 0.05
+	towardZero := func(d float64) float64 {
+		switch {
+		case d < step && d >= -step:
+			return 0
+		case d > 0:
+			return d - step
+		default:
+			return d + step
+		}
+	}
+	for dx != 0 && c.isFreeBox(box.offset(dx, -stepHeight, 0)) {
+		dx = towardZero(dx)
+	}
+	for dz != 0 && c.isFreeBox(box.offset(0, -stepHeight, dz)) {
+		dz = towardZero(dz)
+	}
+	for dx != 0 && dz != 0 && c.isFreeBox(box.offset(dx, -stepHeight, dz)) {
+		dx, dz = towardZero(dx), towardZero(dz)
+	}
+	return dx, dz
+}
+
+// boundingBox return the player's collision box.
+func (c *Client) boundingBox() aabb {
+	const w = playerWidth / 2
+	return aabb{c.X - w, c.Y, c.Z - w, c.X + w, c.Y + playerHeight, c.Z + w}
+}
+
+// grow the box by (x, y, z) on each side
+func (b aabb) grow(x, y, z float64) aabb {
+	return aabb{b.MinX - x, b.MinY - y, b.MinZ - z, b.MaxX + x, b.MaxY + y, b.MaxZ + z}
+}
+
+func (c *Client) isFreeBox(box aabb) bool {
+	return len(c.collisionBoxes(box)) == 0
+}
+
+// isFree reports whether the player could be at the offset,
+// without any collision or liquid.
+func (c *Client) isFree(dx, dy, dz float64) bool {
+	box := c.boundingBox().offset(dx, dy, dz)
+	return c.isFreeBox(box) && !c.inBlocks(box, isWater) && !c.inBlocks(box, isLava)
+}
+
+func isWater(b world.BlockInfo) bool { return b.Water }
+func isLava(b world.BlockInfo) bool  { return b.Lava }
+
+// inBlocks reports whether the box intersects with any block matching f.
+func (c *Client) inBlocks(box aabb, f func(world.BlockInfo) bool) bool {
+	for y := int(math.Floor(box.MinY)); y <= int(math.Floor(box.MaxY)); y++ {
+		for x := int(math.Floor(box.MinX)); x <= int(math.Floor(box.MaxX)); x++ {
+			for z := int(math.Floor(box.MinZ)); z <= int(math.Floor(box.MaxZ)); z++ {
+				if f(c.Wd.BlockInfoAt(x, y, z)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// blockAtFeet return the block the player in.
+func (c *Client) blockAtFeet() world.BlockInfo {
+	return c.Wd.BlockInfoAt(int(math.Floor(c.X)), int(math.Floor(c.Y)), int(math.Floor(c.Z)))
+}
+
+// blockBelow return the block supporting the player.
+func (c *Client) blockBelow() world.BlockInfo {
+	return c.Wd.BlockInfoAt(int(math.Floor(c.X)), int(math.Floor(c.Y-0.5000001)), int(math.Floor(c.Z)))
+}
+
+// blockFactor return the factor of the block at the player's feet,
+// or the block below if the former one is 1.
+func (c *Client) blockFactor(factor func(world.BlockInfo) float64) float64 {
+	feet := c.blockAtFeet()
+	if f := factor(feet); f != 1 || feet.Water {
+		return f
+	}
+	return factor(c.blockBelow())
+}
+
+// motionPackets return the packets sending the state changed in this tick to the server,
+// like the vanilla client. The caller must hold c.stateMu.
+func (c *Client) motionPackets() (packets []pk.Packet) {
+	ph := &c.physics
+	if ph.sprint != ph.sentSprint {
+		action := 4 // stop sprinting
+		if ph.sprint {
+			action = 3 // start sprinting
+		}
+		packets = append(packets, c.entityActionPacket(action))
+		ph.sentSprint = ph.sprint
+	}
+	if ph.sneak != ph.sentSneak {
+		action := 1 // stop sneaking
+		if ph.sneak {
+			action = 0 // start sneaking
+		}
+		packets = append(packets, c.entityActionPacket(action))
+		ph.sentSneak = ph.sneak
+	}
+
+	ph.positionTicks++
+	dx, dy, dz := c.X-ph.lastX, c.Y-ph.lastY, c.Z-ph.lastZ
+	moved := dx*dx+dy*dy+dz*dz > 9e-8 || ph.positionTicks >= 20
+	rotated := c.Yaw != ph.lastYaw || c.Pitch != ph.lastPitch
+	switch {
+	case moved && rotated:
+		packets = append(packets, playerPositionAndLookPacket(c))
+	case moved:
+		packets = append(packets, playerPositionPacket(c))
+	case rotated:
+		packets = append(packets, playerLookPacket(c))
+	case c.OnGround != ph.lastOnGround:
+		packets = append(packets, playerMovementPacket(c))
+	}
+	return
+}
+
+func (c *Client) entityActionPacket(action int) pk.Packet {
+	return pk.Marshal(
+		c.packetID("entity_action"),
+		pk.VarInt(c.EntityID),
+		pk.VarInt(action),
+		pk.VarInt(0), // jump boost
+	)
+}
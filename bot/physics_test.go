@@ -0,0 +1,182 @@
+package bot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Tnze/go-mc/bot/world"
+	"github.com/Tnze/go-mc/data"
+)
+
+// newPhysicsClient return a client standing on a stone floor at y=63.
+func newPhysicsClient() (c *Client, closeFunc func()) {
+	c, _, closeFunc = newTestClient(discardPackets)
+	c.Food = 20
+
+	for cx := -1; cx <= 1; cx++ {
+		for cz := -1; cz <= 1; cz++ {
+			var chunk world.Chunk
+			for x := 0; x < 16; x++ {
+				for z := 0; z < 16; z++ {
+					chunk.Sections[3].SetBlock(x, 15, z, world.Block{ID: 1}) // stone
+				}
+			}
+			c.Wd.LoadChunk(cx, cz, &chunk)
+		}
+	}
+	c.X, c.Y, c.Z = 0.5, 64, 0.5
+	c.physics.ready = true
+	return c, closeFunc
+}
+
+// blockByName return the default state of the block.
+func blockByName(name string) world.Block {
+	id, ok := data.Blocks(ProtocolVersion).DefaultByName[name]
+	if !ok {
+		panic("no block " + name)
+	}
+	return world.Block{ID: uint(id)}
+}
+
+func (c *Client) ticks(t *testing.T, n int) {
+	for i := 0; i < n; i++ {
+		if err := c.PhysicsTick(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPhysics_fall(t *testing.T) {
+	c, closeFunc := newPhysicsClient()
+	defer closeFunc()
+	c.Y = 70
+	c.ticks(t, 40)
+	if c.Y != 64 || !c.OnGround {
+		t.Errorf("player should land on the floor, but at y=%v, onGround=%v", c.Y, c.OnGround)
+	}
+}
+
+func TestPhysics_walk(t *testing.T) {
+	for _, tt := range []struct {
+		ctrl  Controls
+		speed float64 // blocks per tick
+	}{
+		{Controls{Forward: true}, 4.317 / TPS},
+		{Controls{Forward: true, Sprint: true}, 5.612 / TPS},
+		{Controls{Forward: true, Sneak: true}, 1.295 / TPS},
+	} {
+		c, closeFunc := newPhysicsClient()
+		defer closeFunc()
+		c.OnGround = true
+		c.Yaw = -90 // facing east, +X
+		c.SetControls(tt.ctrl)
+		c.ticks(t, 20)
+		x0 := c.X
+		c.ticks(t, 1)
+		if speed := c.X - x0; math.Abs(speed-tt.speed) > 0.001 {
+			t.Errorf("%+v: want speed %.4f, get %.4f", tt.ctrl, tt.speed, speed)
+		}
+		if c.Y != 64 || math.Abs(c.Z-0.5) > 1e-9 {
+			t.Errorf("%+v: walk off the line: %v, %v", tt.ctrl, c.Y, c.Z)
+		}
+	}
+}
+
+func TestPhysics_jump(t *testing.T) {
+	c, closeFunc := newPhysicsClient()
+	defer closeFunc()
+	c.OnGround = true
+	c.SetControls(Controls{Jump: true})
+	c.ticks(t, 1)
+	c.SetControls(Controls{})
+
+	maxY := c.Y
+	for i := 0; i < 20; i++ {
+		c.ticks(t, 1)
+		maxY = math.Max(maxY, c.Y)
+	}
+	if h := maxY - 64; math.Abs(h-1.2522) > 0.001 {
+		t.Errorf("want jump height 1.2522, get %.4f", h)
+	}
+	if c.Y != 64 || !c.OnGround {
+		t.Errorf("player should land, but at y=%v", c.Y)
+	}
+}
+
+func TestPhysics_collision(t *testing.T) {
+	c, closeFunc := newPhysicsClient()
+	defer closeFunc()
+	c.OnGround = true
+	c.Wd.SetBlock(3, 64, 0, blockByName("minecraft:stone_slab")) // a step
+	c.Wd.SetBlock(6, 65, 0, world.Block{ID: 1})                  // a wall
+	c.Wd.SetBlock(6, 66, 0, world.Block{ID: 1})                  // a wall
+	c.Yaw = -90
+	c.SetControls(Controls{Forward: true})
+	var stepped bool
+	for i := 0; i < 60; i++ {
+		c.ticks(t, 1)
+		stepped = stepped || c.Y == 64.5
+	}
+	if !stepped {
+		t.Error("player should step up the slab")
+	}
+	if c.Y != 64 || math.Abs(c.X-5.7) > 1e-9 {
+		t.Errorf("player should stop at the wall, but at (%v, %v)", c.X, c.Y)
+	}
+
+	// sneaking at the edge
+	c, closeFunc = newPhysicsClient()
+	defer closeFunc()
+	c.OnGround = true
+	c.X = 0.5
+	c.Wd.SetBlock(1, 63, 0, world.Block{ID: 0}) // a hole
+	c.Wd.SetBlock(2, 63, 0, world.Block{ID: 0})
+	c.Wd.SetBlock(1, 63, -1, world.Block{ID: 0})
+	c.Wd.SetBlock(1, 63, 1, world.Block{ID: 0})
+	c.Yaw = -90
+	c.SetControls(Controls{Forward: true, Sneak: true})
+	c.ticks(t, 40)
+	if c.Y != 64 || c.X > 1.3 {
+		t.Errorf("sneaking player shouldn't fall, but at (%v, %v)", c.X, c.Y)
+	}
+}
+
+func TestPhysics_climb(t *testing.T) {
+	c, closeFunc := newPhysicsClient()
+	defer closeFunc()
+	c.OnGround = true
+	ladder := blockByName("minecraft:ladder")
+	for y := 64; y < 70; y++ {
+		c.Wd.SetBlock(0, y, 0, ladder)
+	}
+	c.SetControls(Controls{Jump: true})
+	c.ticks(t, 10)
+	y0 := c.Y
+	c.ticks(t, 1)
+	if speed := c.Y - y0; math.Abs(speed-2.352/TPS) > 0.001 {
+		t.Errorf("want climbing speed %.4f, get %.4f", 2.352/TPS, speed)
+	}
+
+	// holding on the ladder
+	c.SetControls(Controls{Sneak: true})
+	c.ticks(t, 2)
+	y0 = c.Y
+	c.ticks(t, 10)
+	if c.Y != y0 {
+		t.Errorf("sneaking player should hold on the ladder, but %v -> %v", y0, c.Y)
+	}
+
+	// swimming up
+	c, closeFunc = newPhysicsClient()
+	defer closeFunc()
+	water := blockByName("minecraft:water")
+	for y := 64; y < 70; y++ {
+		c.Wd.SetBlock(0, y, 0, water)
+	}
+	c.OnGround = true
+	c.SetControls(Controls{Jump: true})
+	c.ticks(t, 20)
+	if c.Y < 66 {
+		t.Errorf("player should swim up, but at y=%v", c.Y)
+	}
+}
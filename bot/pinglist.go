@@ -1,9 +1,14 @@
 package bot
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	stdnet "net"
+	"strconv"
 	"time"
 
+	"github.com/Tnze/go-mc/data"
 	"github.com/Tnze/go-mc/net"
 	pk "github.com/Tnze/go-mc/net/packet"
 )
@@ -20,6 +25,43 @@ func PingAndList(addr string, port int) ([]byte, time.Duration, error) {
 	return pingAndList(addr, port, conn)
 }
 
+// PingAndListStatus is like PingAndList but decodes the response as StatusResponse.
+func PingAndListStatus(addr string, port int) (*net.StatusResponse, time.Duration, error) {
+	resp, delay, err := PingAndList(addr, port)
+	if err != nil {
+		return nil, delay, err
+	}
+
+	var s net.StatusResponse
+	if err := json.Unmarshal(resp, &s); err != nil {
+		return nil, delay, fmt.Errorf("bot: unmarshal status fail: %v", err)
+	}
+	return &s, delay, nil
+}
+
+// PingAndListContext is like PingAndList but aborts when ctx is done.
+// In that case, the returned error matches ErrCanceled.
+// The deadline of ctx is also applied to the connection.
+func PingAndListContext(ctx context.Context, addr string, port int) ([]byte, time.Duration, error) {
+	var d stdnet.Dialer
+	conn, err := d.DialContext(ctx, "tcp", stdnet.JoinHostPort(addr, strconv.Itoa(port)))
+	if err != nil {
+		return nil, 0, contextError(ctx, fmt.Errorf("bot: dial fail: %v", err))
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, 0, fmt.Errorf("bot: set deadline fail: %v", err)
+		}
+	}
+	var w ctxWatcher
+	stop := w.watch(ctx, conn)
+	resp, delay, err := pingAndList(addr, port, net.WrapConn(conn))
+	stop()
+	return resp, delay, contextError(ctx, err)
+}
+
 // PingAndListTimeout PingAndLIstTimeout is the version of PingAndList with max request time.
 func PingAndListTimeout(addr string, port int, timeout time.Duration) ([]byte, time.Duration, error) {
 	deadLine := time.Now().Add(timeout)
@@ -42,7 +84,7 @@ func pingAndList(addr string, port int, conn *net.Conn) ([]byte, time.Duration,
 	err := conn.WritePacket(
 		//Handshake Packet
 		pk.Marshal(
-			0x00,                       //Handshake packet ID
+			packetID(ProtocolVersion, data.Handshaking, "handshake"),
 			pk.VarInt(ProtocolVersion), //Protocol version
 			pk.String(addr),            //Server's address
 			pk.UnsignedShort(port),
@@ -54,7 +96,7 @@ func pingAndList(addr string, port int, conn *net.Conn) ([]byte, time.Duration,
 
 	//LIST
 	//请求服务器状态
-	err = conn.WritePacket(pk.Marshal(0))
+	err = conn.WritePacket(pk.Marshal(packetID(ProtocolVersion, data.Status, "request")))
 	if err != nil {
 		return nil, 0, fmt.Errorf("bot: send list packect fail: %v", err)
 	}
@@ -72,7 +114,7 @@ func pingAndList(addr string, port int, conn *net.Conn) ([]byte, time.Duration,
 
 	//PING
 	startTime := time.Now()
-	err = conn.WritePacket(pk.Marshal(0x01, pk.Long(startTime.Unix())))
+	err = conn.WritePacket(pk.Marshal(packetID(ProtocolVersion, data.Status, "ping"), pk.Long(startTime.Unix())))
 	if err != nil {
 		return nil, 0, fmt.Errorf("bot: send ping packect fail: %v", err)
 	}
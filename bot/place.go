@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Tnze/go-mc/bot/world"
+)
+
+// placeReach is how far the player can place blocks in survival mode,
+// from the eyes to the clicked point.
+const placeReach = 4.5
+
+// ErrPlaceRejected is returned by PlaceBlock when the server doesn't place the block.
+var ErrPlaceRejected = errors.New("bot: placing rejected")
+
+// PlaceBlock places the item as a block in the position.
+// It selects the item in the hotbar, clicks a face of the neighbor block,
+// and returns when the server changes the block in the position.
+//
+// The position must be replaceable, like air or water,
+// and a neighbor must be a solid block within the reach of the player.
+// The blocks used by right clicks, like chests and doors, are never clicked.
+//
+// It returns an error wrapping ErrPlaceRejected if the server reverts the block.
+// When ctx is done, the returned error matches ErrCanceled.
+func (c *Client) PlaceBlock(ctx context.Context, x, y, z int, itemID int32) error {
+	before := c.Wd.GetBlock(x, y, z)
+	if info := c.Wd.BlockInfo(before); !info.Replaceable {
+		return fmt.Errorf("bot: place block at (%d, %d, %d) fail: occupied by %s", x, y, z, info.Name)
+	}
+	c.stateMu.RLock()
+	inWay := c.boundingBox().intersects(aabb{
+		float64(x), float64(y), float64(z),
+		float64(x + 1), float64(y + 1), float64(z + 1),
+	})
+	c.stateMu.RUnlock()
+	if inWay {
+		return fmt.Errorf("bot: place block at (%d, %d, %d) fail: the player is in the way", x, y, z)
+	}
+	nx, ny, nz, face, ok := c.placeAgainst(x, y, z)
+	if !ok {
+		return fmt.Errorf("bot: place block at (%d, %d, %d) fail: no block to place against", x, y, z)
+	}
+	if err := c.holdItem(itemID); err != nil {
+		return err
+	}
+
+	changes := make(chan Event, 64)
+	sub := c.Bus.SubscribeChan(EventBlockChange, changes)
+	defer sub.Unsubscribe()
+
+	// click the center of the face
+	dx, dy, dz := face.Offset()
+	cx, cy, cz := 0.5+0.5*float64(dx), 0.5+0.5*float64(dy), 0.5+0.5*float64(dz)
+	if err := c.LookAtFromEyes(float64(nx)+cx, float64(ny)+cy, float64(nz)+cz); err != nil {
+		return err
+	}
+	if err := c.UseBlock(0, nx, ny, nz, int(face), float32(cx), float32(cy), float32(cz), false); err != nil {
+		return err
+	}
+	if err := c.SwingArm(0); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return canceledError{ctx.Err()}
+		case e := <-changes:
+			b := e.(BlockChangeEvent)
+			if b.X != x || b.Y != y || b.Z != z {
+				continue
+			}
+			// the server resends the old block if it's not placed,
+			// the placed block may be replaceable too, like snow layers and grass
+			if uint(b.StateID) == before.ID {
+				return fmt.Errorf("bot: place block at (%d, %d, %d) fail: %w", x, y, z, ErrPlaceRejected)
+			}
+			return nil
+		}
+	}
+}
+
+// placeAgainst finds the nearest neighbor block of the position to place a block against,
+// and return the face of the neighbor facing the position.
+func (c *Client) placeAgainst(x, y, z int) (nx, ny, nz int, face world.Face, ok bool) {
+	p := c.GetPlayer()
+	ex, ey, ez := p.X, p.Y+eyeHeight, p.Z
+	best := placeReach
+	for f := world.Bottom; f <= world.East; f++ {
+		dx, dy, dz := f.Offset()
+		info := c.Wd.BlockInfoAt(x+dx, y+dy, z+dz)
+		if info.Replaceable || len(info.Boxes) == 0 || interactiveBlock(info.Name) {
+			continue
+		}
+		// the center of the face between the neighbor and the position
+		fx := float64(x) + 0.5 + 0.5*float64(dx)
+		fy := float64(y) + 0.5 + 0.5*float64(dy)
+		fz := float64(z) + 0.5 + 0.5*float64(dz)
+		if d := math.Sqrt((fx-ex)*(fx-ex) + (fy-ey)*(fy-ey) + (fz-ez)*(fz-ez)); d <= best {
+			nx, ny, nz, face, ok = x+dx, y+dy, z+dz, f.Opposite(), true
+			best = d
+		}
+	}
+	return
+}
+
+// holdItem selects the item in the main hand,
+// moving it to the selected hotbar slot if it isn't in the hotbar.
+func (c *Client) holdItem(itemID int32) error {
+	if s := c.MainHandItem(); s.Present && s.ItemID == itemID {
+		return nil
+	}
+	inv := c.Inventory()
+	for i := 0; i < 9; i++ {
+		if s := inv.Slots[inv.Hotbar(i)]; s.Present && s.ItemID == itemID {
+			return c.SelectItem(i)
+		}
+	}
+	return c.EquipToHotbar(itemID, c.GetPlayer().HeldItem)
+}
+
+// The blocks opening a window or changing their states when right-clicked,
+// which need the player sneaking to place blocks against.
+var (
+	interactiveBlocks = map[string]bool{
+		"chest": true, "trapped_chest": true, "ender_chest": true, "barrel": true,
+		"crafting_table": true, "furnace": true, "blast_furnace": true, "smoker": true,
+		"anvil": true, "chipped_anvil": true, "damaged_anvil": true,
+		"enchanting_table": true, "brewing_stand": true, "beacon": true,
+		"hopper": true, "dispenser": true, "dropper": true,
+		"lever": true, "repeater": true, "comparator": true, "daylight_detector": true,
+		"note_block": true, "jukebox": true, "bell": true, "cake": true,
+		"cartography_table": true, "grindstone": true, "lectern": true, "loom": true,
+		"stonecutter": true, "smithing_table": true, "respawn_anchor": true,
+		"command_block": true, "chain_command_block": true, "repeating_command_block": true,
+		"structure_block": true, "jigsaw": true,
+	}
+	interactiveSuffixes = []string{"_door", "_trapdoor", "_fence_gate", "_button", "_bed", "shulker_box"}
+)
+
+func interactiveBlock(name string) bool {
+	name = strings.TrimPrefix(name, "minecraft:")
+	if interactiveBlocks[name] {
+		return true
+	}
+	for _, s := range interactiveSuffixes {
+		if strings.HasSuffix(name, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/bot/world"
+	"github.com/Tnze/go-mc/bot/world/entity"
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestClient_PlaceBlock(t *testing.T) {
+	c, s, closeFunc := newTestClient(readPackets)
+	defer closeFunc()
+	bs := data.Blocks(ProtocolVersion)
+	c.Wd.BlockStates = bs
+	c.Wd.LoadChunk(0, 0, new(world.Chunk))
+	stone := world.Block{ID: uint(bs.DefaultByName["minecraft:stone"])}
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			c.Wd.SetBlock(x, 63, z, stone)
+		}
+	}
+	c.Wd.SetBlock(3, 64, 0, world.Block{ID: uint(bs.DefaultByName["minecraft:chest"])})
+	c.X, c.Y, c.Z, c.OnGround = 0.5, 64, 0.5, true
+	c.inv.slots[38] = entity.Slot{Present: true, ItemID: stoneItem, Count: 10}
+
+	packets := data.Packets(ProtocolVersion, data.Play, data.Clientbound)
+	blockChange := func(x, y, z int, b world.Block) {
+		id, _ := packets.ID("block_change")
+		if _, err := c.handlePacket(pk.Marshal(id, pk.Position{X: x, Y: y, Z: z}, pk.VarInt(b.ID))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// wait for the placement packet
+	placement := func() (pos pk.Position, face pk.VarInt, cursorY pk.Float) {
+		for p := range s.Sent {
+			if p.ID == c.packetID("player_block_placement") {
+				if err := p.Scan(new(pk.VarInt), &pos, &face, new(pk.Float), &cursorY); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+		}
+		t.Fatal("connection closed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- c.PlaceBlock(ctx, 2, 64, 0, stoneItem) }()
+	if pos, face, cursorY := placement(); pos != (pk.Position{X: 2, Y: 63, Z: 0}) || face != pk.VarInt(world.Top) || cursorY != 1 {
+		t.Errorf("wrong placement: %v, face %d, cursor y %v", pos, face, cursorY)
+	}
+	blockChange(2, 64, 0, stone)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if c.GetPlayer().HeldItem != 2 {
+		t.Errorf("the stone isn't selected: %d", c.GetPlayer().HeldItem)
+	}
+
+	go func() { done <- c.PlaceBlock(ctx, 2, 64, 1, stoneItem) }()
+	placement()
+	blockChange(2, 64, 1, world.Block{})
+	if err := <-done; !errors.Is(err, ErrPlaceRejected) {
+		t.Errorf("the rejected placing returns %v", err)
+	}
+
+	// the snow layer is replaceable, but it's placed
+	snow := world.Block{ID: uint(bs.DefaultByName["minecraft:snow"])}
+	go func() { done <- c.PlaceBlock(ctx, 2, 64, 2, stoneItem) }()
+	placement()
+	blockChange(2, 64, 2, snow)
+	if err := <-done; err != nil {
+		t.Errorf("placing snow returns %v", err)
+	}
+
+	if err := c.PlaceBlock(ctx, 2, 64, 0, stoneItem); err == nil {
+		t.Error("placed in an occupied position")
+	}
+	if err := c.PlaceBlock(ctx, 0, 65, 0, stoneItem); err == nil {
+		t.Error("placed in the player")
+	}
+}
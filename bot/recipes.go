@@ -0,0 +1,410 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Tnze/go-mc/bot/world/entity"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// Recipe is a recipe declared by the server.
+type Recipe struct {
+	ID    string // such as "minecraft:stick"
+	Type  string // such as "crafting_shaped", "smelting" or "crafting_special_firework_rocket"
+	Group string
+
+	// Width and Height are the size of a shaped recipe, whose Ingredients are in rows.
+	// Ingredients of the shapeless recipes can be placed in any order.
+	// Smelting and stonecutting recipes have only one Ingredient,
+	// and smithing recipes have the base and the addition.
+	Width, Height int
+	Ingredients   []Ingredient
+	Result        entity.Slot
+
+	// Experience and CookingTime (in ticks) are of the smelting, blasting, smoking and campfire cooking.
+	Experience  float32
+	CookingTime int
+}
+
+// Ingredient is the items accepted by a position of the Recipe, empty if no item is needed.
+type Ingredient []entity.Slot
+
+// Accepts reports whether the item can be used as the Ingredient.
+func (i Ingredient) Accepts(itemID int32) bool {
+	for _, s := range i {
+		if s.Present && s.ItemID == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// recipeBook stores the recipes declared by the server.
+type recipeBook struct {
+	mu      sync.RWMutex
+	recipes []Recipe
+	byID    map[string]int
+}
+
+func (b *recipeBook) set(recipes []Recipe) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.recipes = recipes
+	b.byID = make(map[string]int, len(recipes))
+	for i, r := range recipes {
+		b.byID[r.ID] = i
+	}
+}
+
+// Recipe return the recipe declared by the server by its ID.
+func (c *Client) Recipe(id string) (Recipe, bool) {
+	c.recipes.mu.RLock()
+	defer c.recipes.mu.RUnlock()
+	i, ok := c.recipes.byID[id]
+	if !ok {
+		return Recipe{}, false
+	}
+	return c.recipes.recipes[i], true
+}
+
+// RecipesFor return the recipes making the item.
+func (c *Client) RecipesFor(itemID int32) []Recipe {
+	c.recipes.mu.RLock()
+	defer c.recipes.mu.RUnlock()
+	var list []Recipe
+	for _, r := range c.recipes.recipes {
+		if r.Result.Present && r.Result.ItemID == itemID {
+			list = append(list, r)
+		}
+	}
+	return list
+}
+
+// RequestRecipe asks the server to fill the crafting grid of the opened window with the recipe,
+// as clicking a recipe in the recipe book. The server ignores it if the recipe isn't unlocked.
+func (c *Client) RequestRecipe(recipeID string, makeAll bool) error {
+	c.inv.mu.Lock()
+	id := c.inv.windowID()
+	c.inv.mu.Unlock()
+	return c.sendPacket(pk.Marshal(
+		c.packetID("craft_recipe_request"),
+		pk.Byte(id),
+		pk.Identifier(recipeID),
+		pk.Boolean(makeAll),
+	))
+}
+
+// Craft crafts the recipe count times by clicking the crafting grid,
+// then moves the results into the inventory.
+// The crafting table must be opened unless the recipe fits the 2x2 grid of the inventory.
+//
+// It waits for the server to update the crafting result,
+// so use a ctx with timeout in case the server never does.
+// When ctx is done, the returned error matches ErrCanceled.
+func (c *Client) Craft(ctx context.Context, recipe Recipe, count int) error {
+	for count > 0 {
+		var (
+			grid  map[int]int32
+			batch int
+		)
+		if err := c.withInventory(func() (err error) {
+			grid, batch, err = c.fillGrid(recipe, count)
+			return
+		}); err != nil {
+			return err
+		}
+		if err := c.waitInventory(ctx, func() bool {
+			return c.inv.slot(0).Present && len(c.inv.pending) == 0
+		}); err != nil {
+			return err
+		}
+
+		if err := c.withInventory(func() error {
+			return c.clickWindow(0, 0, ClickShift, false)
+		}); err != nil {
+			return err
+		}
+		// the server takes the ingredients and then updates the grid
+		if err := c.waitInventory(ctx, func() bool {
+			for slot, item := range grid {
+				if s := c.inv.slot(slot); s.Present && s.ItemID == item {
+					return false
+				}
+			}
+			return true
+		}); err != nil {
+			return err
+		}
+		// the containers of the ingredients are left in the grid, such as the buckets
+		if err := c.clearGrid(); err != nil {
+			return err
+		}
+		count -= batch
+	}
+	return nil
+}
+
+// craftingGrid return the size of the crafting grid in the window in focus,
+// 3 for the crafting table and 2 for the inventory. The first slot of the grid is 1.
+// The caller must hold c.inv.mu.
+func (c *Client) craftingGrid() int {
+	switch c.inv.windowType() {
+	case Crafting:
+		return 3
+	case InventoryWindow:
+		return 2
+	}
+	return 0
+}
+
+// fillGrid places the ingredients of up to count crafts into the crafting grid,
+// and return the items placed by the slots.
+// It must be called in withInventory.
+func (c *Client) fillGrid(recipe Recipe, count int) (grid map[int]int32, batch int, err error) {
+	size := c.craftingGrid()
+	var positions []int // the grid slots of the Ingredients
+	switch strings.TrimPrefix(recipe.Type, "minecraft:") {
+	case "crafting_shaped":
+		if recipe.Width > size || recipe.Height > size {
+			break
+		}
+		for y := 0; y < recipe.Height; y++ {
+			for x := 0; x < recipe.Width; x++ {
+				positions = append(positions, 1+y*size+x)
+			}
+		}
+	case "crafting_shapeless":
+		if len(recipe.Ingredients) > size*size {
+			break
+		}
+		for i := range recipe.Ingredients {
+			positions = append(positions, 1+i)
+		}
+	default:
+		return nil, 0, fmt.Errorf("bot: %s isn't a crafting recipe", recipe.ID)
+	}
+	if positions == nil || len(positions) != len(recipe.Ingredients) {
+		return nil, 0, fmt.Errorf("bot: recipe %s needs a crafting table", recipe.ID)
+	}
+	for i := 1; i <= size*size; i++ {
+		if c.inv.slot(i).Present {
+			return nil, 0, fmt.Errorf("bot: the crafting grid isn't empty")
+		}
+	}
+
+	// choose the items, the batch is limited by the stack size of the items
+	batch = count
+	// the ingredients are taken from the player's inventory, excluding the armor slots,
+	// and the results are shift-clicked into it, excluding the off hand
+	from, to, resultTo := 9, c.inv.size(), c.inv.size()-1
+	if size == 3 {
+		from, resultTo = len(c.inv.open.Slots), to
+	}
+	available := make(map[int32]int)
+	for i := from; i < to; i++ {
+		if s := c.inv.slot(i); s.Present {
+			available[s.ItemID] += int(s.Count)
+		}
+	}
+	grid = make(map[int]int32)
+	for i, ingredient := range recipe.Ingredients {
+		if len(ingredient) == 0 {
+			continue
+		}
+		found := false
+		for _, s := range ingredient {
+			if s.Present && available[s.ItemID] > 0 {
+				grid[positions[i]], found = s.ItemID, true
+				available[s.ItemID]--
+				if max := c.inv.maxStack(s); batch > max {
+					batch = max
+				}
+				break
+			}
+		}
+		if !found {
+			return nil, 0, fmt.Errorf("bot: no ingredients for %s: %w", recipe.ID, ErrItemNotFound)
+		}
+	}
+	for _, item := range grid {
+		available[item] -= batch - 1
+	}
+	for item, n := range available {
+		if n < 0 {
+			return nil, 0, fmt.Errorf("bot: need %d more item %d for %s: %w", -n, item, recipe.ID, ErrItemNotFound)
+		}
+	}
+	if space := c.inv.space(recipe.Result, from, resultTo); space < batch*int(recipe.Result.Count) {
+		return nil, 0, fmt.Errorf("bot: no space in the inventory for %s", recipe.ID)
+	}
+
+	for slot, item := range grid {
+		if err := c.placeItems(slot, item, batch, from, to); err != nil {
+			return nil, 0, err
+		}
+	}
+	return grid, batch, nil
+}
+
+// placeItems moves n items from the slots [from, to) to the slot.
+// It must be called in withInventory.
+func (c *Client) placeItems(slot int, item int32, n, from, to int) error {
+	for n > 0 {
+		src := -1
+		for i := from; i < to; i++ {
+			if s := c.inv.slot(i); s.Present && s.ItemID == item {
+				src = i
+				break
+			}
+		}
+		if src == -1 {
+			return ErrItemNotFound
+		}
+		if err := c.clickWindow(src, 0, ClickPickup, false); err != nil {
+			return err
+		}
+		if k := int(c.inv.cursor.Count); k <= n {
+			n -= k
+			if err := c.clickWindow(slot, 0, ClickPickup, false); err != nil {
+				return err
+			}
+			continue
+		}
+		for ; n > 0; n-- {
+			if err := c.clickWindow(slot, 1, ClickPickup, false); err != nil {
+				return err
+			}
+		}
+		if err := c.clickWindow(src, 0, ClickPickup, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearGrid moves the items in the crafting grid into the inventory.
+func (c *Client) clearGrid() error {
+	return c.withInventory(func() error {
+		size := c.craftingGrid()
+		for i := 1; i <= size*size; i++ {
+			if c.inv.slot(i).Present {
+				if err := c.clickWindow(i, 0, ClickShift, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// waitInventory blocks until cond reports true, checking it after every update from the server.
+// The cond is called with c.inv.mu locked.
+func (c *Client) waitInventory(ctx context.Context, cond func() bool) error {
+	for {
+		c.inv.mu.Lock()
+		ok := cond()
+		updated := c.inv.updated()
+		c.inv.mu.Unlock()
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return canceledError{ctx.Err()}
+		case <-updated:
+		}
+	}
+}
+
+func handleDeclareRecipesPacket(c *Client, p pk.Packet) error {
+	r := bytes.NewReader(p.Data)
+	var n pk.VarInt
+	if err := n.Decode(r); err != nil {
+		return err
+	}
+	var recipes []Recipe
+	for i := 0; i < int(n); i++ {
+		recipe, err := readRecipe(r)
+		if err != nil {
+			return fmt.Errorf("bot: read recipe %d fail: %v", i, err)
+		}
+		recipes = append(recipes, recipe)
+	}
+	c.recipes.set(recipes)
+	return nil
+}
+
+func readRecipe(r pk.DecodeReader) (recipe Recipe, err error) {
+	var typ, id pk.Identifier
+	if err = typ.Decode(r); err != nil {
+		return
+	}
+	if err = id.Decode(r); err != nil {
+		return
+	}
+	recipe.ID, recipe.Type = string(id), strings.TrimPrefix(string(typ), "minecraft:")
+
+	decode := func(fields ...pk.FieldDecoder) {
+		for _, f := range fields {
+			if err == nil {
+				err = f.Decode(r)
+			}
+		}
+	}
+	ingredients := func(n int) {
+		for i := 0; i < n && err == nil; i++ {
+			var (
+				count pk.VarInt
+				items Ingredient
+			)
+			decode(&count)
+			for j := 0; j < int(count) && err == nil; j++ {
+				var s entity.Slot
+				decode(&s)
+				items = append(items, s)
+			}
+			recipe.Ingredients = append(recipe.Ingredients, items)
+		}
+	}
+
+	var (
+		group         pk.String
+		width, height pk.VarInt
+		count         pk.VarInt
+		exp           pk.Float
+		time          pk.VarInt
+	)
+	switch recipe.Type {
+	case "crafting_shapeless":
+		decode(&group, &count)
+		ingredients(int(count))
+		decode(&recipe.Result)
+	case "crafting_shaped":
+		decode(&width, &height, &group)
+		ingredients(int(width) * int(height))
+		decode(&recipe.Result)
+		recipe.Width, recipe.Height = int(width), int(height)
+	case "smelting", "blasting", "smoking", "campfire_cooking":
+		decode(&group)
+		ingredients(1)
+		decode(&recipe.Result, &exp, &time)
+		recipe.Experience, recipe.CookingTime = float32(exp), int(time)
+	case "stonecutting":
+		decode(&group)
+		ingredients(1)
+		decode(&recipe.Result)
+	case "smithing":
+		ingredients(2)
+		decode(&recipe.Result)
+	default:
+		if !strings.HasPrefix(recipe.Type, "crafting_special_") {
+			err = fmt.Errorf("unknown recipe type %q", recipe.Type)
+		}
+	}
+	recipe.Group = string(group)
+	return
+}
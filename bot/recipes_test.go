@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+const (
+	planksItem = 13 // oak planks
+	stickItem  = 545
+)
+
+func TestClient_Craft(t *testing.T) {
+	c, s, closeFunc := newTestClient(readPackets)
+	defer closeFunc()
+	packets := data.Packets(ProtocolVersion, data.Play, data.Clientbound)
+	handle := func(name string, fields ...pk.FieldEncoder) {
+		id, _ := packets.ID(name)
+		if _, err := c.handlePacket(pk.Marshal(id, fields...)); err != nil {
+			t.Errorf("handle %s: %v", name, err)
+		}
+	}
+
+	recipes := []pk.FieldEncoder{pk.VarInt(3),
+		pk.Identifier("minecraft:crafting_shaped"), pk.String("minecraft:stick"),
+		pk.VarInt(1), pk.VarInt(2), pk.String("sticks"),
+	}
+	recipes = append(recipes, pk.VarInt(1))
+	recipes = append(recipes, item(planksItem, 1)...)
+	recipes = append(recipes, pk.VarInt(1))
+	recipes = append(recipes, item(planksItem, 1)...)
+	recipes = append(recipes, item(stickItem, 4)...)
+	recipes = append(recipes, pk.Identifier("minecraft:smelting"), pk.String("minecraft:stone"), pk.String(""), pk.VarInt(1))
+	recipes = append(recipes, item(12, 1)...)
+	recipes = append(recipes, item(stoneItem, 1)...)
+	recipes = append(recipes, pk.Float(0.1), pk.VarInt(200))
+	recipes = append(recipes, pk.Identifier("minecraft:crafting_special_bookcloning"), pk.String("minecraft:book_cloning"))
+	handle("declare_recipes", recipes...)
+
+	if r, ok := c.Recipe("minecraft:stone"); !ok || r.Type != "smelting" || r.CookingTime != 200 || !r.Ingredients[0].Accepts(12) {
+		t.Errorf("wrong smelting recipe: %+v", r)
+	}
+	if _, ok := c.Recipe("minecraft:book_cloning"); !ok {
+		t.Error("the special recipe isn't stored")
+	}
+	list := c.RecipesFor(stickItem)
+	if len(list) != 1 || list[0].Width != 1 || list[0].Height != 2 || list[0].Group != "sticks" {
+		t.Fatalf("wrong recipes for sticks: %+v", list)
+	}
+
+	fields := []pk.FieldEncoder{pk.Byte(0), pk.Short(46)}
+	for i := 0; i < 46; i++ {
+		switch i {
+		case 5: // an armor slot, never taken as the ingredients
+			fields = append(fields, item(planksItem, 4)...)
+		case 9:
+			fields = append(fields, item(planksItem, 3)...)
+		case 20:
+			fields = append(fields, item(planksItem, 5)...)
+		default:
+			fields = append(fields, pk.Boolean(false))
+		}
+	}
+	handle("window_items", fields...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- c.Craft(ctx, list[0], 2) }()
+
+	// a fake server crafting the sticks
+	resulted := false
+	for {
+		var p pk.Packet
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := c.Window()
+			if w.Slots[44].ItemID != stickItem || w.Slots[44].Count != 8 || w.Slots[9].Count+w.Slots[20].Count != 4 || w.Slots[5].Count != 4 {
+				t.Errorf("wrong inventory after crafting: %v", w.Slots)
+			}
+			return
+		case p = <-s.Sent:
+		}
+		var (
+			slot         pk.Short
+			action       pk.Short
+			button, mode pk.Byte
+		)
+		if err := p.Scan(new(pk.UnsignedByte), &slot, &button, &action, &mode); err != nil {
+			t.Fatal(err)
+		}
+		handle("confirm_transaction", pk.Byte(0), action, pk.Boolean(true))
+
+		w := c.Window()
+		switch {
+		case slot == 0 && mode == pk.Byte(ClickShift):
+			handle("set_slot", pk.Byte(0), pk.Short(1), pk.Boolean(false))
+			handle("set_slot", pk.Byte(0), pk.Short(3), pk.Boolean(false))
+			handle("set_slot", pk.Byte(0), pk.Short(0), pk.Boolean(false))
+			handle("set_slot", append([]pk.FieldEncoder{pk.Byte(0), pk.Short(44)}, item(stickItem, 8)...)...)
+		case !resulted && w.Slots[1].Count == 2 && w.Slots[3].Count == 2:
+			resulted = true
+			handle("set_slot", append([]pk.FieldEncoder{pk.Byte(0), pk.Short(0)}, item(stickItem, 4)...)...)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Tnze/go-mc/yggdrasil"
+)
+
+// Default backoff of Supervisor
+const (
+	DefaultMinBackoff = time.Second
+	DefaultMaxBackoff = 2 * time.Minute
+)
+
+// A Supervisor keeps a Client in game.
+// It joins the server, runs HandleGame, and when HandleGame returns
+// because of an error or the server disconnected the client,
+// it joins the server again with exponential backoff.
+//
+// Before each join, the world and the player state of the Client are reset,
+// and the access token is refreshed if it has expired.
+// After a successful rejoin, Events.Reconnect is called.
+type Supervisor struct {
+	Client *Client
+	Addr   string
+	Port   int
+
+	// Dialer is used to connect the server. If nil, net.Dialer is used.
+	Dialer Dialer
+	// Access is the account logged in with yggdrasil.
+	// If not nil, the token is validated before each join and refreshed if invalid,
+	// and the Auth of the Client is updated from it.
+	// Leave it nil for offline-mode.
+	Access *yggdrasil.Access
+
+	// MinBackoff is the delay before the first retry, doubled on each consecutive failure
+	// until MaxBackoff. It's reset after a game session lasts longer than MaxBackoff.
+	// If zero, DefaultMinBackoff and DefaultMaxBackoff are used.
+	MinBackoff, MaxBackoff time.Duration
+	// MaxRetries is the max count of consecutive failed joins. Zero means no limit.
+	MaxRetries int
+
+	// ShouldReconnect decides whether to reconnect after the error,
+	// which is returned by joining or HandleGame. It's nil if the server disconnected the client.
+	// If ShouldReconnect is nil, the Supervisor always reconnect.
+	ShouldReconnect func(err error) bool
+}
+
+// Run joins the server and keeps the Client in game until ctx is done,
+// ShouldReconnect returns false, MaxRetries is exceeded or Events.Reconnect returns an error.
+// If ctx is done, the returned error matches ErrCanceled.
+// The join errors matching ErrUnsupportedVersion are returned without retrying.
+func (s *Supervisor) Run(ctx context.Context) error {
+	c := s.Client
+	protocol := c.Protocol // re-negotiate on each join if it's zero
+	minBackoff, maxBackoff := s.MinBackoff, s.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultMinBackoff
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = DefaultMaxBackoff
+		if maxBackoff < minBackoff {
+			maxBackoff = minBackoff
+		}
+	}
+
+	var (
+		backoff    = minBackoff
+		failures   int
+		hadSession bool  // joined the game before
+		reason     error // why the last session ended
+	)
+	for first := true; ; first = false {
+		if !first {
+			t := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return canceledError{ctx.Err()}
+			case <-t.C:
+			}
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+
+		if err := s.join(ctx, protocol); err != nil {
+			if errors.Is(err, ErrCanceled) || errors.Is(err, ErrUnsupportedVersion) ||
+				s.ShouldReconnect != nil && !s.ShouldReconnect(err) {
+				return err
+			}
+			failures++
+			if s.MaxRetries > 0 && failures > s.MaxRetries {
+				return fmt.Errorf("bot: join fail after %d retries: %w", s.MaxRetries, err)
+			}
+			continue
+		}
+		failures = 0
+
+		if hadSession {
+			c.Bus.Publish(ReconnectEvent{Reason: reason})
+		}
+		if hadSession && c.Events.Reconnect != nil {
+			if err := c.Events.Reconnect(reason); err != nil {
+				c.conn.Close()
+				return err
+			}
+		}
+
+		start := time.Now()
+		reason = c.HandleGameContext(ctx)
+		hadSession = true
+		c.conn.Close()
+		if errors.Is(reason, ErrCanceled) || s.ShouldReconnect != nil && !s.ShouldReconnect(reason) {
+			return reason
+		}
+		if time.Since(start) > maxBackoff {
+			backoff = minBackoff
+		}
+	}
+}
+
+// join resets the Client and joins the server.
+func (s *Supervisor) join(ctx context.Context, protocol int) error {
+	c := s.Client
+	if s.Access != nil {
+		if err := s.refreshToken(); err != nil {
+			return err
+		}
+	}
+
+	c.resetState()
+	c.Protocol = protocol
+
+	d := s.Dialer
+	if d == nil {
+		d = &net.Dialer{}
+	}
+	return c.JoinServerWithDialerContext(ctx, d, s.Addr, s.Port)
+}
+
+// refreshToken refreshes the access token if it has expired,
+// and loads the account into the Client.
+func (s *Supervisor) refreshToken() error {
+	ok, err := s.Access.Validate()
+	if err != nil {
+		return fmt.Errorf("bot: validate token fail: %v", err)
+	}
+	if !ok {
+		if err := s.Access.Refresh(nil); err != nil {
+			return fmt.Errorf("bot: refresh token fail: %v", err)
+		}
+	}
+
+	c := s.Client
+	c.Auth.UUID, c.Auth.Name = s.Access.SelectedProfile()
+	c.AsTk = s.Access.AccessToken()
+	return nil
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/data"
+	mcnet "github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// a server lets the client login, then closes the connection immediately
+func kickServer(t *testing.T) (addr string, port int, closeFunc func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loginSuccess, _ := data.Packets(ProtocolVersion, data.Login, data.Clientbound).ID("login_success")
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				c := mcnet.WrapConn(conn)
+				defer c.Close()
+				for i := 0; i < 2; i++ { // handshake and login start
+					if _, err := c.ReadPacket(); err != nil {
+						return
+					}
+				}
+				_ = c.WritePacket(pk.Marshal(loginSuccess, pk.String(OfflineUUID("Steve").String()), pk.String("Steve")))
+			}()
+		}
+	}()
+	a := l.Addr().(*net.TCPAddr)
+	return a.IP.String(), a.Port, func() { l.Close() }
+}
+
+func TestSupervisor_Run(t *testing.T) {
+	addr, port, closeServer := kickServer(t)
+	defer closeServer()
+
+	c := NewClient()
+	c.Protocol = ProtocolVersion
+	errStop := errors.New("stop")
+	var reconnects int
+	c.Events.Reconnect = func(reason error) error {
+		if reason == nil {
+			t.Error("the reason of reconnecting should be the read error")
+		}
+		if reconnects++; reconnects == 3 {
+			return errStop
+		}
+		return nil
+	}
+
+	s := Supervisor{Client: c, Addr: addr, Port: port, MinBackoff: time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Run(ctx); err != errStop {
+		t.Fatalf("run should stop by the Reconnect event, get %v", err)
+	}
+
+	// canceled while waiting
+	c.Events.Reconnect = nil
+	s.MinBackoff = time.Hour
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.Run(ctx); !errors.Is(err, ErrCanceled) {
+		t.Errorf("run should be canceled, get %v", err)
+	}
+}
+
+// failDialer fails the first n dials.
+type failDialer struct{ n int }
+
+func (d *failDialer) Dial(network, addr string) (net.Conn, error) {
+	if d.n > 0 {
+		d.n--
+		return nil, errors.New("connection refused")
+	}
+	return net.Dial(network, addr)
+}
+
+func TestSupervisor_Run_failedJoin(t *testing.T) {
+	addr, port, closeServer := kickServer(t)
+	defer closeServer()
+
+	c := NewClient()
+	c.Protocol = ProtocolVersion
+	errStop := errors.New("stop")
+	c.Events.Reconnect = func(reason error) error {
+		if reason == nil {
+			t.Error("reconnected without a session before")
+		}
+		return errStop
+	}
+
+	s := Supervisor{Client: c, Addr: addr, Port: port, MinBackoff: time.Millisecond, Dialer: &failDialer{n: 2}}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Run(ctx); err != errStop {
+		t.Fatalf("run should stop by the Reconnect event, get %v", err)
+	}
+}
+
+func TestSupervisor_Run_unsupportedVersion(t *testing.T) {
+	c := NewClient()
+	c.Protocol = 735 // 1.16, the tables aren't loaded
+	s := Supervisor{Client: c, Addr: "127.0.0.1", Port: 25565, MinBackoff: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Run(ctx); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("run should stop without retrying, get %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/Tnze/go-mc/data"
+	mcnet "github.com/Tnze/go-mc/net"
+)
+
+// The first protocol versions of the packet formats the bot handles differently
+const (
+	protocol1_15 = 573
+	protocol1_16 = 735
+)
+
+// ErrUnsupportedVersion is returned by joining a server of the version the bot can't handle,
+// or whose data tables are not loaded. Retrying doesn't help.
+var ErrUnsupportedVersion = errors.New("bot: unsupported version")
+
+// SupportedProtocols lists the protocol versions the bot joins out of the box:
+// 1.15, 1.15.1 and 1.15.2, whose block states and registries are built in.
+var SupportedProtocols = []int{573, 575, 578}
+
+// handledProtocols lists the protocol versions whose packets the bot handles,
+// which are SupportedProtocols, 1.14.4, 1.16 and 1.16.1.
+// The servers of the versions not in SupportedProtocols can only be joined
+// after their reports are loaded by data.LoadBlockStates and data.LoadRegistries.
+var handledProtocols = []int{498, 573, 575, 578, 735, 736}
+
+func protocolSupported(protocol int) bool {
+	for _, v := range handledProtocols {
+		if v == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiate decides the protocol version used to join the server.
+// If c.Protocol is zero, the server is pinged through a connection from dial
+// and the version in its status response is used.
+func (c *Client) negotiate(ctx context.Context, dial func() (net.Conn, error), addr string, port int) error {
+	if c.Protocol == 0 {
+		conn, err := dial()
+		if err != nil {
+			return fmt.Errorf("bot: connect server fail: %v", err)
+		}
+		var w ctxWatcher
+		stop := w.watch(ctx, conn)
+		resp, _, err := pingAndList(addr, port, mcnet.WrapConn(conn))
+		stop()
+		conn.Close()
+		if err != nil {
+			return err
+		}
+
+		var s mcnet.StatusResponse
+		if err := json.Unmarshal(resp, &s); err != nil {
+			return fmt.Errorf("bot: unmarshal status fail: %v", err)
+		}
+		if !protocolSupported(s.Version.Protocol) {
+			return fmt.Errorf("bot: server version %s (protocol %d): %w", s.Version.Name, s.Version.Protocol, ErrUnsupportedVersion)
+		}
+		c.Protocol = s.Version.Protocol
+	}
+
+	if !protocolSupported(c.Protocol) {
+		return fmt.Errorf("bot: protocol version %d: %w", c.Protocol, ErrUnsupportedVersion)
+	}
+	return tablesLoaded(c.Protocol)
+}
+
+// tablesLoaded return an error if the data tables of the protocol version are not loaded,
+// without them the blocks, the items and the entities can't be known.
+func tablesLoaded(protocol int) error {
+	if data.Blocks(protocol) == nil {
+		return fmt.Errorf("bot: no block states of protocol %d, load them by data.LoadBlockStates: %w", protocol, ErrUnsupportedVersion)
+	}
+	if data.Items(protocol) == nil || data.Entities(protocol) == nil {
+		return fmt.Errorf("bot: no registries of protocol %d, load them by data.LoadRegistries: %w", protocol, ErrUnsupportedVersion)
+	}
+	return nil
+}
+
+// setTables use the data tables of c.Protocol.
+func (c *Client) setTables() {
+	c.Wd.BlockStates = data.Blocks(c.Protocol)
+	c.Wd.EntityTypes = data.Entities(c.Protocol)
+	c.inv.mu.Lock()
+	c.inv.items = data.Items(c.Protocol)
+	c.inv.mu.Unlock()
+}
+
+// packetID return the ID of the named serverbound packet.
+// It panics if the packet doesn't exist, which means a bug.
+func packetID(protocol int, state data.State, name string) byte {
+	id, ok := data.Packets(protocol, state, data.Serverbound).ID(name)
+	if !ok {
+		panic(fmt.Sprintf("bot: unknown %v packet %q in protocol %d", state, name, protocol))
+	}
+	return id
+}
+
+// packetID return the ID of the named serverbound packet in play state.
+func (c *Client) packetID(name string) byte {
+	return packetID(c.Protocol, data.Play, name)
+}
+
+// packetName return the name of the clientbound packet in play state.
+func (c *Client) packetName(id byte) (string, bool) {
+	return data.Packets(c.Protocol, data.Play, data.Clientbound).Name(id)
+}
@@ -0,0 +1,645 @@
+package bot
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/Tnze/go-mc/bot/world/entity"
+	"github.com/Tnze/go-mc/chat"
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// WindowType is the type of a window.
+type WindowType int
+
+// All kinds of WindowType
+const (
+	InventoryWindow WindowType = iota // the player's inventory, whose ID is always 0
+	Generic9x1
+	Generic9x2
+	Generic9x3 // chest
+	Generic9x4
+	Generic9x5
+	Generic9x6 // large chest
+	Generic3x3 // dispenser and dropper
+	Anvil
+	Beacon
+	BlastFurnace
+	BrewingStand
+	Crafting
+	Enchantment
+	Furnace
+	Grindstone
+	Hopper
+	Lectern
+	Loom
+	Merchant
+	ShulkerBox
+	Smithing
+	Smoker
+	Cartography
+	Stonecutter
+	HorseWindow // opened by the Open Horse Window packet
+)
+
+// The window types in the Open Window packet
+var (
+	windowTypes1_14 = []WindowType{
+		Generic9x1, Generic9x2, Generic9x3, Generic9x4, Generic9x5, Generic9x6, Generic3x3,
+		Anvil, Beacon, BlastFurnace, BrewingStand, Crafting, Enchantment, Furnace, Grindstone,
+		Hopper, Lectern, Loom, Merchant, ShulkerBox, Smoker, Cartography, Stonecutter,
+	}
+	windowTypes1_16 = []WindowType{
+		Generic9x1, Generic9x2, Generic9x3, Generic9x4, Generic9x5, Generic9x6, Generic3x3,
+		Anvil, Beacon, BlastFurnace, BrewingStand, Crafting, Enchantment, Furnace, Grindstone,
+		Hopper, Lectern, Loom, Merchant, ShulkerBox, Smithing, Smoker, Cartography, Stonecutter,
+	}
+)
+
+// maxHorseSlots is the most slots of a HorseWindow,
+// which are the saddle, the armor and the 15 chest slots of a strongest llama.
+const maxHorseSlots = 2 + 15
+
+// ContainerSlots return the number of the slots before the player's inventory in the window.
+// For HorseWindow it depends on the horse, and for InventoryWindow it's 9 (crafting and armor).
+func (t WindowType) ContainerSlots() int {
+	switch t {
+	case InventoryWindow:
+		return 9
+	case Generic9x1, Generic9x2, Generic9x3, Generic9x4, Generic9x5, Generic9x6:
+		return 9 * int(t-Generic9x1+1)
+	case Generic3x3:
+		return 9
+	case Beacon, Lectern:
+		return 1
+	case Enchantment, Stonecutter:
+		return 2
+	case Anvil, BlastFurnace, Furnace, Grindstone, Merchant, Smithing, Smoker, Cartography:
+		return 3
+	case Loom:
+		return 4
+	case BrewingStand, Hopper:
+		return 5
+	case Crafting:
+		return 10
+	case ShulkerBox:
+		return 27
+	}
+	return 0
+}
+
+// ResultSlot return the output slot of the window, such as the crafting result, or -1 if none.
+// Items can be taken from but not placed into the output slot.
+func (t WindowType) ResultSlot() int {
+	switch t {
+	case InventoryWindow, Crafting:
+		return 0
+	case Stonecutter:
+		return 1
+	case Anvil, BlastFurnace, Furnace, Grindstone, Merchant, Smithing, Smoker, Cartography:
+		return 2
+	case Loom:
+		return 3
+	}
+	return -1
+}
+
+// Window is a window showing the slots of a container and the player's inventory.
+type Window struct {
+	ID    int
+	Type  WindowType
+	Title chat.Message
+	// Slots are the slots of the container, followed by the 27 slots of
+	// the player's main inventory and the 9 slots of the hotbar.
+	// The slots of the InventoryWindow are:
+	//	0: crafting output, 1-4: crafting input, 5-8: armor from helmet to boots,
+	//	9-35: main inventory, 36-44: hotbar, 45: off hand.
+	Slots []entity.Slot
+	// Properties are set by the Window Property packets, such as the progress of a furnace.
+	Properties map[int]int
+}
+
+// Hotbar return the index of the hotbar slot i (0-8) in the window,
+// or -1 if the window doesn't show the player's inventory.
+func (w Window) Hotbar(i int) int {
+	switch w.Type {
+	case InventoryWindow:
+		return 36 + i
+	case Lectern:
+		return -1
+	}
+	return len(w.Slots) - 9 + i
+}
+
+// FindItem return the index of the first slot holding the item, except the result slot.
+func (w Window) FindItem(itemID int32) (slot int, ok bool) {
+	result := w.Type.ResultSlot()
+	for i, s := range w.Slots {
+		if i != result && s.Present && s.ItemID == itemID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// ClickMode is the mode of the Click Window packet.
+type ClickMode int
+
+// All kinds of ClickMode, the buttons are:
+//
+//	ClickPickup: 0 for left click and 1 for right click.
+//	ClickShift: 0 or 1, moves the stack to the other part of the window.
+//	ClickNumberKey: 0-8 for the hotbar slots, and 40 for the off hand since 1.16.
+//	ClickMiddle: 2, clones the stack in creative mode.
+//	ClickDrop: 0 drops one item and 1 drops the whole stack.
+//	ClickDrag: see DragItems.
+//	ClickDouble: 0, collects the same items to the cursor.
+const (
+	ClickPickup ClickMode = iota
+	ClickShift
+	ClickNumberKey
+	ClickMiddle
+	ClickDrop
+	ClickDrag
+	ClickDouble
+)
+
+// SlotOutside is the slot clicked when clicking outside the window, to drop the item on the cursor.
+const SlotOutside = -999
+
+// inventory tracks the player's inventory, the opened window and the item on the cursor.
+// The clicks are applied immediately and rolled back if the server rejects them.
+type inventory struct {
+	mu     sync.Mutex
+	slots  [46]entity.Slot // the InventoryWindow
+	open   *Window         // nil if no container is opened, its Slots only include the container
+	cursor entity.Slot
+
+	action  int16 // the last action number
+	pending []transaction
+	drag    dragging
+
+	changed chan struct{}  // closed when updated by the server
+	queued  []pk.Packet    // the packets of the actions, sent by withInventory
+	sendMu  sync.Mutex     // held by withInventory while sending the packets
+	items   *data.Registry // the items of the protocol version, for the stack sizes
+}
+
+// transaction is a click waiting for the confirmation of the server.
+type transaction struct {
+	windowID int
+	action   int16
+	slots    []entity.Slot // the window before the click
+	cursor   entity.Slot
+}
+
+type dragging struct {
+	button int // 0: left, 1: right, 2: middle
+	slots  []int
+}
+
+func (inv *inventory) reset() {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.slots = [46]entity.Slot{}
+	inv.cursor = entity.Slot{}
+	inv.action = 0
+	inv.openWindow(nil)
+}
+
+func (inv *inventory) windowID() int {
+	if inv.open == nil {
+		return 0
+	}
+	return inv.open.ID
+}
+
+func (inv *inventory) windowType() WindowType {
+	if inv.open == nil {
+		return InventoryWindow
+	}
+	return inv.open.Type
+}
+
+// size return the number of the slots in the focused window.
+func (inv *inventory) size() int {
+	if inv.open == nil {
+		return len(inv.slots)
+	}
+	if inv.open.Type == Lectern {
+		return len(inv.open.Slots) // the lectern doesn't show the inventory
+	}
+	return len(inv.open.Slots) + 36
+}
+
+// slot return the slot i of the focused window, nil if out of range.
+func (inv *inventory) slot(i int) *entity.Slot {
+	if i < 0 || i >= inv.size() {
+		return nil
+	}
+	if inv.open == nil {
+		return &inv.slots[i]
+	}
+	n := len(inv.open.Slots)
+	if i < n {
+		return &inv.open.Slots[i]
+	}
+	return &inv.slots[9+i-n] // the main inventory and the hotbar
+}
+
+// window return a copy of the focused window.
+func (inv *inventory) window() Window {
+	if inv.open == nil {
+		return Window{Type: InventoryWindow, Slots: append([]entity.Slot(nil), inv.slots[:]...)}
+	}
+	w := *inv.open
+	w.Slots = inv.snapshot()
+	w.Properties = make(map[int]int, len(inv.open.Properties))
+	for k, v := range inv.open.Properties {
+		w.Properties[k] = v
+	}
+	return w
+}
+
+func (inv *inventory) snapshot() []entity.Slot {
+	slots := make([]entity.Slot, inv.size())
+	for i := range slots {
+		slots[i] = *inv.slot(i)
+	}
+	return slots
+}
+
+func (inv *inventory) restore(slots []entity.Slot) {
+	for i, s := range slots {
+		if p := inv.slot(i); p != nil {
+			*p = s
+		}
+	}
+}
+
+// openWindow replaces the opened window, dropping the pending clicks.
+func (inv *inventory) openWindow(w *Window) {
+	inv.open = w
+	inv.pending = nil
+	inv.drag = dragging{}
+	inv.notify()
+}
+
+// updated return a channel closed at the next update from the server.
+func (inv *inventory) updated() <-chan struct{} {
+	if inv.changed == nil {
+		inv.changed = make(chan struct{})
+	}
+	return inv.changed
+}
+
+func (inv *inventory) notify() {
+	if inv.changed != nil {
+		close(inv.changed)
+		inv.changed = nil
+	}
+}
+
+// setSlot sets a slot of the window by the Set Slot or Window Items packets.
+// The updates of the windows not opened are ignored.
+func (inv *inventory) setSlot(windowID, i int, s entity.Slot) {
+	defer inv.notify()
+	switch {
+	case windowID == -1 && i == -1:
+		inv.cursor = s
+	case windowID == 0 || windowID == -2:
+		if i >= 0 && i < len(inv.slots) {
+			inv.slots[i] = s
+		}
+	case windowID == inv.windowID():
+		if p := inv.slot(i); p != nil {
+			*p = s
+		}
+	}
+}
+
+// confirm handles the Confirm Transaction packet.
+// A rejected click is rolled back with the clicks after it,
+// then the server resends the window.
+func (inv *inventory) confirm(windowID int, action int16, accepted bool) {
+	defer inv.notify()
+	for i, t := range inv.pending {
+		if t.windowID != windowID || t.action != action {
+			continue
+		}
+		if accepted {
+			inv.pending = inv.pending[i+1:]
+		} else {
+			if windowID == inv.windowID() {
+				inv.restore(t.slots)
+				inv.cursor = t.cursor
+			}
+			inv.pending = inv.pending[:i]
+		}
+		return
+	}
+}
+
+// click applies the click to the focused window as the server does,
+// and return the transaction of it and the item in the clicked slot before the click.
+func (inv *inventory) click(slot, button int, mode ClickMode, creative bool) (t transaction, clicked entity.Slot) {
+	inv.action++
+	t = transaction{
+		windowID: inv.windowID(),
+		action:   inv.action,
+		slots:    inv.snapshot(),
+		cursor:   inv.cursor,
+	}
+	if s := inv.slot(slot); s != nil {
+		clicked = *s
+	}
+	inv.pending = append(inv.pending, t)
+
+	switch mode {
+	case ClickPickup:
+		inv.pickup(slot, button)
+	case ClickShift:
+		if s := inv.slot(slot); s != nil && s.Present {
+			inv.shift(slot, s)
+		}
+	case ClickNumberKey:
+		inv.swapHotbar(slot, button)
+	case ClickMiddle:
+		if s := inv.slot(slot); creative && s != nil && s.Present && !inv.cursor.Present {
+			inv.cursor = *s
+			inv.cursor.Count = int8(inv.maxStack(*s))
+		}
+	case ClickDrop:
+		if s := inv.slot(slot); s != nil && s.Present && !inv.cursor.Present {
+			if button == 0 {
+				take(s, 1)
+			} else {
+				*s = entity.Slot{}
+			}
+		}
+	case ClickDrag:
+		inv.dragClick(slot, button, creative)
+	case ClickDouble:
+		inv.collect(slot)
+	}
+	return
+}
+
+func (inv *inventory) pickup(slot, button int) {
+	c := &inv.cursor
+	if slot == SlotOutside {
+		if c.Present {
+			if button == 0 {
+				*c = entity.Slot{}
+			} else {
+				take(c, 1)
+			}
+		}
+		return
+	}
+	s := inv.slot(slot)
+	if s == nil {
+		return
+	}
+	if slot == inv.windowType().ResultSlot() {
+		// take the result if the cursor can hold all of it
+		if s.Present && (!c.Present || sameItem(*c, *s) && int(c.Count+s.Count) <= inv.maxStack(*s)) {
+			if c.Present {
+				c.Count += s.Count
+			} else {
+				*c = *s
+			}
+			*s = entity.Slot{}
+		}
+		return
+	}
+
+	switch {
+	case !c.Present && !s.Present:
+	case !c.Present: // pick up
+		n := s.Count
+		if button == 1 {
+			n = (n + 1) / 2
+		}
+		*c = *s
+		c.Count = n
+		take(s, n)
+	case !s.Present: // put down
+		n := c.Count
+		if button == 1 {
+			n = 1
+		}
+		*s = *c
+		s.Count = n
+		take(c, n)
+	case sameItem(*c, *s): // merge
+		n := int(c.Count)
+		if button == 1 {
+			n = 1
+		}
+		if space := inv.maxStack(*s) - int(s.Count); n > space {
+			n = space
+		}
+		if n > 0 {
+			s.Count += int8(n)
+			take(c, int8(n))
+		}
+	default: // swap
+		*c, *s = *s, *c
+	}
+}
+
+// shift moves the stack to the other part of the window, as the shift-click.
+// The server may place some items differently, such as fuels of a furnace, and then corrects the slots.
+func (inv *inventory) shift(slot int, s *entity.Slot) {
+	size := inv.size()
+	if inv.open == nil {
+		switch {
+		case slot >= 9 && slot < 36:
+			inv.merge(s, 36, 45, false)
+		case slot >= 36 && slot < 45:
+			inv.merge(s, 9, 36, false)
+		default:
+			inv.merge(s, 9, 45, slot == 0)
+		}
+		return
+	}
+	if n := len(inv.open.Slots); slot < n {
+		inv.merge(s, n, size, true)
+	} else {
+		inv.merge(s, 0, n, false)
+	}
+}
+
+// merge moves the items in s to the slots [from, to),
+// filling the same items first and then the empty slots.
+func (inv *inventory) merge(s *entity.Slot, from, to int, reverse bool) {
+	result := inv.windowType().ResultSlot()
+	each := func(f func(d *entity.Slot)) {
+		for i := from; i < to && s.Present; i++ {
+			j := i
+			if reverse {
+				j = to - 1 - (i - from)
+			}
+			if d := inv.slot(j); d != nil && d != s && j != result {
+				f(d)
+			}
+		}
+	}
+	max := inv.maxStack(*s)
+	each(func(d *entity.Slot) {
+		if d.Present && sameItem(*d, *s) && int(d.Count) < max {
+			n := int(s.Count)
+			if space := max - int(d.Count); n > space {
+				n = space
+			}
+			d.Count += int8(n)
+			take(s, int8(n))
+		}
+	})
+	each(func(d *entity.Slot) {
+		if !d.Present {
+			*d, *s = *s, entity.Slot{}
+		}
+	})
+}
+
+func (inv *inventory) swapHotbar(slot, button int) {
+	s := inv.slot(slot)
+	if s == nil {
+		return
+	}
+	var h *entity.Slot
+	switch {
+	case button >= 0 && button < 9:
+		h = &inv.slots[36+button]
+	case button == 40:
+		h = &inv.slots[45]
+	default:
+		return
+	}
+	if slot == inv.windowType().ResultSlot() {
+		// the result can only be moved into an empty slot
+		if h.Present {
+			return
+		}
+	}
+	*s, *h = *h, *s
+}
+
+func (inv *inventory) dragClick(slot, button int, creative bool) {
+	kind, stage := button>>2, button&3
+	switch {
+	case stage == 0 && slot == SlotOutside: // start
+		inv.drag = dragging{button: kind}
+	case stage == 1 && kind == inv.drag.button: // add slot
+		s := inv.slot(slot)
+		c := inv.cursor
+		if s == nil || !c.Present || slot == inv.windowType().ResultSlot() ||
+			s.Present && !sameItem(*s, c) || len(inv.drag.slots) >= int(c.Count) && kind != 2 {
+			return
+		}
+		for _, i := range inv.drag.slots {
+			if i == slot {
+				return
+			}
+		}
+		inv.drag.slots = append(inv.drag.slots, slot)
+	case stage == 2 && kind == inv.drag.button && slot == SlotOutside: // end
+		slots := inv.drag.slots
+		inv.drag = dragging{}
+		if len(slots) == 0 || !inv.cursor.Present || kind == 2 && !creative {
+			return
+		}
+		c := &inv.cursor
+		max := inv.maxStack(*c)
+		var each int
+		switch kind {
+		case 0:
+			each = int(c.Count) / len(slots)
+		case 1:
+			each = 1
+		case 2:
+			each = max
+		}
+		for _, i := range slots {
+			s := inv.slot(i)
+			if s.Present && !sameItem(*s, *c) {
+				continue
+			}
+			n := each
+			if s.Present && int(s.Count)+n > max {
+				n = max - int(s.Count)
+			}
+			if !s.Present {
+				*s = *c
+				s.Count = 0
+			}
+			s.Count += int8(n)
+			if kind != 2 {
+				take(c, int8(n))
+			}
+		}
+	default:
+		inv.drag = dragging{}
+	}
+}
+
+// collect picks up the items same as the cursor, the not full stacks first.
+func (inv *inventory) collect(slot int) {
+	c := &inv.cursor
+	if !c.Present {
+		return
+	}
+	max := inv.maxStack(*c)
+	result := inv.windowType().ResultSlot()
+	for _, full := range []bool{false, true} {
+		for i := 0; i < inv.size() && int(c.Count) < max; i++ {
+			s := inv.slot(i)
+			if i == result || !s.Present || !sameItem(*s, *c) || (int(s.Count) == inv.maxStack(*s)) != full {
+				continue
+			}
+			n := max - int(c.Count)
+			if n > int(s.Count) {
+				n = int(s.Count)
+			}
+			c.Count += int8(n)
+			take(s, int8(n))
+		}
+	}
+}
+
+// space return how many items like s can be put into the slots [from, to).
+func (inv *inventory) space(s entity.Slot, from, to int) (n int) {
+	max := inv.maxStack(s)
+	for i := from; i < to; i++ {
+		switch t := inv.slot(i); {
+		case !t.Present:
+			n += max
+		case sameItem(*t, s):
+			n += max - int(t.Count)
+		}
+	}
+	return
+}
+
+// take removes n items from the slot.
+func take(s *entity.Slot, n int8) {
+	s.Count -= n
+	if s.Count <= 0 {
+		*s = entity.Slot{}
+	}
+}
+
+func sameItem(a, b entity.Slot) bool {
+	return a.ItemID == b.ItemID && reflect.DeepEqual(a.NBT, b.NBT)
+}
+
+// maxStack return the max count of the item in one slot, 64 if the item is unknown.
+func (inv *inventory) maxStack(s entity.Slot) int {
+	if name := inv.items.Name(int(s.ItemID)); name != "" {
+		return data.MaxStackSize(name)
+	}
+	return 64
+}
@@ -0,0 +1,281 @@
+package world
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/Tnze/go-mc/chat"
+	"github.com/Tnze/go-mc/nbt"
+	"github.com/Tnze/go-mc/save"
+)
+
+// BlockEntity is the extra data of a block, like the text of a sign or the settings of a spawner.
+type BlockEntity struct {
+	ID      string // such as "minecraft:sign"
+	X, Y, Z int
+	// Data is the NBT compound sent by the server, as decoded by the nbt package.
+	// It's shared by the copies of the BlockEntity, so don't modify it.
+	Data map[string]interface{}
+}
+
+// blockPos is the position of a block in the world.
+type blockPos struct{ x, y, z int }
+
+// NewBlockEntity make the BlockEntity from the NBT compound sent by the server,
+// which contains the id and the position.
+func NewBlockEntity(data map[string]interface{}) BlockEntity {
+	e := BlockEntity{Data: data}
+	e.ID, _ = data["id"].(string)
+	x, _ := data["x"].(int32)
+	y, _ := data["y"].(int32)
+	z, _ := data["z"].(int32)
+	e.X, e.Y, e.Z = int(x), int(y), int(z)
+	return e
+}
+
+// Decode decodes the Data into v by the nbt package, like a struct with the nbt tags.
+func (e BlockEntity) Decode(v interface{}) error {
+	var buf bytes.Buffer
+	if err := nbt.Marshal(&buf, e.Data); err != nil {
+		return err
+	}
+	return nbt.Unmarshal(buf.Bytes(), v)
+}
+
+// check return an error if the block entity isn't one of the IDs, which are without "minecraft:".
+func (e BlockEntity) check(kind string, ids ...string) error {
+	id := strings.TrimPrefix(e.ID, "minecraft:")
+	for _, v := range ids {
+		if id == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("world: block entity %s isn't a %s", e.ID, kind)
+}
+
+// Sign is the text on a sign.
+type Sign struct {
+	Lines [4]chat.Message
+	Color string // the dye color of the text, such as "black"
+}
+
+// Sign return the text if the block entity is a sign.
+func (e BlockEntity) Sign() (s Sign, err error) {
+	if err = e.check("sign", "sign"); err != nil {
+		return
+	}
+	var data struct {
+		Text1, Text2, Text3, Text4 string
+		Color                      string
+	}
+	if err = e.Decode(&data); err != nil {
+		return
+	}
+	for i, text := range []string{data.Text1, data.Text2, data.Text3, data.Text4} {
+		s.Lines[i] = chat.ParseText(text)
+	}
+	s.Color = data.Color
+	return
+}
+
+// Container is a block holding items, like a chest, a barrel or a furnace.
+//
+// The vanilla server doesn't send the items in the chunks,
+// so Items are usually empty until the container is opened.
+type Container struct {
+	Items      []save.Item
+	CustomName *chat.Message // nil if not renamed
+	Lock       string        // the name of the item opening the container, empty if not locked
+	LootTable  string        // the loot table of a chest not opened yet
+}
+
+// containers are the IDs of the block entities holding items.
+var containers = []string{
+	"chest", "trapped_chest", "barrel", "shulker_box",
+	"furnace", "blast_furnace", "smoker", "brewing_stand",
+	"dispenser", "dropper", "hopper",
+}
+
+// Container return the items and the name if the block entity is a container.
+func (e BlockEntity) Container() (c Container, err error) {
+	if err = e.check("container", containers...); err != nil {
+		return
+	}
+	var data struct {
+		Items      []save.Item
+		CustomName string
+		Lock       string
+		LootTable  string
+	}
+	if err = e.Decode(&data); err != nil {
+		return
+	}
+	c.Items, c.Lock, c.LootTable = data.Items, data.Lock, data.LootTable
+	c.CustomName = customName(data.CustomName)
+	return
+}
+
+// Banner is the patterns on a banner.
+type Banner struct {
+	Patterns   []BannerPattern
+	CustomName *chat.Message // nil if not renamed
+}
+
+// BannerPattern is a layer of the patterns on a banner.
+type BannerPattern struct {
+	Pattern string // the code of the pattern, such as "cr" for the cross
+	Color   int32  // the dye color ID
+}
+
+// Banner return the patterns if the block entity is a banner.
+func (e BlockEntity) Banner() (b Banner, err error) {
+	if err = e.check("banner", "banner"); err != nil {
+		return
+	}
+	var data struct {
+		Patterns   []BannerPattern
+		CustomName string
+	}
+	if err = e.Decode(&data); err != nil {
+		return
+	}
+	b.Patterns, b.CustomName = data.Patterns, customName(data.CustomName)
+	return
+}
+
+// Spawner is the settings of a monster spawner.
+type Spawner struct {
+	Entity string // the ID of the spawned entities, such as "minecraft:zombie"
+	Delay  int16  // the ticks before the next spawn
+
+	MinSpawnDelay, MaxSpawnDelay int16
+	SpawnCount, SpawnRange       int16
+	MaxNearbyEntities            int16
+	RequiredPlayerRange          int16
+}
+
+// Spawner return the settings if the block entity is a monster spawner.
+func (e BlockEntity) Spawner() (s Spawner, err error) {
+	if err = e.check("spawner", "mob_spawner"); err != nil {
+		return
+	}
+	var data struct {
+		SpawnData struct {
+			ID string `nbt:"id"`
+		}
+		Delay, MinSpawnDelay, MaxSpawnDelay    int16
+		SpawnCount, SpawnRange                 int16
+		MaxNearbyEntities, RequiredPlayerRange int16
+	}
+	if err = e.Decode(&data); err != nil {
+		return
+	}
+	s = Spawner{
+		Entity:              data.SpawnData.ID,
+		Delay:               data.Delay,
+		MinSpawnDelay:       data.MinSpawnDelay,
+		MaxSpawnDelay:       data.MaxSpawnDelay,
+		SpawnCount:          data.SpawnCount,
+		SpawnRange:          data.SpawnRange,
+		MaxNearbyEntities:   data.MaxNearbyEntities,
+		RequiredPlayerRange: data.RequiredPlayerRange,
+	}
+	return
+}
+
+// CommandBlock is the command and the state of a command block.
+type CommandBlock struct {
+	Command      string
+	CustomName   *chat.Message // nil if not renamed
+	LastOutput   *chat.Message // nil if no output is tracked
+	SuccessCount int32
+	Auto         bool // always active, instead of needing redstone
+	Powered      bool
+	TrackOutput  bool
+}
+
+// CommandBlock return the command if the block entity is a command block.
+func (e BlockEntity) CommandBlock() (c CommandBlock, err error) {
+	if err = e.check("command block", "command_block"); err != nil {
+		return
+	}
+	var data struct {
+		Command      string
+		CustomName   string
+		LastOutput   string
+		SuccessCount int32
+		Auto         bool `nbt:"auto"`
+		Powered      bool `nbt:"powered"`
+		TrackOutput  bool
+	}
+	if err = e.Decode(&data); err != nil {
+		return
+	}
+	c = CommandBlock{
+		Command:      data.Command,
+		CustomName:   customName(data.CustomName),
+		LastOutput:   customName(data.LastOutput),
+		SuccessCount: data.SuccessCount,
+		Auto:         data.Auto,
+		Powered:      data.Powered,
+		TrackOutput:  data.TrackOutput,
+	}
+	return
+}
+
+// customName return nil for the empty name.
+func customName(s string) *chat.Message {
+	if s == "" {
+		return nil
+	}
+	msg := chat.ParseText(s)
+	return &msg
+}
+
+// BlockEntity return the block entity in the position.
+func (w *World) BlockEntity(x, y, z int) (e BlockEntity, ok bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if c := w.Chunks[ChunkLoc{x >> 4, z >> 4}]; c != nil {
+		e, ok = c.blockEntities[blockPos{x, y, z}]
+	}
+	return
+}
+
+// BlockEntities return the block entities in the chunk at (x, z).
+func (w *World) BlockEntities(x, z int) []BlockEntity {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	c := w.Chunks[ChunkLoc{x, z}]
+	if c == nil {
+		return nil
+	}
+	list := make([]BlockEntity, 0, len(c.blockEntities))
+	for _, e := range c.blockEntities {
+		list = append(list, e)
+	}
+	return list
+}
+
+// SetBlockEntity add or replace the block entity in its position.
+// It does nothing if the chunk isn't loaded.
+func (w *World) SetBlockEntity(e BlockEntity) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if c := w.Chunks[ChunkLoc{e.X >> 4, e.Z >> 4}]; c != nil {
+		if c.blockEntities == nil {
+			c.blockEntities = make(map[blockPos]BlockEntity)
+		}
+		c.blockEntities[blockPos{e.X, e.Y, e.Z}] = e
+	}
+}
+
+// RemoveBlockEntity remove the block entity in the position.
+func (w *World) RemoveBlockEntity(x, y, z int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if c := w.Chunks[ChunkLoc{x >> 4, z >> 4}]; c != nil {
+		delete(c.blockEntities, blockPos{x, y, z})
+	}
+}
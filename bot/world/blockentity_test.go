@@ -0,0 +1,72 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/Tnze/go-mc/data"
+)
+
+func TestWorld_BlockEntity(t *testing.T) {
+	w := World{BlockStates: data.Blocks(578)}
+	w.LoadChunk(0, 0, new(Chunk))
+	sign := uint(w.BlockStates.DefaultByName["minecraft:oak_sign"])
+	w.SetBlock(1, 64, 2, Block{ID: sign})
+
+	w.SetBlockEntity(NewBlockEntity(map[string]interface{}{
+		"id": "minecraft:sign", "x": int32(1), "y": int32(64), "z": int32(2),
+		"Text1": `{"text":"Hello"}`, "Text2": `""`, "Text3": "plain", "Text4": `""`,
+		"Color": "black",
+	}))
+	w.SetBlockEntity(NewBlockEntity(map[string]interface{}{
+		"id": "minecraft:mob_spawner", "x": int32(3), "y": int32(10), "z": int32(4),
+		"SpawnData": map[string]interface{}{"id": "minecraft:zombie"},
+		"Delay":     int16(20), "SpawnCount": int16(4), "SpawnRange": int16(4),
+	}))
+	// not loaded
+	w.SetBlockEntity(NewBlockEntity(map[string]interface{}{"id": "minecraft:chest", "x": int32(100)}))
+
+	e, ok := w.BlockEntity(1, 64, 2)
+	if !ok {
+		t.Fatal("the sign isn't found")
+	}
+	s, err := e.Sign()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Lines[0].Text != "Hello" || s.Lines[2].Text != "plain" || s.Color != "black" {
+		t.Errorf("wrong sign: %+v", s)
+	}
+	if _, err := e.Spawner(); err == nil {
+		t.Error("the sign is a spawner")
+	}
+
+	e, _ = w.BlockEntity(3, 10, 4)
+	sp, err := e.Spawner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp.Entity != "minecraft:zombie" || sp.Delay != 20 || sp.SpawnCount != 4 {
+		t.Errorf("wrong spawner: %+v", sp)
+	}
+	if n := len(w.BlockEntities(0, 0)); n != 2 {
+		t.Errorf("%d block entities in the chunk, want 2", n)
+	}
+	if n := len(w.BlockEntities(6, 0)); n != 0 {
+		t.Errorf("the block entity of an unloaded chunk is added")
+	}
+
+	// another state of the sign keeps the block entity, but air removes it
+	w.SetBlock(1, 64, 2, Block{ID: sign + 2})
+	if _, ok := w.BlockEntity(1, 64, 2); !ok {
+		t.Error("the block entity is removed by a state change")
+	}
+	w.SetBlock(1, 64, 2, Block{})
+	if _, ok := w.BlockEntity(1, 64, 2); ok {
+		t.Error("the block entity isn't removed with the block")
+	}
+	// the section 0 is sent again
+	w.UpdateSections(0, 0, 1, new(Chunk))
+	if _, ok := w.BlockEntity(3, 10, 4); ok {
+		t.Error("the block entity isn't removed with the section")
+	}
+}
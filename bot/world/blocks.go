@@ -0,0 +1,141 @@
+package world
+
+import (
+	"strings"
+
+	"github.com/Tnze/go-mc/data"
+)
+
+// Box is an axis-aligned box, the unit is block.
+type Box = data.BlockBox
+
+// BlockInfo is what the physics and the pathfinder need to know about a block.
+type BlockInfo struct {
+	Name string // empty if unknown
+
+	// Boxes are the collision boxes in a unit cube.
+	Boxes []Box
+
+	Climbable bool // ladders and vines
+	Water     bool // water, waterlogged blocks or water plants
+	Lava      bool
+	Dangerous bool // hurts the player who touches it
+	// Replaceable is true if a placed block replaces it, like air, water or grass.
+	Replaceable bool
+
+	Slipperiness float64 // 0.6 for the most blocks, higher for ice
+	SpeedFactor  float64 // 1 for the most blocks, lower for soul sand
+	JumpFactor   float64 // 1 for the most blocks, lower for honey block
+}
+
+// Passable reports whether the player can stand in the middle of the block,
+// which means its collision boxes don't touch the player,
+// like air, an opened door or a ladder.
+func (b BlockInfo) Passable() bool { return b.PassableToward(0, 0) }
+
+// PassableToward reports whether the player in the middle of the block can move
+// to the side in the direction (dx, dz), each of them is -1, 0 or 1.
+// A closed door is passable along it but not toward it.
+func (b BlockInfo) PassableToward(dx, dz int) bool {
+	// the range swept by the player, who is 0.6 blocks wide
+	span := func(d int) (min, max float64) {
+		switch {
+		case d > 0:
+			return 0.2, 1
+		case d < 0:
+			return 0, 0.8
+		}
+		return 0.2, 0.8
+	}
+	minX, maxX := span(dx)
+	minZ, maxZ := span(dz)
+	for _, box := range b.Boxes {
+		if box.MinX < maxX && box.MaxX > minX && box.MinZ < maxZ && box.MaxZ > minZ {
+			return false
+		}
+	}
+	return true
+}
+
+// BlockInfo return the BlockInfo of the block by the block state table of the world.
+// The unknown blocks are full cubes.
+func (w *World) BlockInfo(b Block) BlockInfo {
+	info := BlockInfo{
+		Boxes:        fullCube,
+		Slipperiness: 0.6,
+		SpeedFactor:  1,
+		JumpFactor:   1,
+	}
+	if b.ID == 0 {
+		info.Name, info.Boxes, info.Replaceable = "minecraft:air", nil, true
+		return info
+	}
+	if w.BlockStates == nil || b.ID >= uint(len(w.BlockStates.States)) {
+		return info
+	}
+	state := &w.BlockStates.States[b.ID]
+	info.Name, info.Boxes = state.Name, state.Collision
+
+	name := strings.TrimPrefix(info.Name, "minecraft:")
+	info.Climbable = climbableBlocks[name]
+	info.Water = waterBlocks[name] || state.Property("waterlogged") == "true"
+	info.Lava = name == "lava"
+	info.Dangerous = dangerousBlocks[name]
+	info.Replaceable = replaceableBlocks[name]
+	if s, ok := blockSlipperiness[name]; ok {
+		info.Slipperiness = s
+	}
+	if f, ok := blockSpeedFactors[name]; ok {
+		info.SpeedFactor = f
+	}
+	if f, ok := blockJumpFactors[name]; ok {
+		info.JumpFactor = f
+	}
+	return info
+}
+
+// BlockInfoAt return the BlockInfo of the block in the position (x, y, z).
+func (w *World) BlockInfoAt(x, y, z int) BlockInfo {
+	return w.BlockInfo(w.GetBlock(x, y, z))
+}
+
+var fullCube = []Box{{MaxX: 1, MaxY: 1, MaxZ: 1}}
+
+// Blocks affecting the movement
+var (
+	climbableBlocks = map[string]bool{
+		"ladder": true, "vine": true, "scaffolding": true,
+		"weeping_vines": true, "weeping_vines_plant": true,
+		"twisting_vines": true, "twisting_vines_plant": true,
+	}
+	waterBlocks = map[string]bool{
+		"water": true, "bubble_column": true,
+		"seagrass": true, "tall_seagrass": true, "kelp": true, "kelp_plant": true,
+	}
+	dangerousBlocks = map[string]bool{
+		"lava": true, "fire": true, "soul_fire": true, "magma_block": true,
+		"cactus": true, "sweet_berry_bush": true, "wither_rose": true,
+		"campfire": true, "soul_campfire": true, "cobweb": true,
+	}
+	replaceableBlocks = map[string]bool{
+		"air": true, "cave_air": true, "void_air": true, "structure_void": true,
+		"water": true, "lava": true, "bubble_column": true, "fire": true, "soul_fire": true,
+		"grass": true, "tall_grass": true, "fern": true, "large_fern": true, "dead_bush": true,
+		"seagrass": true, "tall_seagrass": true, "vine": true,
+		"crimson_roots": true, "warped_roots": true, "nether_sprouts": true,
+	}
+	blockSlipperiness = map[string]float64{
+		"ice":         0.98,
+		"packed_ice":  0.98,
+		"frosted_ice": 0.98,
+		"blue_ice":    0.989,
+		"slime_block": 0.8,
+	}
+	blockSpeedFactors = map[string]float64{
+		"soul_sand":   0.4,
+		"honey_block": 0.4,
+	}
+	blockJumpFactors = map[string]float64{
+		"honey_block": 0.5,
+	}
+)
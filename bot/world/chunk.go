@@ -5,12 +5,24 @@ import (
 	"fmt"
 
 	// "io"
-	"github.com/Tnze/go-mc/data"
 	pk "github.com/Tnze/go-mc/net/packet"
 )
 
-//DecodeChunkColumn decode the chunk data structure
+// ChunkFormat describes how the chunk columns are encoded in Chunk Data packet,
+// which changes between protocol versions.
+type ChunkFormat struct {
+	// Compact is true since 1.16.
+	// The block states in data array don't span across two longs.
+	Compact bool
+}
+
+// DecodeChunkColumn decode the chunk data structure of 1.14 and 1.15
 func DecodeChunkColumn(mask int32, data []byte) (*Chunk, error) {
+	return ChunkFormat{}.Decode(mask, data)
+}
+
+// Decode the chunk data structure in this format
+func (f ChunkFormat) Decode(mask int32, data []byte) (*Chunk, error) {
 	var c Chunk
 	r := bytes.NewReader(data)
 	for sectionY := 0; sectionY < 16; sectionY++ {
@@ -52,16 +64,39 @@ func DecodeChunkColumn(mask int32, data []byte) (*Chunk, error) {
 			return nil, fmt.Errorf("read DataArrayLength fail: %v", err)
 		}
 
-		DataArray := make([]int64, DataArrayLength)
+		bits := perBits(byte(BitsPerBlock))
+		want := int(bits) * sectionVolume / 64
+		if f.Compact {
+			want = dataLength(bits)
+		}
+		if bits > 32 || int(DataArrayLength) != want {
+			return nil, fmt.Errorf("wrong DataArrayLength %d for %d bits per block", DataArrayLength, bits)
+		}
+		DataArray := make([]uint64, DataArrayLength)
 		for i := 0; i < int(DataArrayLength); i++ {
-			if err := (*pk.Long)(&DataArray[i]).Decode(r); err != nil {
+			var l pk.Long
+			if err := l.Decode(r); err != nil {
 				return nil, fmt.Errorf("read DataArray fail: %v", err)
 			}
+			DataArray[i] = uint64(l)
 		}
 		//用数据填充区块
-		fillSection(&c.Sections[sectionY], perBits(byte(BitsPerBlock)), DataArray, palette)
+		s := &c.Sections[sectionY]
+		s.palette, s.bits, s.data = palette, bits, DataArray
+		if !f.Compact {
+			s.data = unspan(DataArray, bits)
+		}
 	}
 
+	// before 1.15, the biomes of a full chunk are at the end
+	if r.Len() >= 256*4 {
+		c.Biomes = make([]int32, 256)
+		for i := range c.Biomes {
+			if err := (*pk.Int)(&c.Biomes[i]).Decode(r); err != nil {
+				return nil, fmt.Errorf("read biomes fail: %v", err)
+			}
+		}
+	}
 	return &c, nil
 }
 
@@ -72,26 +107,24 @@ func perBits(BitsPerBlock byte) uint {
 	case BitsPerBlock < 9:
 		return uint(BitsPerBlock)
 	default:
-		return uint(data.BitsPerBlock) // DefaultBitsPerBlock
+		// Global palette, the server sends the bits needed by
+		// the block state table of its version (eg. 14 for 1.15).
+		return uint(BitsPerBlock)
 	}
 }
 
-func fillSection(s *Section, bpb uint, DataArray []int64, palette []uint) {
-	mask := uint(1<<bpb - 1)
-	for n := 0; n < 16*16*16; n++ {
-		offset := uint(n * int(bpb))
-		data := uint(DataArray[offset/64])
-		data >>= offset % 64
+// unspan repacks the blocks spanning across two longs, like the data array before 1.16,
+// so each long holds whole blocks.
+func unspan(DataArray []uint64, bpb uint) []uint64 {
+	s := Section{bits: bpb, data: make([]uint64, dataLength(bpb))}
+	mask := uint64(1<<bpb - 1)
+	for n := uint(0); n < sectionVolume; n++ {
+		offset := n * bpb
+		data := DataArray[offset/64] >> (offset % 64)
 		if offset%64 > 64-bpb {
-			l := 64 - offset%64
-			data |= uint(DataArray[offset/64+1] << l)
-		}
-		data &= mask
-
-		if bpb < 9 {
-			s.Blocks[n%16][n/(16*16)][n%(16*16)/16].ID = palette[data]
-		} else {
-			s.Blocks[n%16][n/(16*16)][n%(16*16)/16].ID = data
+			data |= DataArray[offset/64+1] << (64 - offset%64)
 		}
+		s.set(n, uint(data&mask))
 	}
+	return s.data
 }
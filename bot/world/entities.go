@@ -0,0 +1,78 @@
+package world
+
+import "github.com/Tnze/go-mc/bot/world/entity"
+
+// UpdateEntity calls update with the entity if it exists, and saves the changes.
+// It reports whether the entity exists.
+// The update is called with the World locked, so don't call the methods of World in it.
+func (w *World) UpdateEntity(id int32, update func(e *entity.Entity)) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	e, ok := w.Entities[id]
+	if ok {
+		update(&e)
+		w.Entities[id] = e
+	}
+	return ok
+}
+
+// EntitiesWithin return the entities within r blocks of the position,
+// for which filter returns true. A nil filter accepts all entities.
+func (w *World) EntitiesWithin(x, y, z, r float64, filter func(e entity.Entity) bool) []entity.Entity {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var list []entity.Entity
+	for _, e := range w.Entities {
+		if distance2(e, x, y, z) <= r*r && (filter == nil || filter(e)) {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+
+// NearestEntity return the nearest entity within r blocks of the position,
+// for which filter returns true. A nil filter accepts all entities.
+func (w *World) NearestEntity(x, y, z, r float64, filter func(e entity.Entity) bool) (nearest entity.Entity, ok bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	min := r * r
+	for _, e := range w.Entities {
+		if d := distance2(e, x, y, z); d <= min && (filter == nil || filter(e)) {
+			nearest, ok, min = e, true, d
+		}
+	}
+	return
+}
+
+// OfType return a filter of EntitiesWithin and NearestEntity accepting the entities of the type.
+func OfType(typ int) func(e entity.Entity) bool {
+	return func(e entity.Entity) bool { return e.Type == typ }
+}
+
+func distance2(e entity.Entity, x, y, z float64) float64 {
+	dx, dy, dz := e.X-x, e.Y-y, e.Z-z
+	return dx*dx + dy*dy + dz*dz
+}
+
+// EntityTypeID return the type ID of the entity by its name in the server's version,
+// such as "minecraft:zombie". It returns -1 if the name is unknown.
+func (w *World) EntityTypeID(name string) int {
+	if id, ok := w.EntityTypes.ID(name); ok {
+		return id
+	}
+	return -1
+}
+
+// EntityName return the type name of the entity in the server's version,
+// such as "minecraft:zombie". It returns empty string if the type is unknown.
+func (w *World) EntityName(e entity.Entity) string {
+	return w.EntityTypes.Name(e.Type)
+}
+
+// AngleToDegrees convert the angle in the packets, in steps of 1/256 of a full turn, to degrees.
+func AngleToDegrees(a int8) float32 {
+	return float32(a) * 360 / 256
+}
@@ -1,9 +1,13 @@
 package entity
 
 import (
+	"bytes"
+	"errors"
+
 	"github.com/Tnze/go-mc/data"
 	"github.com/Tnze/go-mc/nbt"
 	pk "github.com/Tnze/go-mc/net/packet"
+	"github.com/Tnze/go-mc/save"
 )
 
 //Entity is the entity of minecraft
@@ -11,8 +15,32 @@ type Entity struct {
 	EntityID int //实体ID
 	Type     int
 	X, Y, Z  float64
+
+	UUID             pk.UUID
+	Yaw, Pitch       float32 // in degrees
+	HeadYaw          float32
+	VelX, VelY, VelZ float64 // blocks per tick
+	OnGround         bool
+
+	// Data is the data of the objects, such as the block state of a falling block.
+	Data int
+	// Equipment is the items held and worn by the entity, indexed by the EquipmentSlot.
+	Equipment [6]Slot
+	// Metadata is replaced instead of modified when updated,
+	// so it's safe to read the Metadata of the copies from World.GetEntity.
+	Metadata Metadata
 }
 
+// The EquipmentSlot of Entity.Equipment
+const (
+	MainHand = iota
+	OffHand
+	Boots
+	Leggings
+	Chestplate
+	Helmet
+)
+
 // The Slot data structure is how Minecraft represents an item and its associated data in the Minecraft Protocol
 type Slot struct {
 	Present bool
@@ -33,17 +61,65 @@ func (s *Slot) Decode(r pk.DecodeReader) error {
 		if err := (*pk.Byte)(&s.Count).Decode(r); err != nil {
 			return err
 		}
-		if err := nbt.NewDecoder(r).Decode(&s.NBT); err != nil {
+		// TAG_End means no NBT
+		if tag, err := r.ReadByte(); err != nil {
+			return err
+		} else if tag == 0 {
+			s.NBT = nil
+			return nil
+		}
+		br, ok := r.(nbt.DecoderReader)
+		if !ok {
+			return errors.New("entity: reader cannot unread byte")
+		}
+		if err := br.UnreadByte(); err != nil {
+			return err
+		}
+		if err := nbt.NewDecoder(br).Decode(&s.NBT); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+//Encode implement packet.FieldEncoder interface
+func (s Slot) Encode() []byte {
+	if !s.Present {
+		return pk.Boolean(false).Encode()
+	}
+	var buf bytes.Buffer
+	buf.Write(pk.Boolean(true).Encode())
+	buf.Write(pk.VarInt(s.ItemID).Encode())
+	buf.Write(pk.Byte(s.Count).Encode())
+	if s.NBT == nil {
+		buf.WriteByte(nbt.TagEnd)
+	} else if err := nbt.NewEncoder(&buf).Encode(s.NBT); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+// ItemTag return the typed NBT of the item.
+func (s Slot) ItemTag() save.ItemTag {
+	tag, _ := s.NBT.(map[string]interface{})
+	return save.ParseItemTag(tag)
+}
+
+// SetItemTag replace the NBT of the item.
+func (s *Slot) SetItemTag(t save.ItemTag) {
+	if m := t.Map(); m != nil { // keep the NBT an untyped nil if empty
+		s.NBT = m
+	} else {
+		s.NBT = nil
+	}
+}
+
 func (s Slot) String() string {
 	return data.ItemNameByID[s.ItemID]
 }
 
+// String return the type name of the entity in 1.15.x.
+// Use World.EntityName for the name in the server's version.
 func (e Entity) String() string {
 	return data.EntityNameByID[e.Type]
 }
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	pk "github.com/Tnze/go-mc/net/packet"
+	"github.com/Tnze/go-mc/save"
+)
+
+func TestSlot_roundTrip(t *testing.T) {
+	var pickaxe Slot
+	pickaxe.Present, pickaxe.ItemID, pickaxe.Count = true, 543, 1
+	pickaxe.SetItemTag(save.ItemTag{
+		Damage:       10,
+		Enchantments: []save.Enchantment{{ID: "minecraft:efficiency", Level: 4}},
+	})
+
+	for _, want := range []Slot{{}, {Present: true, ItemID: 1, Count: 64}, pickaxe} {
+		var got Slot
+		if err := got.Decode(bytes.NewReader(want.Encode())); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip fail: get %+v, want %+v", got, want)
+		}
+	}
+
+	p := pk.Marshal(0x28, pk.Short(36), pickaxe)
+	var (
+		slot pk.Short
+		got  Slot
+	)
+	if err := p.Scan(&slot, &got); err != nil {
+		t.Fatal(err)
+	}
+	if lvl := got.ItemTag().EnchantmentLevel("minecraft:efficiency"); lvl != 4 {
+		t.Errorf("wrong efficiency level: %d", lvl)
+	}
+}
@@ -0,0 +1,322 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/Tnze/go-mc/chat"
+	"github.com/Tnze/go-mc/nbt"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// Metadata is the metadata of an entity, by the index.
+//
+// The types of the values are:
+//
+//	Byte: int8              VarInt: int32           Float: float32
+//	String: string          Chat: chat.Message      OptChat: *chat.Message
+//	Slot: Slot              Boolean: bool           Rotation: Rotation
+//	Position: pk.Position   OptPosition: *pk.Position
+//	Direction: Direction    OptUUID: *pk.UUID       OptBlockID: OptBlockID
+//	NBT: interface{}        Particle: Particle      VillagerData: VillagerData
+//	OptVarInt: *int32       Pose: Pose
+//
+// The meanings of the indexes depend on the entity type, see the Meta* constants.
+type Metadata map[byte]interface{}
+
+// Rotation is the rotation of the armor stand parts, in degrees.
+type Rotation struct{ X, Y, Z float32 }
+
+// Direction is down, up, north, south, west or east.
+type Direction int32
+
+// OptBlockID is a block state ID, 0 for absent.
+type OptBlockID int32
+
+// VillagerData is the type, the profession and the level of a villager.
+type VillagerData struct{ Type, Profession, Level int32 }
+
+// Particle is a particle type with its data.
+// Data is a block state ID (int32) for block and falling dust,
+// [4]float32 of red, green, blue and scale for dust, a Slot for item particles,
+// or nil for the others.
+type Particle struct {
+	ID   int32
+	Data interface{}
+}
+
+// Pose is the pose of an entity
+type Pose int32
+
+// All kinds of Pose
+const (
+	Standing Pose = iota
+	FallFlying
+	Sleeping
+	Swimming
+	SpinAttack
+	Crouching
+	Dying
+)
+
+// The metadata indexes of all entities
+const (
+	MetaFlags             = 0 // Byte, see the Flag* constants
+	MetaAir               = 1 // VarInt
+	MetaCustomName        = 2 // OptChat
+	MetaCustomNameVisible = 3 // Boolean
+	MetaSilent            = 4 // Boolean
+	MetaNoGravity         = 5 // Boolean
+	MetaPose              = 6 // Pose
+)
+
+// The metadata indexes of the items entities and item frames
+const (
+	MetaItem              = 7 // Slot, the dropped item or the item in the frame
+	MetaItemFrameRotation = 8 // VarInt
+)
+
+// The metadata indexes of the living entities, including players and mobs
+const (
+	MetaHandStates = 7 // Byte
+	MetaHealth     = 8 // Float
+)
+
+// The bits of the MetaFlags
+const (
+	FlagOnFire    = 0x01
+	FlagCrouching = 0x02
+	FlagSprinting = 0x08
+	FlagSwimming  = 0x10
+	FlagInvisible = 0x20
+	FlagGlowing   = 0x40
+	FlagFlying    = 0x80 // with elytra
+)
+
+// Flags return the MetaFlags
+func (m Metadata) Flags() int8 {
+	f, _ := m[MetaFlags].(int8)
+	return f
+}
+
+// Sneaking reports whether the entity, usually a player, is sneaking.
+func (m Metadata) Sneaking() bool { return m.Flags()&FlagCrouching != 0 }
+
+// Sprinting reports whether the entity is sprinting.
+func (m Metadata) Sprinting() bool { return m.Flags()&FlagSprinting != 0 }
+
+// Invisible reports whether the entity is invisible.
+func (m Metadata) Invisible() bool { return m.Flags()&FlagInvisible != 0 }
+
+// Pose return the pose of the entity, Standing if unknown.
+func (m Metadata) Pose() Pose {
+	p, _ := m[MetaPose].(Pose)
+	return p
+}
+
+// CustomName return the custom name of the entity.
+func (m Metadata) CustomName() (name chat.Message, ok bool) {
+	if n, _ := m[MetaCustomName].(*chat.Message); n != nil {
+		return *n, true
+	}
+	return
+}
+
+// Item return the item of a dropped item or an item frame.
+func (m Metadata) Item() (item Slot, ok bool) {
+	item, ok = m[MetaItem].(Slot)
+	return
+}
+
+// Health return the health of a living entity.
+func (m Metadata) Health() (health float32, ok bool) {
+	health, ok = m[MetaHealth].(float32)
+	return
+}
+
+// Merge return a new Metadata with the entries of both m and n.
+// The values in n are used if both have the same index.
+func (m Metadata) Merge(n Metadata) Metadata {
+	merged := make(Metadata, len(m)+len(n))
+	for k, v := range m {
+		merged[k] = v
+	}
+	for k, v := range n {
+		merged[k] = v
+	}
+	return merged
+}
+
+// The particle IDs with data
+type particleIDs struct{ block, dust, fallingDust, item int32 }
+
+var (
+	particles1_14 = particleIDs{block: 3, dust: 14, fallingDust: 23, item: 32}
+	particles1_16 = particleIDs{block: 3, dust: 14, fallingDust: 23, item: 34}
+)
+
+// ReadMetadata read the entity metadata in the protocol version, until the end mark 0xFF.
+func ReadMetadata(r pk.DecodeReader, protocol int) (Metadata, error) {
+	particles := particles1_14
+	if protocol >= 735 { // 1.16
+		particles = particles1_16
+	}
+
+	m := make(Metadata)
+	for {
+		var index pk.UnsignedByte
+		if err := index.Decode(r); err != nil {
+			return nil, err
+		}
+		if index == 0xFF {
+			return m, nil
+		}
+		var typ pk.VarInt
+		if err := typ.Decode(r); err != nil {
+			return nil, err
+		}
+		v, err := readMetadataValue(r, int(typ), particles)
+		if err != nil {
+			return nil, fmt.Errorf("entity: read metadata %d of type %d fail: %v", index, typ, err)
+		}
+		m[byte(index)] = v
+	}
+}
+
+func readMetadataValue(r pk.DecodeReader, typ int, particles particleIDs) (interface{}, error) {
+	var err error
+	decode := func(fields ...pk.FieldDecoder) {
+		for _, f := range fields {
+			if err == nil {
+				err = f.Decode(r)
+			}
+		}
+	}
+	// the optional values are prefixed with a Boolean
+	present := func() bool {
+		var ok pk.Boolean
+		decode(&ok)
+		return err == nil && bool(ok)
+	}
+
+	switch typ {
+	case 0:
+		var v pk.Byte
+		decode(&v)
+		return int8(v), err
+	case 1:
+		var v pk.VarInt
+		decode(&v)
+		return int32(v), err
+	case 2:
+		var v pk.Float
+		decode(&v)
+		return float32(v), err
+	case 3:
+		var v pk.String
+		decode(&v)
+		return string(v), err
+	case 4:
+		var v chat.Message
+		decode(&v)
+		return v, err
+	case 5:
+		var v *chat.Message
+		if present() {
+			v = new(chat.Message)
+			decode(v)
+		}
+		return v, err
+	case 6:
+		var v Slot
+		decode(&v)
+		return v, err
+	case 7:
+		var v pk.Boolean
+		decode(&v)
+		return bool(v), err
+	case 8:
+		var x, y, z pk.Float
+		decode(&x, &y, &z)
+		return Rotation{float32(x), float32(y), float32(z)}, err
+	case 9:
+		var v pk.Position
+		decode(&v)
+		return v, err
+	case 10:
+		var v *pk.Position
+		if present() {
+			v = new(pk.Position)
+			decode(v)
+		}
+		return v, err
+	case 11:
+		var v pk.VarInt
+		decode(&v)
+		return Direction(v), err
+	case 12:
+		var v *pk.UUID
+		if present() {
+			v = new(pk.UUID)
+			decode(v)
+		}
+		return v, err
+	case 13:
+		var v pk.VarInt
+		decode(&v)
+		return OptBlockID(v), err
+	case 14:
+		var v interface{}
+		if err := nbt.NewDecoder(r).Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case 15:
+		return readParticle(r, particles)
+	case 16:
+		var t, p, l pk.VarInt
+		decode(&t, &p, &l)
+		return VillagerData{int32(t), int32(p), int32(l)}, err
+	case 17:
+		var v pk.VarInt
+		decode(&v)
+		if err != nil || v == 0 {
+			return (*int32)(nil), err
+		}
+		i := int32(v) - 1
+		return &i, nil
+	case 18:
+		var v pk.VarInt
+		decode(&v)
+		return Pose(v), err
+	}
+	return nil, fmt.Errorf("unknown type")
+}
+
+func readParticle(r pk.DecodeReader, particles particleIDs) (p Particle, err error) {
+	var id pk.VarInt
+	if err = id.Decode(r); err != nil {
+		return
+	}
+	p.ID = int32(id)
+	switch p.ID {
+	case particles.block, particles.fallingDust:
+		var state pk.VarInt
+		err = state.Decode(r)
+		p.Data = int32(state)
+	case particles.dust:
+		var rgbs [4]float32
+		for i := range rgbs {
+			var f pk.Float
+			if err = f.Decode(r); err != nil {
+				return
+			}
+			rgbs[i] = float32(f)
+		}
+		p.Data = rgbs
+	case particles.item:
+		var item Slot
+		err = item.Decode(r)
+		p.Data = item
+	}
+	return
+}
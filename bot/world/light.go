@@ -0,0 +1,129 @@
+package world
+
+// maxPendingLight is the max count of the chunk columns whose light is kept before loaded,
+// more than the chunks in the max view distance.
+const maxPendingLight = 65 * 65
+
+// columnLight is the light of the sections in a chunk column.
+type columnLight struct {
+	sky, block [16][]byte
+}
+
+// apply the light to the sections of c which have no light.
+func (l *columnLight) apply(c *Chunk) {
+	for i := range c.Sections {
+		s := &c.Sections[i]
+		if s.SkyLight == nil {
+			s.SkyLight = l.sky[i]
+		}
+		if s.BlockLight == nil {
+			s.BlockLight = l.block[i]
+		}
+	}
+}
+
+// UpdateLight set the light of the sections in the chunk column at (x, z),
+// as the Update Light packet. The sky and block map the section Y to the light arrays,
+// the sections out of the world (-1 and 16) are ignored.
+// If the chunk isn't loaded yet, the light is kept until LoadChunk.
+// At most maxPendingLight columns are kept, the farthest from (x, z) are dropped.
+func (w *World) UpdateLight(x, z int, sky, block map[int][]byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var l columnLight
+	for y, light := range sky {
+		if y >= 0 && y < 16 {
+			l.sky[y] = light
+		}
+	}
+	for y, light := range block {
+		if y >= 0 && y < 16 {
+			l.block[y] = light
+		}
+	}
+
+	loc := ChunkLoc{X: x, Z: z}
+	if c := w.Chunks[loc]; c != nil {
+		for i := range c.Sections {
+			if l.sky[i] != nil {
+				c.Sections[i].SkyLight = l.sky[i]
+			}
+			if l.block[i] != nil {
+				c.Sections[i].BlockLight = l.block[i]
+			}
+		}
+		return
+	}
+	if w.pendingLight == nil {
+		w.pendingLight = make(map[ChunkLoc]*columnLight)
+	}
+	if p := w.pendingLight[loc]; p != nil {
+		for i := range p.sky {
+			if l.sky[i] == nil {
+				l.sky[i] = p.sky[i]
+			}
+			if l.block[i] == nil {
+				l.block[i] = p.block[i]
+			}
+		}
+	} else if len(w.pendingLight) >= maxPendingLight {
+		// 区块的光照到了，区块本身却一直没有加载，丢掉最远的
+		far, dist := loc, -1
+		for p := range w.pendingLight {
+			if d := chunkDistance(p, loc); d > dist {
+				far, dist = p, d
+			}
+		}
+		delete(w.pendingLight, far)
+	}
+	w.pendingLight[loc] = &l
+}
+
+// chunkDistance return the distance between the chunks, in the max of x and z.
+func chunkDistance(a, b ChunkLoc) int {
+	dx, dz := a.X-b.X, a.Z-b.Z
+	if dx < 0 {
+		dx = -dx
+	}
+	if dz < 0 {
+		dz = -dz
+	}
+	if dx > dz {
+		return dx
+	}
+	return dz
+}
+
+// GetLight return the sky light and the block light in the position, from 0 to 15.
+// ok is false if the light of the position isn't received.
+// Above the world the sky light is always 15.
+func (w *World) GetLight(x, y, z int) (sky, block int, ok bool) {
+	if y >= 256 {
+		return 15, 0, true
+	}
+	if y < 0 {
+		return 0, 0, false
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	c := w.Chunks[ChunkLoc{x >> 4, z >> 4}]
+	if c == nil {
+		return 0, 0, false
+	}
+	s := &c.Sections[y>>4]
+	if s.SkyLight == nil && s.BlockLight == nil {
+		return 0, 0, false
+	}
+	i := (y&15)<<8 | (z&15)<<4 | (x & 15)
+	return nibble(s.SkyLight, i), nibble(s.BlockLight, i), true
+}
+
+// nibble return the ith 4 bits of the light array, 0 if out of range.
+func nibble(light []byte, i int) int {
+	if i>>1 >= len(light) {
+		return 0
+	}
+	return int(light[i>>1]>>(uint(i&1)*4)) & 15
+}
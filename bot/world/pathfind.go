@@ -0,0 +1,372 @@
+package world
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+)
+
+// ErrNoPath is returned by FindPath if the goal is unreachable in the loaded world.
+var ErrNoPath = errors.New("world: no path found")
+
+// Default limits of Pathfinder
+const (
+	DefaultMaxNodes = 10000
+	DefaultMaxFall  = 3
+)
+
+// A Goal is where the pathfinder goes.
+// The positions are the block positions of the player's feet.
+type Goal interface {
+	Reached(x, y, z int) bool
+	// Heuristic estimates the cost from (x, y, z) to the goal.
+	// It should not overestimate, or the path found may not be the shortest.
+	Heuristic(x, y, z int) float64
+}
+
+// GoalBlock is reached when the player's feet is in the block.
+type GoalBlock struct{ X, Y, Z int }
+
+// Reached implements Goal
+func (g GoalBlock) Reached(x, y, z int) bool { return x == g.X && y == g.Y && z == g.Z }
+
+// Heuristic implements Goal
+func (g GoalBlock) Heuristic(x, y, z int) float64 { return distance(x-g.X, y-g.Y, z-g.Z) }
+
+// GoalNear is reached when the player's feet is within Range blocks of the position.
+type GoalNear struct{ X, Y, Z, Range int }
+
+// Reached implements Goal
+func (g GoalNear) Reached(x, y, z int) bool {
+	dx, dy, dz := x-g.X, y-g.Y, z-g.Z
+	return dx*dx+dy*dy+dz*dz <= g.Range*g.Range
+}
+
+// Heuristic implements Goal
+func (g GoalNear) Heuristic(x, y, z int) float64 {
+	return math.Max(0, distance(x-g.X, y-g.Y, z-g.Z)-float64(g.Range))
+}
+
+// GoalY is reached when the player's feet is at the Y level.
+type GoalY struct{ Y int }
+
+// Reached implements Goal
+func (g GoalY) Reached(_, y, _ int) bool { return y == g.Y }
+
+// Heuristic implements Goal
+func (g GoalY) Heuristic(_, y, _ int) float64 { return math.Abs(float64(y - g.Y)) }
+
+// GoalNearEntity return a GoalNear within r blocks of the entity's current position.
+// The goal doesn't follow the entity, so make a new one when the entity moves.
+func (w *World) GoalNearEntity(id int32, r int) (g GoalNear, ok bool) {
+	e, ok := w.GetEntity(id)
+	if !ok {
+		return
+	}
+	return GoalNear{
+		X:     int(math.Floor(e.X)),
+		Y:     int(math.Floor(e.Y)),
+		Z:     int(math.Floor(e.Z)),
+		Range: r,
+	}, true
+}
+
+func distance(dx, dy, dz int) float64 {
+	return math.Sqrt(float64(dx*dx + dy*dy + dz*dz))
+}
+
+// Move is how the player gets into a Step.
+type Move byte
+
+// All kinds of Move
+const (
+	Walk   Move = iota // walk on the same level, maybe diagonally
+	Ascend             // jump up a block
+	Fall               // walk off the edge and fall down
+	Climb              // climb up or down the ladders and vines
+	Swim               // swim in the water
+)
+
+func (m Move) String() string {
+	switch m {
+	case Walk:
+		return "walk"
+	case Ascend:
+		return "ascend"
+	case Fall:
+		return "fall"
+	case Climb:
+		return "climb"
+	case Swim:
+		return "swim"
+	}
+	return "unknown"
+}
+
+// Step is a block position of the player's feet in a Path.
+type Step struct {
+	X, Y, Z int
+	Move    Move
+}
+
+// Path is the steps from the start, excluding the start position itself, to the goal.
+type Path []Step
+
+// Affected reports whether changing the block at (x, y, z) may invalidate the path.
+// It's used to decide when to find a new path.
+func (p Path) Affected(x, y, z int) bool {
+	for i, s := range p {
+		top := s.Y + 2 // the head and the space for jumping
+		if i > 0 {
+			prev := p[i-1]
+			if prev.Y+2 > top {
+				top = prev.Y + 2 // falling down from prev
+			}
+			// the corners of a diagonal move
+			if prev.X != s.X && prev.Z != s.Z && (y == s.Y || y == s.Y+1) &&
+				(x == prev.X && z == s.Z || x == s.X && z == prev.Z) {
+				return true
+			}
+		}
+		if x == s.X && z == s.Z && y >= s.Y-1 && y <= top {
+			return true
+		}
+	}
+	return false
+}
+
+// Pathfinder finds paths in a World by A* search.
+//
+// The player is assumed to be two blocks high.
+// It walks, jumps up a block, falls down at most MaxFall blocks,
+// climbs the ladders and vines, and swims in the water.
+// It never goes through the dangerous blocks like lava, fire and cactus, nor the closed doors,
+// and never goes into the unloaded chunks.
+type Pathfinder struct {
+	World *World
+
+	MaxNodes int // the max count of the positions searched. If zero, DefaultMaxNodes is used
+	MaxFall  int // the max height to fall down. If zero, DefaultMaxFall is used
+}
+
+// The cost of each block moved
+const (
+	costWalk     = 1
+	costDiagonal = math.Sqrt2
+	costAscend   = 2
+	costFall     = 1 // plus 1 per block fallen
+	costClimb    = 1.5
+	costSwim     = 2
+)
+
+type node struct{ x, y, z int }
+
+type pathNode struct {
+	node
+	move   Move
+	parent *pathNode
+	g, f   float64
+	index  int // in the heap, -1 if closed
+}
+
+// FindPath finds a path from the player's feet at (x, y, z) to the goal.
+// It returns an empty path if the goal is reached already,
+// or ErrNoPath if the goal is unreachable before searching MaxNodes positions.
+func (p *Pathfinder) FindPath(x, y, z int, goal Goal) (Path, error) {
+	maxNodes := p.MaxNodes
+	if maxNodes <= 0 {
+		maxNodes = DefaultMaxNodes
+	}
+
+	start := &pathNode{node: node{x, y, z}, f: goal.Heuristic(x, y, z)}
+	nodes := map[node]*pathNode{start.node: start}
+	open := &nodeHeap{start}
+	for searched := 0; open.Len() > 0 && searched < maxNodes; searched++ {
+		cur := heap.Pop(open).(*pathNode)
+		cur.index = -1
+		if goal.Reached(cur.x, cur.y, cur.z) {
+			return cur.path(), nil
+		}
+
+		p.neighbors(cur.node, func(n node, m Move, cost float64) {
+			g := cur.g + cost
+			next, ok := nodes[n]
+			if !ok {
+				next = &pathNode{node: n, index: -1}
+				nodes[n] = next
+			} else if g >= next.g {
+				return
+			}
+			next.move, next.parent, next.g = m, cur, g
+			next.f = g + goal.Heuristic(n.x, n.y, n.z)
+			if next.index < 0 {
+				heap.Push(open, next)
+			} else {
+				heap.Fix(open, next.index)
+			}
+		})
+	}
+	return nil, ErrNoPath
+}
+
+func (n *pathNode) path() Path {
+	var steps int
+	for p := n; p.parent != nil; p = p.parent {
+		steps++
+	}
+	path := make(Path, steps)
+	for p := n; p.parent != nil; p = p.parent {
+		steps--
+		path[steps] = Step{X: p.x, Y: p.y, Z: p.z, Move: p.move}
+	}
+	return path
+}
+
+var directions = [...]struct{ x, z int }{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+}
+
+// neighbors calls f with each position the player can move to from n.
+func (p *Pathfinder) neighbors(n node, f func(n node, m Move, cost float64)) {
+	maxFall := p.MaxFall
+	if maxFall <= 0 {
+		maxFall = DefaultMaxFall
+	}
+	feet := p.World.BlockInfoAt(n.x, n.y, n.z)
+
+	for _, d := range directions {
+		t := node{n.x + d.x, n.y, n.z + d.z}
+		if d.x != 0 && d.z != 0 {
+			// only walk diagonally, without cutting the corners
+			if p.canStand(t) && p.canMove(n, t) && p.isEmpty(node{n.x + d.x, n.y, n.z}) && p.isEmpty(node{n.x, n.y, n.z + d.z}) {
+				f(t, p.horizontalMove(feet, t), costDiagonal)
+			}
+			continue
+		}
+
+		switch {
+		case !p.canMove(n, t):
+			up := node{t.x, t.y + 1, t.z}
+			if p.isSafe(node{n.x, n.y + 2, n.z}) && p.canStand(up) && p.canMove(node{n.x, n.y + 1, n.z}, up) {
+				f(up, Ascend, costAscend)
+			}
+		case p.canStand(t):
+			f(t, p.horizontalMove(feet, t), costWalk)
+		case p.isClear(t):
+			for i := 1; i <= maxFall; i++ {
+				below := node{t.x, t.y - i, t.z}
+				if p.canStand(below) {
+					f(below, Fall, costFall+float64(i))
+					break
+				}
+				if !p.isClear(below) {
+					break
+				}
+			}
+		}
+	}
+
+	if feet.Climbable || feet.Water {
+		m, cost := Climb, float64(costClimb)
+		if feet.Water {
+			m, cost = Swim, costSwim
+		}
+		if up := (node{n.x, n.y + 1, n.z}); p.canStand(up) {
+			f(up, m, cost)
+		}
+		if down := (node{n.x, n.y - 1, n.z}); p.canStand(down) {
+			f(down, m, cost)
+		}
+	}
+}
+
+func (p *Pathfinder) horizontalMove(from BlockInfo, to node) Move {
+	if from.Water || p.World.BlockInfoAt(to.x, to.y, to.z).Water {
+		return Swim
+	}
+	return Walk
+}
+
+// isSafe reports whether the block at n is passable and not dangerous.
+func (p *Pathfinder) isSafe(n node) bool {
+	if !p.World.ChunkLoaded(n.x>>4, n.z>>4) {
+		return false
+	}
+	b := p.World.BlockInfoAt(n.x, n.y, n.z)
+	return b.Passable() && !b.Dangerous
+}
+
+// isClear reports whether the player's body fits at n.
+func (p *Pathfinder) isClear(n node) bool {
+	return p.isSafe(n) && p.isSafe(node{n.x, n.y + 1, n.z})
+}
+
+// canMove reports whether the player's body can move from n to the neighbor t on the same level,
+// without hitting the collision boxes on the way, like a closed door.
+func (p *Pathfinder) canMove(n, t node) bool {
+	dx, dz := t.x-n.x, t.z-n.z
+	for y := 0; y < 2; y++ {
+		from := p.World.BlockInfoAt(n.x, n.y+y, n.z)
+		to := p.World.BlockInfoAt(t.x, t.y+y, t.z)
+		if !from.PassableToward(dx, dz) || !to.PassableToward(-dx, -dz) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty reports whether the player's body fits at n without touching any collision box,
+// which is needed by the corners of a diagonal move.
+func (p *Pathfinder) isEmpty(n node) bool {
+	return p.isClear(n) &&
+		len(p.World.BlockInfoAt(n.x, n.y, n.z).Boxes) == 0 &&
+		len(p.World.BlockInfoAt(n.x, n.y+1, n.z).Boxes) == 0
+}
+
+// canStand reports whether the player can stay at n,
+// which means there is a safe floor, or it's in the water or on the ladders.
+func (p *Pathfinder) canStand(n node) bool {
+	if !p.isClear(n) {
+		return false
+	}
+	feet := p.World.BlockInfoAt(n.x, n.y, n.z)
+	if feet.Climbable || feet.Water {
+		return true
+	}
+	floor := p.World.BlockInfoAt(n.x, n.y-1, n.z)
+	return !floor.Passable() && !floor.Dangerous && floor.height() <= 1
+}
+
+// height return the top of the collision boxes.
+// The fences and walls are higher than 1, the player can't stand on them.
+func (b BlockInfo) height() (h float64) {
+	for _, box := range b.Boxes {
+		h = math.Max(h, box.MaxY)
+	}
+	return
+}
+
+// nodeHeap is the open set of A*, sorted by f.
+type nodeHeap []*pathNode
+
+func (h nodeHeap) Len() int           { return len(h) }
+func (h nodeHeap) Less(i, j int) bool { return h[i].f < h[j].f }
+func (h nodeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *nodeHeap) Push(x interface{}) {
+	n := x.(*pathNode)
+	n.index = len(*h)
+	*h = append(*h, n)
+}
+
+func (h *nodeHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,190 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/Tnze/go-mc/data"
+)
+
+// newFlatWorld return a world with a stone floor at y=63, 3*3 chunks around the origin.
+func newFlatWorld() *World {
+	w := &World{BlockStates: data.Blocks(578)}
+	for x := -1; x <= 1; x++ {
+		for z := -1; z <= 1; z++ {
+			c := new(Chunk)
+			for i := 0; i < 16; i++ {
+				for j := 0; j < 16; j++ {
+					c.Sections[3].SetBlock(i, 15, j, blockByName(w, "minecraft:stone"))
+				}
+			}
+			w.LoadChunk(x, z, c)
+		}
+	}
+	return w
+}
+
+// blockByName return the default state of the block.
+func blockByName(w *World, name string) Block {
+	id, ok := w.BlockStates.DefaultByName[name]
+	if !ok {
+		panic("no block " + name)
+	}
+	return Block{ID: uint(id)}
+}
+
+func TestPathfinder_flat(t *testing.T) {
+	w := newFlatWorld()
+	pf := Pathfinder{World: w}
+	path, err := pf.FindPath(0, 64, 0, GoalBlock{5, 64, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 5 {
+		t.Errorf("path should be 5 diagonal steps: %v", path)
+	}
+
+	path, err = pf.FindPath(0, 64, 0, GoalNear{10, 64, 0, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := path[len(path)-1]; last.X != 7 || len(path) != 7 {
+		t.Errorf("path should stop 3 blocks before the goal: %v", path)
+	}
+
+	if _, err := pf.FindPath(0, 64, 0, GoalBlock{100, 64, 0}); err != ErrNoPath {
+		t.Errorf("path into unloaded chunks: %v", err)
+	}
+}
+
+func TestPathfinder_obstacles(t *testing.T) {
+	w := newFlatWorld()
+	stone := blockByName(w, "minecraft:stone")
+	// a wall at x=2, 3 blocks high, with a gap at z=-5
+	for z := -8; z <= 8; z++ {
+		if z != -5 {
+			for y := 64; y < 67; y++ {
+				w.SetBlock(2, y, z, stone)
+			}
+		}
+	}
+	// lava in the way
+	for z := -3; z <= -1; z++ {
+		w.SetBlock(1, 63, z, blockByName(w, "minecraft:lava"))
+	}
+
+	pf := Pathfinder{World: w}
+	path, err := pf.FindPath(0, 64, 0, GoalBlock{4, 64, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range path {
+		if s.X == 2 && s.Z != -5 {
+			t.Fatalf("path through the wall: %v", path)
+		}
+		if s.X == 1 && s.Z >= -3 && s.Z <= -1 {
+			t.Fatalf("path through the lava: %v", path)
+		}
+	}
+	if !path.Affected(2, 64, -5) || path.Affected(8, 64, 8) {
+		t.Error("wrong affected blocks")
+	}
+
+	// a step is better than the long way
+	w.SetBlock(2, 66, 0, Block{})
+	w.SetBlock(2, 65, 0, Block{})
+	path, err = pf.FindPath(0, 64, 0, GoalBlock{4, 64, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ascended, fallen bool
+	for _, s := range path {
+		ascended = ascended || s.Move == Ascend && s.Y == 65
+		fallen = fallen || s.Move == Fall && s.Y == 64
+	}
+	if !ascended || !fallen {
+		t.Errorf("path should go over the step: %v", path)
+	}
+}
+
+func TestPathfinder_climb(t *testing.T) {
+	w := newFlatWorld()
+	stone := blockByName(w, "minecraft:stone")
+	ladder := blockByName(w, "minecraft:ladder")
+	// a 5 blocks high platform with a ladder
+	for y := 64; y < 69; y++ {
+		for x := 1; x < 4; x++ {
+			for z := 1; z < 4; z++ {
+				w.SetBlock(x, y, z, stone)
+			}
+		}
+		w.SetBlock(0, y, 2, ladder)
+	}
+
+	pf := Pathfinder{World: w}
+	path, err := pf.FindPath(0, 64, 0, GoalY{69})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var climbs int
+	for _, s := range path {
+		if s.Move == Climb {
+			climbs++
+		}
+	}
+	if climbs != 4 || path[len(path)-1].Y != 69 {
+		t.Errorf("path should climb the ladder: %v", path)
+	}
+}
+
+// stateOf return the state of the block with the properties.
+func stateOf(w *World, name string, props map[string]string) Block {
+	for id, s := range w.BlockStates.States {
+		match := s.Name == name
+		for k, v := range props {
+			match = match && s.Property(k) == v
+		}
+		if match {
+			return Block{ID: uint(id)}
+		}
+	}
+	panic("no state of " + name)
+}
+
+func TestPathfinder_door(t *testing.T) {
+	w := newFlatWorld()
+	stone := blockByName(w, "minecraft:stone")
+	// a wall at x=2, 3 blocks high, with an oak door at z=0
+	for z := -8; z <= 8; z++ {
+		for y := 64; y < 67; y++ {
+			w.SetBlock(2, y, z, stone)
+		}
+	}
+	door := func(open string) {
+		for y, half := range []string{"lower", "upper"} {
+			w.SetBlock(2, 64+y, 0, stateOf(w, "minecraft:oak_door", map[string]string{
+				"facing": "east", "half": half, "open": open, "hinge": "left", "powered": "false",
+			}))
+		}
+	}
+
+	pf := Pathfinder{World: w}
+	door("false")
+	path, err := pf.FindPath(0, 64, 0, GoalBlock{4, 64, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range path {
+		if s.X == 2 && s.Z == 0 {
+			t.Fatalf("path through the closed door: %v", path)
+		}
+	}
+
+	door("true")
+	path, err = pf.FindPath(0, 64, 0, GoalBlock{4, 64, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 4 {
+		t.Errorf("path should go through the opened door: %v", path)
+	}
+}
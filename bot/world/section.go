@@ -0,0 +1,143 @@
+package world
+
+import "math/bits"
+
+// sectionVolume is the number of blocks in a section.
+const sectionVolume = 16 * 16 * 16
+
+// Section store a 16*16*16 cube blocks
+//
+// The blocks are kept as the network protocol does, the indexes of a palette
+// packed in an array of longs. A section of a few kinds of blocks takes 2 KiB
+// instead of 32 KiB. The zero value is a section of air.
+// The copies of a Section share the blocks, so don't copy it.
+type Section struct {
+	// palette maps the indexes in data to the block state IDs.
+	// It's nil if data stores the state IDs directly, which is the global palette.
+	palette []uint
+	bits    uint     // bits per block in data
+	data    []uint64 // nil if all blocks are palette[0], or air without palette
+
+	// SkyLight and BlockLight are the light levels of the blocks, 4 bits for each,
+	// in the same order as the network protocol. They are nil if not received.
+	// The arrays may be shared between sections, don't modify them.
+	SkyLight, BlockLight []byte
+}
+
+// sectionIndex return the index of the block in a section, in the order of y, z, x.
+func sectionIndex(x, y, z int) uint {
+	return uint(y<<8 | z<<4 | x)
+}
+
+// GetBlock return the block at (x, y, z) in the section, each of them is 0 to 15.
+func (s *Section) GetBlock(x, y, z int) Block {
+	if s.data == nil {
+		if len(s.palette) > 0 {
+			return Block{ID: s.palette[0]}
+		}
+		return Block{}
+	}
+	v := s.get(sectionIndex(x, y, z))
+	if s.palette != nil {
+		if v >= uint(len(s.palette)) {
+			return Block{} // broken data from the server
+		}
+		v = s.palette[v]
+	}
+	return Block{ID: v}
+}
+
+// SetBlock set the block at (x, y, z) in the section, each of them is 0 to 15.
+// The palette grows if the block isn't in it, and it's replaced by
+// the global palette when more than 8 bits are needed.
+func (s *Section) SetBlock(x, y, z int, b Block) {
+	n := sectionIndex(x, y, z)
+	if s.data == nil {
+		old := s.GetBlock(0, 0, 0).ID
+		if old == b.ID {
+			return
+		}
+		s.palette, s.bits = []uint{old}, 4
+		s.data = make([]uint64, dataLength(4))
+	}
+	if s.palette == nil {
+		if l := uint(bits.Len(b.ID)); l > s.bits {
+			s.resize(l)
+		}
+		s.set(n, b.ID)
+		return
+	}
+
+	i := -1
+	for j, id := range s.palette {
+		if id == b.ID {
+			i = j
+			break
+		}
+	}
+	if i == -1 {
+		i = len(s.palette)
+		s.palette = append(s.palette, b.ID)
+		if uint(i) >= 1<<s.bits {
+			s.resize(s.bits + 1)
+		}
+		if s.palette == nil {
+			s.set(n, b.ID)
+			return
+		}
+	}
+	s.set(n, uint(i))
+}
+
+// get the nth value in data.
+func (s *Section) get(n uint) uint {
+	perLong := 64 / s.bits
+	return uint(s.data[n/perLong]>>(n%perLong*s.bits)) & (1<<s.bits - 1)
+}
+
+// set the nth value in data.
+func (s *Section) set(n, v uint) {
+	perLong := 64 / s.bits
+	shift := n % perLong * s.bits
+	mask := uint64(1<<s.bits-1) << shift
+	s.data[n/perLong] = s.data[n/perLong]&^mask | uint64(v)<<shift
+}
+
+// resize repacks the blocks with more bits per block.
+// The global palette is used for more than 8 bits,
+// with the bits needed by the largest state ID but at least 9.
+func (s *Section) resize(newBits uint) {
+	old := *s
+	global := newBits > 8
+	if global && s.palette != nil {
+		newBits = 9
+		for _, id := range s.palette {
+			if l := uint(bits.Len(id)); l > newBits {
+				newBits = l
+			}
+		}
+	}
+	s.bits = newBits
+	s.data = make([]uint64, dataLength(newBits))
+	for n := uint(0); n < sectionVolume; n++ {
+		v := old.get(n)
+		if global && old.palette != nil {
+			if v < uint(len(old.palette)) {
+				v = old.palette[v]
+			} else {
+				v = 0
+			}
+		}
+		s.set(n, v)
+	}
+	if global {
+		s.palette = nil
+	}
+}
+
+// dataLength return how many longs are needed by the blocks,
+// which don't span across two longs.
+func dataLength(bits uint) int {
+	perLong := 64 / bits
+	return int((sectionVolume + perLong - 1) / perLong)
+}
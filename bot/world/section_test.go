@@ -0,0 +1,88 @@
+package world
+
+import (
+	"bytes"
+	"testing"
+
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestSection_SetBlock(t *testing.T) {
+	var s Section
+	if b := s.GetBlock(1, 2, 3); b.ID != 0 {
+		t.Fatalf("the zero section isn't air: %v", b.ID)
+	}
+	s.SetBlock(1, 2, 3, Block{ID: 0})
+	if s.data != nil {
+		t.Error("setting air allocates the data")
+	}
+
+	// 300 kinds of blocks need the global palette
+	for i := 0; i < 300; i++ {
+		s.SetBlock(i%16, i/256, i/16%16, Block{ID: uint(i*30 + 1)})
+		if i == 15 && (s.bits != 5 || len(s.palette) != 17) {
+			t.Errorf("wrong palette of 17 blocks: %d bits, %v", s.bits, s.palette)
+		}
+	}
+	if s.palette != nil || s.bits != 14 {
+		t.Errorf("the global palette isn't used: %d bits", s.bits)
+	}
+	for i := 0; i < 300; i++ {
+		if b := s.GetBlock(i%16, i/256, i/16%16); b.ID != uint(i*30+1) {
+			t.Fatalf("block %d: get %d, want %d", i, b.ID, i*30+1)
+		}
+	}
+	if b := s.GetBlock(15, 15, 15); b.ID != 0 {
+		t.Errorf("wrong air block: %d", b.ID)
+	}
+}
+
+func TestChunkFormat_Decode(t *testing.T) {
+	const bpb = 5
+	palette := make([]uint, 20)
+	for i := range palette {
+		palette[i] = uint(i*7 + 1)
+	}
+	// the data array spanning across the longs before 1.16
+	spanning := make([]uint64, bpb*sectionVolume/64)
+	compact := Section{bits: bpb, data: make([]uint64, dataLength(bpb))}
+	for n := uint(0); n < sectionVolume; n++ {
+		v := uint64(n % 20)
+		offset := n * bpb
+		spanning[offset/64] |= v << (offset % 64)
+		if offset%64 > 64-bpb {
+			spanning[offset/64+1] |= v >> (64 - offset%64)
+		}
+		compact.set(n, uint(v))
+	}
+
+	for _, f := range []ChunkFormat{{Compact: false}, {Compact: true}} {
+		data := spanning
+		if f.Compact {
+			data = compact.data
+		}
+		fields := []pk.FieldEncoder{pk.Short(sectionVolume), pk.Byte(bpb), pk.VarInt(len(palette))}
+		for _, id := range palette {
+			fields = append(fields, pk.VarInt(id))
+		}
+		fields = append(fields, pk.VarInt(len(data)))
+		for _, l := range data {
+			fields = append(fields, pk.Long(l))
+		}
+		var buf bytes.Buffer
+		for _, field := range fields {
+			buf.Write(field.Encode())
+		}
+
+		c, err := f.Decode(1<<4, buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for n := 0; n < sectionVolume; n++ {
+			x, y, z := n%16, n/256, n/16%16
+			if b := c.Sections[4].GetBlock(x, y, z); b.ID != palette[n%20] {
+				t.Fatalf("compact %v: block %d: get %d, want %d", f.Compact, n, b.ID, palette[n%20])
+			}
+		}
+	}
+}
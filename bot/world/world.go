@@ -1,24 +1,45 @@
 package world
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/Tnze/go-mc/bot/world/entity"
 	"github.com/Tnze/go-mc/data"
 )
 
 //World record all of the things in the world where player at
+//
+// The methods of World are safe for concurrent use.
+// The maps are updated by the goroutine running HandleGame,
+// so only read them directly in that goroutine, such as in the event handlers.
+// Other goroutines should use the methods instead.
 type World struct {
 	Entities map[int32]entity.Entity
 	Chunks   map[ChunkLoc]*Chunk
+
+	// BlockStates is the block state table of the server's version.
+	// It's nil if the table of that version isn't loaded.
+	// It's set before joining the game and not changed while playing.
+	BlockStates *data.BlockStates
+	// EntityTypes is the entity type registry of the server's version, like BlockStates.
+	EntityTypes *data.Registry
+
+	// the light received before the chunk is loaded
+	pendingLight map[ChunkLoc]*columnLight
+
+	mu sync.RWMutex // guards the maps
 }
 
 //Chunk store a 256*16*16 clolumn blocks
 type Chunk struct {
 	Sections [16]Section
-}
+	// Biomes are the biome IDs of the column, nil if not received.
+	// Since 1.15 there is a biome for each 4*4*4 blocks, 1024 in total,
+	// and before that a biome for each column of blocks, 256 in total.
+	Biomes []int32
 
-//Section store a 16*16*16 cube blocks
-type Section struct {
-	Blocks [16][16][16]Block
+	blockEntities map[blockPos]BlockEntity
 }
 
 //Block is the base of world
@@ -49,8 +70,38 @@ const (
 	East
 )
 
+// Offset return the direction the face is facing, such as (0, 1, 0) for Top.
+func (f Face) Offset() (dx, dy, dz int) {
+	switch f {
+	case Bottom:
+		return 0, -1, 0
+	case Top:
+		return 0, 1, 0
+	case North:
+		return 0, 0, -1
+	case South:
+		return 0, 0, 1
+	case West:
+		return -1, 0, 0
+	case East:
+		return 1, 0, 0
+	}
+	return 0, 0, 0
+}
+
+// Opposite return the face on the other side of the block, such as Bottom for Top.
+func (f Face) Opposite() Face {
+	return f ^ 1
+}
+
 //getBlock return the block in the position (x, y, z)
 func (w *World) GetBlock(x, y, z int) Block {
+	if y < 0 || y >= 256 {
+		return Block{ID: 0}
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
 	c := w.Chunks[ChunkLoc{x >> 4, z >> 4}]
 	if c != nil {
 		cx, cy, cz := x&15, y&15, z&15
@@ -63,17 +114,183 @@ func (w *World) GetBlock(x, y, z int) Block {
 			if n < 0 { n += 16 }
 		*/
 
-		return c.Sections[y/16].Blocks[cx][cy][cz]
+		return c.Sections[y/16].GetBlock(cx, cy, cz)
 	}
 
 	return Block{ID: 0}
 }
 
+// String return the name of the block in 1.15.2, or "Block(ID)" if the ID isn't in 1.15.2.
+// The IDs are different in other versions, use World.BlockName for the server's version.
 func (b Block) String() string {
+	if b.ID >= uint(len(data.BlockNameByID)) {
+		return fmt.Sprintf("Block(%d)", b.ID)
+	}
 	return data.BlockNameByID[b.ID]
 }
 
+// GetBiome return the biome ID in the position, ok is false if the biomes aren't received.
+func (w *World) GetBiome(x, y, z int) (biome int, ok bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	c := w.Chunks[ChunkLoc{x >> 4, z >> 4}]
+	if c == nil {
+		return 0, false
+	}
+	switch len(c.Biomes) {
+	case 1024:
+		if y < 0 {
+			y = 0
+		} else if y > 255 {
+			y = 255
+		}
+		return int(c.Biomes[(y>>2)<<4|((z&15)>>2)<<2|(x&15)>>2]), true
+	case 256:
+		return int(c.Biomes[(z&15)<<4|(x&15)]), true
+	}
+	return 0, false
+}
+
+// BlockName return the name of the block by the block state table of the world.
+// It returns empty string if the name is unknown.
+func (w *World) BlockName(b Block) string {
+	if b.ID == 0 {
+		return "minecraft:air" // in all versions
+	}
+	if w.BlockStates == nil || b.ID >= uint(len(w.BlockStates.NameByID)) {
+		return ""
+	}
+	return w.BlockStates.NameByID[b.ID]
+}
+
+// SetBlock change the block in the position (x, y, z).
+// The block entity in the position is removed if the block is changed to another kind.
+// It does nothing if the chunk isn't loaded.
+func (w *World) SetBlock(x, y, z int, b Block) {
+	if y < 0 || y >= 256 {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if c := w.Chunks[ChunkLoc{x >> 4, z >> 4}]; c != nil {
+		s := &c.Sections[y/16]
+		// the block entity is removed with its block
+		if _, ok := c.blockEntities[blockPos{x, y, z}]; ok {
+			// a chest rotated or a sign waterlogged keeps its block entity,
+			// but the states are compared if the names are unknown
+			old := s.GetBlock(x&15, y&15, z&15)
+			name := w.BlockName(b)
+			if name != w.BlockName(old) || name == "" && old.ID != b.ID {
+				delete(c.blockEntities, blockPos{x, y, z})
+			}
+		}
+		s.SetBlock(x&15, y&15, z&15, b)
+	}
+}
+
 //LoadChunk load chunk at (x, z)
 func (w *World) LoadChunk(x, z int, c *Chunk) {
-	w.Chunks[ChunkLoc{X: x, Z: z}] = c
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.Chunks == nil {
+		w.Chunks = make(map[ChunkLoc]*Chunk)
+	}
+	loc := ChunkLoc{X: x, Z: z}
+	if l := w.pendingLight[loc]; l != nil {
+		l.apply(c)
+		delete(w.pendingLight, loc)
+	}
+	w.Chunks[loc] = c
+}
+
+// UpdateSections replace the sections of the loaded chunk at (x, z) by the sections of c in the mask,
+// like the Chunk Data packet which isn't a full chunk. The light and the biomes are kept.
+// The block entities in the replaced sections are removed.
+func (w *World) UpdateSections(x, z int, mask int32, c *Chunk) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	old := w.Chunks[ChunkLoc{X: x, Z: z}]
+	if old == nil {
+		return
+	}
+	for i := range old.Sections {
+		if mask&(1<<uint(i)) != 0 {
+			s := &old.Sections[i]
+			s.palette, s.bits, s.data = c.Sections[i].palette, c.Sections[i].bits, c.Sections[i].data
+		}
+	}
+	// the block entities in the sections are sent again with them
+	for pos := range old.blockEntities {
+		if mask&(1<<uint(pos.y/16)) != 0 {
+			delete(old.blockEntities, pos)
+		}
+	}
+}
+
+// UnloadChunk remove the chunk at (x, z).
+func (w *World) UnloadChunk(x, z int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.Chunks, ChunkLoc{X: x, Z: z})
+	delete(w.pendingLight, ChunkLoc{X: x, Z: z})
+}
+
+// ChunkLoaded reports whether the chunk at (x, z) is loaded.
+func (w *World) ChunkLoaded(x, z int) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	_, ok := w.Chunks[ChunkLoc{X: x, Z: z}]
+	return ok
+}
+
+// GetEntity return a copy of the entity.
+func (w *World) GetEntity(id int32) (e entity.Entity, ok bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	e, ok = w.Entities[id]
+	return
+}
+
+// EntityList return a snapshot of all entities in the world.
+func (w *World) EntityList() []entity.Entity {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	list := make([]entity.Entity, 0, len(w.Entities))
+	for _, e := range w.Entities {
+		list = append(list, e)
+	}
+	return list
+}
+
+// SetEntity add or update an entity.
+func (w *World) SetEntity(e entity.Entity) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.Entities == nil {
+		w.Entities = make(map[int32]entity.Entity)
+	}
+	w.Entities[int32(e.EntityID)] = e
+}
+
+// RemoveEntities remove the entities by their IDs.
+func (w *World) RemoveEntities(ids ...int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range ids {
+		delete(w.Entities, id)
+	}
+}
+
+// Reset unload all chunks and entities.
+func (w *World) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.Entities = make(map[int32]entity.Entity)
+	w.Chunks = make(map[ChunkLoc]*Chunk)
+	w.pendingLight = nil
 }
@@ -1,5 +1,13 @@
 package world
 
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Tnze/go-mc/bot/world/entity"
+	"github.com/Tnze/go-mc/data"
+)
+
 // import "testing"
 
 // func TestBlockString(t *testing.T) {
@@ -7,3 +15,129 @@ package world
 // 		t.Log(Block{id: i})
 // 	}
 // }
+
+func TestWorld_concurrent(t *testing.T) {
+	var w World
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			w.LoadChunk(i, 0, new(Chunk))
+			w.SetBlock(i*16, 64, 0, Block{ID: 1})
+			w.SetEntity(entity.Entity{EntityID: i})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_ = w.GetBlock(i*16, 64, 0)
+		_ = w.EntityList()
+	}
+	<-done
+
+	if w.GetBlock(16, 64, 0).ID != 1 || w.GetBlock(16, 300, 0).ID != 0 {
+		t.Error("wrong block")
+	}
+	if e, ok := w.GetEntity(42); !ok || e.EntityID != 42 {
+		t.Error("entity not found")
+	}
+	w.RemoveEntities(42)
+	if _, ok := w.GetEntity(42); ok || len(w.EntityList()) != 99 {
+		t.Error("entity not removed")
+	}
+}
+
+// The light of the chunks never loaded shouldn't be kept forever.
+func TestWorld_pendingLight(t *testing.T) {
+	var w World
+	light := map[int][]byte{0: make([]byte, 2048)}
+	w.UpdateLight(1000, 1000, light, nil) // far away
+	for i := 0; i < maxPendingLight; i++ {
+		w.UpdateLight(i%65, i/65, light, nil)
+	}
+	if len(w.pendingLight) != maxPendingLight {
+		t.Errorf("%d columns of light are kept, want %d", len(w.pendingLight), maxPendingLight)
+	}
+	if w.pendingLight[ChunkLoc{X: 1000, Z: 1000}] != nil {
+		t.Error("the farthest light isn't dropped")
+	}
+}
+
+func TestWorld_lightAndBiomes(t *testing.T) {
+	var w World
+	sky := make([]byte, 2048)
+	sky[(5<<8|2<<4|3)>>1] = 0xF0 // (3, 16+5, 2) is 15
+	block := make([]byte, 2048)
+	block[(5<<8|2<<4|2)>>1] = 0x07 // (2, 16+5, 2) is 7
+	// the light is sent before the chunk
+	w.UpdateLight(0, 0, map[int][]byte{-1: make([]byte, 2048), 1: sky}, map[int][]byte{1: block})
+	if _, _, ok := w.GetLight(3, 21, 2); ok {
+		t.Error("got the light of an unloaded chunk")
+	}
+
+	c := &Chunk{Biomes: make([]int32, 1024)}
+	c.Biomes[(64>>2)<<4|(8>>2)<<2|(4>>2)] = 1 // plains at (4, 64, 8)
+	w.LoadChunk(0, 0, c)
+	if s, b, ok := w.GetLight(3, 21, 2); !ok || s != 15 || b != 0 {
+		t.Errorf("wrong light: %d, %d, %v", s, b, ok)
+	}
+	if s, b, ok := w.GetLight(2, 21, 2); !ok || s != 0 || b != 7 {
+		t.Errorf("wrong light: %d, %d, %v", s, b, ok)
+	}
+	if _, _, ok := w.GetLight(2, 40, 2); ok {
+		t.Error("got the light of a section without light")
+	}
+	if biome, ok := w.GetBiome(5, 66, 11); !ok || biome != 1 {
+		t.Errorf("wrong biome: %d", biome)
+	}
+
+	var update Chunk
+	update.Sections[4].SetBlock(0, 0, 0, Block{ID: 1})
+	w.UpdateSections(0, 0, 1<<4, &update)
+	if w.GetBlock(0, 64, 0).ID != 1 || c.Sections[1].SkyLight == nil || c.Biomes == nil {
+		t.Error("wrong sections update")
+	}
+
+	w.UnloadChunk(0, 0)
+	if w.ChunkLoaded(0, 0) {
+		t.Error("the chunk isn't unloaded")
+	}
+	if _, ok := w.GetBiome(5, 66, 11); ok {
+		t.Error("got the biome of an unloaded chunk")
+	}
+}
+
+func TestWorld_EntityTypeID(t *testing.T) {
+	var w World
+	if id := w.EntityTypeID("minecraft:zombie"); id != -1 {
+		t.Errorf("got the ID %d without the registry", id)
+	}
+	w.EntityTypes = data.Entities(578)
+	zombie := w.EntityTypeID("minecraft:zombie")
+	if name := w.EntityName(entity.Entity{Type: zombie}); name != "minecraft:zombie" {
+		t.Errorf("wrong name of zombie: %q", name)
+	}
+
+	// another version with other IDs
+	w.EntityTypes = &data.Registry{
+		NameByID: []string{"minecraft:piglin", "minecraft:zombie"},
+		IDByName: map[string]int{"minecraft:piglin": 0, "minecraft:zombie": 1},
+	}
+	if id := w.EntityTypeID("minecraft:zombie"); id != 1 {
+		t.Errorf("wrong ID of zombie: %d", id)
+	}
+}
+
+func TestWorld_BlockName(t *testing.T) {
+	var w World
+	bad := Block{ID: uint(len(data.BlockNameByID)) + 100} // like a state of 1.16
+	if s := bad.String(); s != fmt.Sprintf("Block(%d)", bad.ID) {
+		t.Errorf("wrong name of unknown block: %q", s)
+	}
+	if name := w.BlockName(bad); name != "" {
+		t.Errorf("got the name %q without the block states", name)
+	}
+	w.BlockStates = data.Blocks(578)
+	stone := Block{ID: uint(w.BlockStates.DefaultByName["minecraft:stone"])}
+	if name := w.BlockName(stone); name != "minecraft:stone" || stone.String() != name {
+		t.Errorf("wrong name of stone: %q", name)
+	}
+}
@@ -65,6 +65,15 @@ func (m Message) Encode() []byte {
 	return pk.String(code).Encode()
 }
 
+//ParseText parse the JSON text, such as the texts stored in NBT,
+//or use it as plain text if it isn't JSON.
+func ParseText(s string) (m Message) {
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		m = Message{Text: s}
+	}
+	return
+}
+
 var fmtCode = map[byte]string{
 	'0': "30",
 	'1': "34",
@@ -107,3 +107,12 @@ func TestMessage_Encode(t *testing.T) {
 		t.Error("encode Message error: get", string(msg), ", want", wantMsg)
 	}
 }
+
+func TestParseText(t *testing.T) {
+	if msg := chat.ParseText(`{"text":"Hello","bold":true}`); msg.Text != "Hello" || !msg.Bold {
+		t.Errorf("wrong JSON text: %+v", msg)
+	}
+	if msg := chat.ParseText("Hello {world"); msg.Text != "Hello {world" {
+		t.Errorf("wrong plain text: %+v", msg)
+	}
+}
@@ -2,54 +2,27 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/Tnze/go-mc/bot"
-	"github.com/Tnze/go-mc/chat"
 	_ "github.com/Tnze/go-mc/data/lang/en-us"
-	"github.com/google/uuid"
 )
 
-type status struct {
-	Description chat.Message
-	Players     struct {
-		Max    int
-		Online int
-		Sample []struct {
-			ID   uuid.UUID
-			Name string
-		}
-	}
-	Version struct {
-		Name     string
-		Protocol int
-	}
-	//favicon ignored
-}
-
 func main() {
 	addr, port := getAddr()
 
 	fmt.Printf("MCPING (%s:%d):\n", addr, port)
 
-	resp, delay, err := bot.PingAndList(addr, port)
+	resp, delay, err := bot.PingAndListStatus(addr, port)
 	if err != nil {
 		fmt.Printf("ping and list server fail: %v", err)
 		os.Exit(1)
 	}
 
-	var s status
-	err = json.Unmarshal(resp, &s)
-	if err != nil {
-		fmt.Print("unmarshal resp fail:", err)
-		os.Exit(1)
-	}
-
-	fmt.Print(s)
+	fmt.Print(resp)
 	fmt.Println("Delay:", delay)
 }
 
@@ -76,15 +49,3 @@ func getAddr() (string, int) {
 
 	return addr[0], port
 }
-
-func (s status) String() string {
-	var sb strings.Builder
-	fmt.Fprintln(&sb, "Server:", s.Version.Name)
-	fmt.Fprintln(&sb, "Protocol:", s.Version.Protocol)
-	fmt.Fprintln(&sb, "Description:", s.Description)
-	fmt.Fprintf(&sb, "Players: %d/%d\n", s.Players.Online, s.Players.Max)
-	for _, v := range s.Players.Sample {
-		fmt.Fprintf(&sb, "- [%s] %v\n", v.Name, v.ID)
-	}
-	return sb.String()
-}
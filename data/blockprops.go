@@ -0,0 +1,374 @@
+package data
+
+import "strings"
+
+// Tool is a kind of tools digging the blocks faster.
+type Tool byte
+
+// All kinds of Tool
+const (
+	ToolNone Tool = iota
+	ToolPickaxe
+	ToolAxe
+	ToolShovel
+	ToolHoe
+	ToolSword
+	ToolShears
+)
+
+func (t Tool) String() string {
+	switch t {
+	case ToolPickaxe:
+		return "pickaxe"
+	case ToolAxe:
+		return "axe"
+	case ToolShovel:
+		return "shovel"
+	case ToolHoe:
+		return "hoe"
+	case ToolSword:
+		return "sword"
+	case ToolShears:
+		return "shears"
+	}
+	return "none"
+}
+
+// HarvestLevel is the material level of the tools.
+type HarvestLevel byte
+
+// The harvest levels of the tool materials.
+// The golden tools are the same level as wooden.
+const (
+	LevelWood HarvestLevel = iota
+	LevelStone
+	LevelIron
+	LevelDiamond
+)
+
+// blockProps are the properties shared by all states of a block.
+type blockProps struct {
+	hardness, resistance float64
+	tool                 Tool
+	requiresTool         bool
+	level                HarvestLevel
+}
+
+func props(hardness, resistance float64, tool Tool) blockProps {
+	return blockProps{hardness: hardness, resistance: resistance, tool: tool}
+}
+
+// requires the tool at least the level for dropping.
+func (p blockProps) requires(level HarvestLevel) blockProps {
+	p.requiresTool, p.level = true, level
+	return p
+}
+
+// Some common blockProps
+var (
+	propsInstant    = props(0, 0, ToolNone)
+	propsUnbreak    = props(-1, 3600000, ToolNone)
+	propsStone      = props(1.5, 6, ToolPickaxe).requires(LevelWood)
+	propsCobble     = props(2, 6, ToolPickaxe).requires(LevelWood)
+	propsSandstone  = props(0.8, 0.8, ToolPickaxe).requires(LevelWood)
+	propsOre        = props(3, 3, ToolPickaxe)
+	propsPlanks     = props(2, 3, ToolAxe)
+	propsLog        = props(2, 2, ToolAxe)
+	propsStoneSlab  = props(2, 6, ToolPickaxe).requires(LevelWood)
+	propsWorkbench  = props(2.5, 2.5, ToolAxe)
+	propsFurnace    = props(3.5, 3.5, ToolPickaxe).requires(LevelWood)
+	propsLiquid     = props(100, 100, ToolNone)
+	propsCoralBlock = props(1.5, 6, ToolPickaxe).requires(LevelWood)
+)
+
+// blockProperties are the hardness and tools of the vanilla blocks in 1.15.2,
+// by the names without "minecraft:".
+// The blocks not listed here are looked up in blockSuffixProperties.
+// Every block of 1.15.2 must be found in these tables, see TestBlockStates_explicit.
+var blockProperties = map[string]blockProps{
+	"air": propsInstant, "cave_air": propsInstant, "void_air": propsInstant,
+	"water": propsLiquid, "lava": propsLiquid, "bubble_column": propsInstant,
+
+	"stone": propsStone, "granite": propsStone, "polished_granite": propsStone,
+	"diorite": propsStone, "polished_diorite": propsStone,
+	"andesite": propsStone, "polished_andesite": propsStone,
+	"cobblestone": propsCobble, "mossy_cobblestone": propsCobble, "bricks": propsCobble,
+	"smooth_stone": propsCobble,
+	"stone_bricks": propsStone, "mossy_stone_bricks": propsStone,
+	"cracked_stone_bricks": propsStone, "chiseled_stone_bricks": propsStone,
+	"sandstone": propsSandstone, "chiseled_sandstone": propsSandstone, "cut_sandstone": propsSandstone,
+	"red_sandstone": propsSandstone, "chiseled_red_sandstone": propsSandstone, "cut_red_sandstone": propsSandstone,
+	"smooth_sandstone": propsCobble, "smooth_red_sandstone": propsCobble,
+	"bedrock":  propsUnbreak,
+	"obsidian": props(50, 1200, ToolPickaxe).requires(LevelDiamond),
+
+	"coal_ore":          propsOre.requires(LevelWood),
+	"iron_ore":          propsOre.requires(LevelStone),
+	"lapis_ore":         propsOre.requires(LevelStone),
+	"gold_ore":          propsOre.requires(LevelIron),
+	"diamond_ore":       propsOre.requires(LevelIron),
+	"emerald_ore":       propsOre.requires(LevelIron),
+	"redstone_ore":      propsOre.requires(LevelIron),
+	"nether_quartz_ore": propsOre.requires(LevelWood),
+
+	"coal_block":     props(5, 6, ToolPickaxe).requires(LevelWood),
+	"iron_block":     props(5, 6, ToolPickaxe).requires(LevelStone),
+	"lapis_block":    props(3, 3, ToolPickaxe).requires(LevelStone),
+	"gold_block":     props(3, 6, ToolPickaxe).requires(LevelIron),
+	"diamond_block":  props(5, 6, ToolPickaxe).requires(LevelIron),
+	"emerald_block":  props(5, 6, ToolPickaxe).requires(LevelIron),
+	"redstone_block": props(5, 6, ToolPickaxe).requires(LevelWood),
+
+	"quartz_block": propsSandstone, "chiseled_quartz_block": propsSandstone, "quartz_pillar": propsSandstone,
+	"smooth_quartz": propsCobble,
+	"purpur_block":  propsStone, "purpur_pillar": propsStone,
+	"end_stone":        props(3, 9, ToolPickaxe).requires(LevelWood),
+	"end_stone_bricks": props(3, 9, ToolPickaxe).requires(LevelWood),
+	"prismarine":       propsStone, "prismarine_bricks": propsStone, "dark_prismarine": propsStone,
+	"nether_bricks": propsCobble, "red_nether_bricks": propsCobble,
+	"netherrack":    props(0.4, 0.4, ToolPickaxe).requires(LevelWood),
+	"magma_block":   props(0.5, 0.5, ToolPickaxe).requires(LevelWood),
+	"terracotta":    props(1.25, 4.2, ToolPickaxe).requires(LevelWood),
+	"bone_block":    props(2, 2, ToolPickaxe).requires(LevelWood),
+	"glowstone":     props(0.3, 0.3, ToolNone),
+	"sea_lantern":   props(0.3, 0.3, ToolNone),
+	"redstone_lamp": props(0.3, 0.3, ToolNone),
+
+	"grass_block": props(0.6, 0.6, ToolShovel), "mycelium": props(0.6, 0.6, ToolShovel),
+	"dirt": props(0.5, 0.5, ToolShovel), "coarse_dirt": props(0.5, 0.5, ToolShovel), "podzol": props(0.5, 0.5, ToolShovel),
+	"farmland": props(0.6, 0.6, ToolShovel), "grass_path": props(0.65, 0.65, ToolShovel),
+	"sand": props(0.5, 0.5, ToolShovel), "red_sand": props(0.5, 0.5, ToolShovel),
+	"gravel": props(0.6, 0.6, ToolShovel), "clay": props(0.6, 0.6, ToolShovel),
+	"soul_sand":  props(0.5, 0.5, ToolShovel),
+	"snow":       props(0.1, 0.1, ToolShovel).requires(LevelWood),
+	"snow_block": props(0.2, 0.2, ToolShovel).requires(LevelWood),
+	"ice":        props(0.5, 0.5, ToolPickaxe), "packed_ice": props(0.5, 0.5, ToolPickaxe),
+	"frosted_ice": props(0.5, 0.5, ToolPickaxe), "blue_ice": props(2.8, 2.8, ToolPickaxe),
+
+	"sponge": props(0.6, 0.6, ToolNone), "wet_sponge": props(0.6, 0.6, ToolNone),
+	"glass": props(0.3, 0.3, ToolNone), "glass_pane": props(0.3, 0.3, ToolNone),
+	"iron_bars": props(5, 6, ToolPickaxe).requires(LevelWood),
+	"cobweb":    props(4, 4, ToolSword).requires(LevelWood),
+	"bookshelf": props(1.5, 1.5, ToolAxe),
+	"pumpkin":   props(1, 1, ToolAxe), "carved_pumpkin": props(1, 1, ToolAxe),
+	"jack_o_lantern": props(1, 1, ToolAxe), "melon": props(1, 1, ToolAxe),
+	"hay_block": props(0.5, 0.5, ToolNone), "dried_kelp_block": props(0.5, 2.5, ToolNone),
+	"nether_wart_block": props(1, 1, ToolNone),
+	"slime_block":       propsInstant, "honey_block": propsInstant,
+	"honeycomb_block":      props(0.6, 0.6, ToolNone),
+	"brown_mushroom_block": props(0.2, 0.2, ToolAxe), "red_mushroom_block": props(0.2, 0.2, ToolAxe),
+	"mushroom_stem": props(0.2, 0.2, ToolAxe),
+	"chorus_plant":  props(0.4, 0.4, ToolAxe), "chorus_flower": props(0.4, 0.4, ToolAxe),
+	"cactus": props(0.4, 0.4, ToolNone), "cocoa": props(0.2, 3, ToolAxe),
+	"vine": props(0.2, 0.2, ToolAxe), "ladder": props(0.4, 0.4, ToolAxe),
+	"bamboo": props(1, 1, ToolAxe), "bamboo_sapling": props(1, 1, ToolSword),
+	"scaffolding": propsInstant,
+	"dragon_egg":  props(3, 9, ToolNone), "turtle_egg": props(0.5, 0.5, ToolNone),
+	"sea_pickle": propsInstant, "conduit": props(3, 3, ToolPickaxe),
+
+	"grass": propsInstant, "fern": propsInstant, "dead_bush": propsInstant,
+	"tall_grass": propsInstant, "large_fern": propsInstant,
+	"seagrass": propsInstant, "tall_seagrass": propsInstant, "kelp": propsInstant, "kelp_plant": propsInstant,
+	"dandelion": propsInstant, "poppy": propsInstant, "blue_orchid": propsInstant,
+	"allium": propsInstant, "azure_bluet": propsInstant, "oxeye_daisy": propsInstant,
+	"cornflower": propsInstant, "lily_of_the_valley": propsInstant, "wither_rose": propsInstant,
+	"sunflower": propsInstant, "lilac": propsInstant, "rose_bush": propsInstant, "peony": propsInstant,
+	"wheat": propsInstant, "carrots": propsInstant, "potatoes": propsInstant, "beetroots": propsInstant,
+	"nether_wart": propsInstant, "sugar_cane": propsInstant, "sweet_berry_bush": propsInstant,
+	"fire": propsInstant, "redstone_wire": propsInstant, "tripwire": propsInstant, "tripwire_hook": propsInstant,
+	"soul_fire": propsInstant, "weeping_vines": propsInstant, "weeping_vines_plant": propsInstant, // 1.16
+	"twisting_vines": propsInstant, "twisting_vines_plant": propsInstant, // 1.16
+	"cake": props(0.5, 0.5, ToolNone), "tnt": propsInstant,
+
+	"crafting_table": propsWorkbench, "cartography_table": propsWorkbench,
+	"fletching_table": propsWorkbench, "smithing_table": propsWorkbench,
+	"loom": propsWorkbench, "barrel": propsWorkbench, "lectern": propsWorkbench,
+	"chest": propsWorkbench, "trapped_chest": propsWorkbench,
+	"note_block": props(0.8, 0.8, ToolAxe), "jukebox": props(2, 6, ToolAxe),
+	"composter": props(0.6, 0.6, ToolAxe), "campfire": props(2, 2, ToolAxe),
+	"bee_nest": props(0.3, 0.3, ToolAxe), "beehive": props(0.6, 0.6, ToolAxe),
+	"daylight_detector": props(0.2, 0.2, ToolAxe),
+	"ender_chest":       props(22.5, 600, ToolPickaxe).requires(LevelWood),
+	"furnace":           propsFurnace, "smoker": propsFurnace, "blast_furnace": propsFurnace,
+	"dispenser": propsFurnace, "dropper": propsFurnace, "stonecutter": propsFurnace,
+	"observer": props(3, 3, ToolPickaxe).requires(LevelWood),
+	"piston":   props(1.5, 1.5, ToolPickaxe), "sticky_piston": props(1.5, 1.5, ToolPickaxe),
+	"piston_head": props(1.5, 1.5, ToolPickaxe), "moving_piston": propsUnbreak,
+	"spawner":          props(5, 5, ToolPickaxe).requires(LevelWood),
+	"enchanting_table": props(5, 1200, ToolPickaxe).requires(LevelWood),
+	"brewing_stand":    props(0.5, 0.5, ToolPickaxe).requires(LevelWood),
+	"cauldron":         props(2, 2, ToolPickaxe).requires(LevelWood),
+	"hopper":           props(3, 4.8, ToolPickaxe).requires(LevelWood),
+	"anvil":            props(5, 1200, ToolPickaxe).requires(LevelWood),
+	"chipped_anvil":    props(5, 1200, ToolPickaxe).requires(LevelWood),
+	"damaged_anvil":    props(5, 1200, ToolPickaxe).requires(LevelWood),
+	"grindstone":       props(2, 6, ToolPickaxe).requires(LevelWood),
+	"bell":             props(5, 5, ToolPickaxe).requires(LevelWood),
+	"lantern":          props(3.5, 3.5, ToolPickaxe).requires(LevelWood),
+	"beacon":           props(3, 3, ToolNone),
+	"end_rod":          propsInstant, "flower_pot": propsInstant, "lily_pad": propsInstant,
+	"repeater": propsInstant, "comparator": propsInstant,
+
+	"iron_door":                     props(5, 5, ToolPickaxe).requires(LevelWood),
+	"iron_trapdoor":                 props(5, 5, ToolPickaxe).requires(LevelWood),
+	"nether_brick_fence":            propsCobble,
+	"stone_pressure_plate":          props(0.5, 0.5, ToolPickaxe).requires(LevelWood),
+	"light_weighted_pressure_plate": props(0.5, 0.5, ToolPickaxe).requires(LevelWood),
+	"heavy_weighted_pressure_plate": props(0.5, 0.5, ToolPickaxe).requires(LevelWood),
+	"stone_button":                  props(0.5, 0.5, ToolPickaxe),
+	"lever":                         props(0.5, 0.5, ToolNone),
+	"rail":                          props(0.7, 0.7, ToolPickaxe), "powered_rail": props(0.7, 0.7, ToolPickaxe),
+	"detector_rail": props(0.7, 0.7, ToolPickaxe), "activator_rail": props(0.7, 0.7, ToolPickaxe),
+	"skeleton_skull": props(1, 1, ToolNone), "wither_skeleton_skull": props(1, 1, ToolNone),
+	"skeleton_wall_skull": props(1, 1, ToolNone), "wither_skeleton_wall_skull": props(1, 1, ToolNone),
+
+	"infested_stone": props(0.75, 0.75, ToolNone), "infested_cobblestone": props(1, 0.75, ToolNone),
+	"infested_stone_bricks": props(0.75, 0.75, ToolNone), "infested_mossy_stone_bricks": props(0.75, 0.75, ToolNone),
+	"infested_cracked_stone_bricks": props(0.75, 0.75, ToolNone), "infested_chiseled_stone_bricks": props(0.75, 0.75, ToolNone),
+
+	"nether_portal": propsUnbreak, "end_portal": propsUnbreak, "end_gateway": propsUnbreak,
+	"end_portal_frame": propsUnbreak, "command_block": propsUnbreak,
+	"chain_command_block": propsUnbreak, "repeating_command_block": propsUnbreak,
+	"structure_block": propsUnbreak, "jigsaw": propsUnbreak, "barrier": propsUnbreak,
+	"structure_void": propsInstant,
+}
+
+// blockSuffixProperties are the properties of the block families, by the suffix of the names.
+// The first matched suffix is used.
+var blockSuffixProperties = []struct {
+	suffix string
+	props  blockProps
+}{
+	{"_glazed_terracotta", props(1.4, 1.4, ToolPickaxe).requires(LevelWood)},
+	{"_terracotta", props(1.25, 4.2, ToolPickaxe).requires(LevelWood)},
+	{"_concrete_powder", props(0.5, 0.5, ToolShovel)},
+	{"_concrete", props(1.8, 1.8, ToolPickaxe).requires(LevelWood)},
+	{"_stained_glass_pane", props(0.3, 0.3, ToolNone)},
+	{"_stained_glass", props(0.3, 0.3, ToolNone)},
+	{"_shulker_box", props(2, 2, ToolPickaxe)},
+	{"shulker_box", props(2, 2, ToolPickaxe)},
+	{"_wool", props(0.8, 0.8, ToolShears)},
+	{"_carpet", props(0.1, 0.1, ToolNone)},
+	{"_bed", props(0.2, 0.2, ToolNone)},
+	{"_banner", props(1, 1, ToolAxe)},
+	{"_leaves", props(0.2, 0.2, ToolShears)},
+	{"_planks", propsPlanks},
+	{"_log", propsLog},
+	{"_wood", propsLog},
+	{"_stem", propsLog},   // 1.16
+	{"_hyphae", propsLog}, // 1.16
+	{"_sign", props(1, 1, ToolAxe)},
+	{"_fence_gate", propsPlanks},
+	{"_fence", propsPlanks},
+	{"_trapdoor", props(3, 3, ToolAxe)},
+	{"_door", props(3, 3, ToolAxe)},
+	{"_pressure_plate", props(0.5, 0.5, ToolAxe)},
+	{"_button", props(0.5, 0.5, ToolAxe)},
+	{"_coral_block", propsCoralBlock},
+	{"_coral", propsInstant},
+	{"_coral_fan", propsInstant},
+	{"_coral_wall_fan", propsInstant},
+	{"_head", props(1, 1, ToolNone)},
+	{"torch", propsInstant},
+	{"_sapling", propsInstant},
+	{"_mushroom", propsInstant},
+	{"_tulip", propsInstant},
+	{"_roots", propsInstant},  // 1.16
+	{"_fungus", propsInstant}, // 1.16
+}
+
+// blockPropertiesOf return the properties of the block by the name without "minecraft:".
+// ok is false if the block isn't in the tables, and a guess is returned.
+func blockPropertiesOf(name string) (p blockProps, ok bool) {
+	if p, ok := blockProperties[name]; ok {
+		return p, true
+	}
+	switch {
+	case strings.HasPrefix(name, "potted_"):
+		return propsInstant, true
+	case strings.HasSuffix(name, "_slab"):
+		if base, ok := baseBlockProperties(strings.TrimSuffix(name, "_slab")); ok && base.tool == ToolAxe {
+			return base, true // wooden slabs
+		}
+		return propsStoneSlab, true
+	case strings.HasSuffix(name, "_stairs"):
+		if base, ok := baseBlockProperties(strings.TrimSuffix(name, "_stairs")); ok {
+			return base, true
+		}
+	case strings.HasSuffix(name, "_wall"):
+		if base, ok := baseBlockProperties(strings.TrimSuffix(name, "_wall")); ok {
+			return base, true
+		}
+	}
+	for _, v := range blockSuffixProperties {
+		if strings.HasSuffix(name, v.suffix) {
+			return v.props, true
+		}
+	}
+	return props(1, 1, ToolNone), false // unknown
+}
+
+// baseBlockProperties return the properties of the block
+// which the stairs, slabs or walls are made of, like "oak" to "oak_planks".
+func baseBlockProperties(base string) (blockProps, bool) {
+	switch base {
+	case "oak", "spruce", "birch", "jungle", "acacia", "dark_oak", "petrified_oak",
+		"crimson", "warped": // 1.16
+		return propsPlanks, true
+	case "smooth_stone", "stone":
+		return propsStone, true
+	}
+	for _, name := range []string{base, base + "s", base + "_block"} {
+		if p, ok := blockProperties[name]; ok {
+			return p, true
+		}
+	}
+	return blockProps{}, false
+}
+
+// The light emitted by the blocks.
+// If the block has the "lit" property, it only emits light when lit.
+var blockLight = map[string]int{
+	"lava": 15, "fire": 15, "glowstone": 15, "sea_lantern": 15, "jack_o_lantern": 15,
+	"beacon": 15, "conduit": 15, "end_portal": 15, "end_gateway": 15, "lantern": 15,
+	"campfire": 15, "redstone_lamp": 15,
+	"torch": 14, "wall_torch": 14, "end_rod": 14,
+	"furnace": 13, "smoker": 13, "blast_furnace": 13,
+	"nether_portal": 11, "redstone_ore": 9,
+	"redstone_torch": 7, "redstone_wall_torch": 7,
+	"magma_block":   3,
+	"brewing_stand": 1, "brown_mushroom": 1, "dragon_egg": 1, "end_portal_frame": 1,
+}
+
+// The full cubes which are not opaque
+var transparentBlocks = map[string]bool{
+	"glass": true, "ice": true, "frosted_ice": true, "slime_block": true, "honey_block": true,
+	"spawner": true, "beacon": true, "barrier": true,
+	"water": true, "lava": true, "bubble_column": true,
+}
+
+// build the shapes and the properties of the state by the name and the state properties.
+func (s *BlockState) build() {
+	name := strings.TrimPrefix(s.Name, "minecraft:")
+	s.Collision, _ = collisionShape(name, s.Properties)
+	s.Outline, _ = outlineShape(name, s.Properties, s.Collision)
+
+	p, _ := blockPropertiesOf(name)
+	s.Hardness = p.hardness
+	s.BlastResistance = p.resistance
+	s.Tool = p.tool
+	s.RequiresTool = p.requiresTool
+	s.HarvestLevel = p.level
+
+	s.Transparent = transparentBlocks[name] || !isFullCube(s.Outline) ||
+		strings.HasSuffix(name, "_leaves") || strings.HasSuffix(name, "_stained_glass")
+	s.Solid = !s.Transparent && isFullCube(s.Collision)
+
+	s.Light = blockLight[name]
+	if lit, ok := s.Properties["lit"]; ok && lit != "true" {
+		s.Light = 0
+	}
+	if name == "sea_pickle" && s.Properties["waterlogged"] == "true" {
+		s.Light = 3 * (atoi(s.Properties["pickles"]) + 1)
+	}
+}
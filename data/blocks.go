@@ -2,10 +2,14 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"math"
+	"strings"
+	"sync"
 )
 
-var blockStates map[string]struct {
+type blocksReport map[string]struct {
 	Properties map[string][]interface{} `json:"properties"`
 	States     []struct {
 		ID         int                    `json:"id"`
@@ -14,26 +18,146 @@ var blockStates map[string]struct {
 	} `json:"states"`
 }
 
+// BlockStates is the block state table of a protocol version.
+type BlockStates struct {
+	// NameByID stores each block names for each state ID.
+	NameByID []string
+	// States stores the details of each state ID.
+	States []BlockState
+	// DefaultByName stores the default state ID of each block.
+	DefaultByName map[string]int
+	//BitsPerBlock is how many bits used in network protocol per block.
+	BitsPerBlock int
+}
+
+// BlockState is a state of a block, like an oak stairs facing east.
+// The shapes and the properties of the blocks are built in
+// (see blockProperties), the unknown blocks are full cubes.
+type BlockState struct {
+	Name string
+	// Properties are the state properties, like "facing": "east".
+	// It's nil if the block doesn't have any property.
+	Properties map[string]string
+	Default    bool
+
+	// Collision are the boxes stopping the entities, nil if passable.
+	Collision []BlockBox
+	// Outline are the boxes selected by the cursor, nil for air and liquids.
+	Outline []BlockBox
+
+	Hardness        float64 // the time to dig, -1 for unbreakable blocks
+	BlastResistance float64
+	Tool            Tool // the tool digs this block faster, ToolNone if no such tool
+	// RequiresTool is true if the block drops nothing
+	// unless it's dug by the Tool at least HarvestLevel.
+	RequiresTool bool
+	HarvestLevel HarvestLevel
+
+	Solid       bool // an opaque full cube
+	Transparent bool // the light passes through
+	Light       int  // the light emitted, 0 to 15
+}
+
+// Property return the value of the state property, empty if not exist.
+func (s *BlockState) Property(name string) string {
+	return s.Properties[name]
+}
+
+// State return the state of the ID, nil if the ID is invalid.
+func (bs *BlockStates) State(id int) *BlockState {
+	if id < 0 || id >= len(bs.States) {
+		return nil
+	}
+	return &bs.States[id]
+}
+
 var (
 	//BlockNameByID stores each block names for each state ID.
 	BlockNameByID []string
 	//BitsPerBlock is how many bits used in network protocol per block.
 	BitsPerBlock int
+
+	blockStateTables = make(map[int]*BlockStates)
+	blockStatesLock  sync.RWMutex
 )
 
 func init() {
-	json.Unmarshal([]byte(blockStatesJSON), &blockStates)
-	BlockNameByID = make([]string, blockStatesLen)
-	for i, v := range blockStates {
+	bs, err := ReadBlockStates(strings.NewReader(blockStatesJSON))
+	if err != nil {
+		panic(fmt.Errorf("data: load block states fail: %v", err))
+	}
+	for _, protocol := range []int{573, 575, 578} { // 1.15 - 1.15.2
+		blockStateTables[protocol] = bs
+	}
+	BlockNameByID = bs.NameByID
+	BitsPerBlock = bs.BitsPerBlock
+}
+
+// ReadBlockStates read the block state table from a blocks report,
+// which is generated by vanilla server (see blockStatesJSON).
+func ReadBlockStates(r io.Reader) (*BlockStates, error) {
+	var report blocksReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, err
+	}
+
+	var length int
+	for _, v := range report {
 		for _, s := range v.States {
-			BlockNameByID[s.ID] = i
+			if s.ID+1 > length {
+				length = s.ID + 1
+			}
 		}
 	}
 
-	BitsPerBlock = int(math.Ceil(math.Log2(blockStatesLen)))
+	bs := &BlockStates{
+		NameByID:      make([]string, length),
+		States:        make([]BlockState, length),
+		DefaultByName: make(map[string]int, len(report)),
+		BitsPerBlock:  int(math.Ceil(math.Log2(float64(length)))),
+	}
+	for i, v := range report {
+		for _, s := range v.States {
+			bs.NameByID[s.ID] = i
+			state := BlockState{Name: i, Default: s.Default}
+			if len(s.Properties) > 0 {
+				state.Properties = make(map[string]string, len(s.Properties))
+				for k, v := range s.Properties {
+					state.Properties[k] = fmt.Sprint(v)
+				}
+			}
+			state.build()
+			bs.States[s.ID] = state
+			if s.Default {
+				bs.DefaultByName[i] = s.ID
+			}
+		}
+	}
+	return bs, nil
 }
 
-const blockStatesLen = 11336 + 1
+// LoadBlockStates read the blocks report of another Minecraft version
+// and use it for the protocol version.
+// Only the table of 1.15.x is built in.
+func LoadBlockStates(protocol int, r io.Reader) error {
+	bs, err := ReadBlockStates(r)
+	if err != nil {
+		return fmt.Errorf("data: read block states fail: %v", err)
+	}
+
+	blockStatesLock.Lock()
+	defer blockStatesLock.Unlock()
+	blockStateTables[protocol] = bs
+	return nil
+}
+
+// Blocks return the block state table of the protocol version.
+// It returns nil if the table of this version is not loaded.
+func Blocks(protocol int) *BlockStates {
+	blockStatesLock.RLock()
+	defer blockStatesLock.RUnlock()
+	return blockStateTables[protocol]
+}
 
 // Generate with follow steps:
 // java -cp minecraft_server.1.15.jar net.minecraft.data.Main --all
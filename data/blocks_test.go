@@ -0,0 +1,189 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func stateOf(t *testing.T, name string, props map[string]string) *BlockState {
+	bs := Blocks(578)
+	for i := range bs.States {
+		s := &bs.States[i]
+		if s.Name != name {
+			continue
+		}
+		match := true
+		for k, v := range props {
+			match = match && s.Property(k) == v
+		}
+		if match {
+			return s
+		}
+	}
+	t.Fatalf("state %s %v not found", name, props)
+	return nil
+}
+
+func TestBlockStates(t *testing.T) {
+	bs := Blocks(578)
+	if len(bs.States) != len(bs.NameByID) {
+		t.Fatalf("%d states for %d IDs", len(bs.States), len(bs.NameByID))
+	}
+	for i, s := range bs.States {
+		if s.Name != bs.NameByID[i] {
+			t.Fatalf("state %d: name %q != %q", i, s.Name, bs.NameByID[i])
+		}
+	}
+	id := bs.DefaultByName["minecraft:oak_stairs"]
+	if s := bs.State(id); !s.Default || s.Property("facing") != "north" || s.Property("half") != "bottom" {
+		t.Errorf("wrong default oak stairs: %v", s.Properties)
+	}
+	if bs.State(-1) != nil || bs.State(len(bs.States)) != nil {
+		t.Error("invalid ID should return nil")
+	}
+}
+
+func TestBlockState_properties(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		props    map[string]string
+		hardness float64
+		tool     Tool
+		requires bool
+		level    HarvestLevel
+		solid    bool
+		light    int
+	}{
+		{"minecraft:stone", nil, 1.5, ToolPickaxe, true, LevelWood, true, 0},
+		{"minecraft:obsidian", nil, 50, ToolPickaxe, true, LevelDiamond, true, 0},
+		{"minecraft:diamond_ore", nil, 3, ToolPickaxe, true, LevelIron, true, 0},
+		{"minecraft:birch_planks", nil, 2, ToolAxe, false, 0, true, 0},
+		{"minecraft:stone_brick_stairs", nil, 1.5, ToolPickaxe, true, LevelWood, false, 0},
+		{"minecraft:spruce_slab", nil, 2, ToolAxe, false, 0, false, 0},
+		{"minecraft:sandstone_slab", nil, 2, ToolPickaxe, true, LevelWood, false, 0},
+		{"minecraft:glass", nil, 0.3, ToolNone, false, 0, false, 0},
+		{"minecraft:oak_leaves", nil, 0.2, ToolShears, false, 0, false, 0},
+		{"minecraft:bedrock", nil, -1, ToolNone, false, 0, true, 0},
+		{"minecraft:torch", nil, 0, ToolNone, false, 0, false, 14},
+		{"minecraft:furnace", map[string]string{"lit": "false"}, 3.5, ToolPickaxe, true, LevelWood, true, 0},
+		{"minecraft:furnace", map[string]string{"lit": "true"}, 3.5, ToolPickaxe, true, LevelWood, true, 13},
+		{"minecraft:red_glazed_terracotta", nil, 1.4, ToolPickaxe, true, LevelWood, true, 0},
+	} {
+		s := stateOf(t, tt.name, tt.props)
+		if s.Hardness != tt.hardness || s.Tool != tt.tool || s.RequiresTool != tt.requires ||
+			s.HarvestLevel != tt.level || s.Solid != tt.solid || s.Light != tt.light {
+			t.Errorf("%s %v: got hardness=%v tool=%v requires=%v level=%v solid=%v light=%v",
+				tt.name, tt.props, s.Hardness, s.Tool, s.RequiresTool, s.HarvestLevel, s.Solid, s.Light)
+		}
+	}
+}
+
+func TestBlockState_shapes(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		props     map[string]string
+		collision []BlockBox
+	}{
+		{"minecraft:air", nil, nil},
+		{"minecraft:grass", nil, nil},
+		{"minecraft:dirt", nil, fullCube},
+		{"minecraft:potted_oak_sapling", nil, shape(box(5, 0, 5, 11, 6, 11))},
+		{"minecraft:oak_slab", map[string]string{"type": "top"}, shape(box(0, 8, 0, 16, 16, 16))},
+		{"minecraft:oak_stairs", map[string]string{"facing": "east", "half": "bottom", "shape": "straight"},
+			shape(box(0, 0, 0, 16, 8, 16), box(8, 8, 0, 16, 16, 16))},
+		{"minecraft:oak_stairs", map[string]string{"facing": "north", "half": "top", "shape": "outer_left"},
+			shape(box(0, 8, 0, 16, 16, 16), box(0, 0, 0, 8, 8, 8))},
+		{"minecraft:oak_fence", map[string]string{"north": "false", "south": "false", "east": "true", "west": "false"},
+			shape(box(6, 0, 6, 10, 24, 10), box(10, 0, 6, 16, 24, 10))},
+		{"minecraft:oak_fence_gate", map[string]string{"open": "true"}, nil},
+		{"minecraft:oak_door", map[string]string{"facing": "east", "open": "false"}, shape(box(0, 0, 0, 3, 16, 16))},
+		{"minecraft:oak_door", map[string]string{"facing": "east", "open": "true", "hinge": "right"}, shape(box(0, 0, 13, 16, 16, 16))},
+		{"minecraft:snow", map[string]string{"layers": "3"}, shape(box(0, 0, 0, 16, 4, 16))},
+		{"minecraft:ladder", map[string]string{"facing": "south"}, shape(box(0, 0, 0, 16, 16, 3))},
+		{"minecraft:piston", map[string]string{"facing": "up", "extended": "true"}, shape(box(0, 0, 0, 16, 12, 16))},
+	} {
+		s := stateOf(t, tt.name, tt.props)
+		if !equalBoxes(s.Collision, tt.collision) {
+			t.Errorf("%s %v: want %v, got %v", tt.name, tt.props, tt.collision, s.Collision)
+		}
+	}
+
+	if s := stateOf(t, "minecraft:water", nil); s.Outline != nil || !s.Transparent || s.Light != 0 {
+		t.Error("wrong water")
+	}
+	if s := stateOf(t, "minecraft:oak_fence", nil); s.Outline[0].MaxY != 1 {
+		t.Error("the outline of the fences should be 1 block high")
+	}
+	for _, tt := range []struct {
+		name    string
+		props   map[string]string
+		outline []BlockBox
+	}{
+		{"minecraft:wheat", map[string]string{"age": "3"}, shape(box(0, 0, 0, 16, 8, 16))},
+		{"minecraft:wall_torch", map[string]string{"facing": "east"}, shape(box(0, 3, 5.5, 5, 13, 10.5))},
+		{"minecraft:stone_button", map[string]string{"face": "floor", "facing": "east", "powered": "false"},
+			shape(box(6, 0, 5, 10, 2, 11))},
+		{"minecraft:lever", map[string]string{"face": "wall", "facing": "south"}, shape(box(5, 4, 0, 11, 12, 6))},
+	} {
+		s := stateOf(t, tt.name, tt.props)
+		if !equalBoxes(s.Outline, tt.outline) {
+			t.Errorf("%s %v: want outline %v, got %v", tt.name, tt.props, tt.outline, s.Outline)
+		}
+	}
+}
+
+// Every block state must be found in the tables of the properties and the shapes,
+// or it is guessed silently.
+func TestBlockStates_explicit(t *testing.T) {
+	missing := make(map[string]bool)
+	for _, s := range Blocks(578).States {
+		name := strings.TrimPrefix(s.Name, "minecraft:")
+		_, okProps := blockPropertiesOf(name)
+		collision, okCollision := collisionShape(name, s.Properties)
+		_, okOutline := outlineShape(name, s.Properties, collision)
+		if !okProps || !okCollision || !okOutline {
+			missing[name] = true
+		}
+	}
+	for name := range missing {
+		t.Errorf("%s isn't in the block tables", name)
+	}
+}
+
+func equalBoxes(a, b []BlockBox) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBlockState_DigSpeed(t *testing.T) {
+	for _, tt := range []struct {
+		block, item string
+		speed       float64
+		harvest     bool
+	}{
+		{"minecraft:stone", "", 1, false},
+		{"minecraft:stone", "minecraft:wooden_pickaxe", 2, true},
+		{"minecraft:stone", "minecraft:golden_shovel", 1, false},
+		{"minecraft:diamond_ore", "minecraft:stone_pickaxe", 4, false},
+		{"minecraft:diamond_ore", "minecraft:iron_pickaxe", 6, true},
+		{"minecraft:dirt", "minecraft:diamond_sword", 1, true},
+		{"minecraft:oak_leaves", "minecraft:wooden_sword", 1.5, true},
+		{"minecraft:melon", "minecraft:iron_sword", 1.5, true},
+		{"minecraft:tube_coral_block", "minecraft:stone_sword", 1.5, false},
+		{"minecraft:cobweb", "minecraft:shears", 15, true},
+		{"minecraft:white_wool", "minecraft:shears", 5, true},
+		{"minecraft:oak_log", "minecraft:netherite_axe", 9, true},
+	} {
+		s := stateOf(t, tt.block, nil)
+		if speed, harvest := s.DigSpeed(tt.item); speed != tt.speed || harvest != tt.harvest {
+			t.Errorf("dig %s by %q: get %v %v, want %v %v", tt.block, tt.item, speed, harvest, tt.speed, tt.harvest)
+		}
+	}
+}
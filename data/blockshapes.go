@@ -0,0 +1,632 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BlockBox is an axis-aligned box in a block, from (0, 0, 0) to (1, 1, 1) for a full cube.
+type BlockBox struct {
+	MinX, MinY, MinZ float64
+	MaxX, MaxY, MaxZ float64
+}
+
+// box is the BlockBox in 1/16 blocks, like the vanilla Block.makeCuboidShape
+func box(minX, minY, minZ, maxX, maxY, maxZ float64) BlockBox {
+	return BlockBox{minX / 16, minY / 16, minZ / 16, maxX / 16, maxY / 16, maxZ / 16}
+}
+
+// shape of multiple boxes
+func shape(boxes ...BlockBox) []BlockBox { return boxes }
+
+var fullCube = shape(box(0, 0, 0, 16, 16, 16))
+
+func isFullCube(s []BlockBox) bool {
+	return len(s) == 1 && s[0] == fullCube[0]
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
+
+// The blocks without collision boxes
+var passableBlocks = map[string]bool{
+	"air": true, "cave_air": true, "void_air": true,
+	"water": true, "lava": true, "bubble_column": true,
+	"grass": true, "tall_grass": true, "fern": true, "large_fern": true, "dead_bush": true,
+	"seagrass": true, "tall_seagrass": true, "kelp": true, "kelp_plant": true,
+	"dandelion": true, "poppy": true, "blue_orchid": true, "allium": true, "azure_bluet": true,
+	"oxeye_daisy": true, "cornflower": true, "lily_of_the_valley": true, "wither_rose": true,
+	"sunflower": true, "lilac": true, "rose_bush": true, "peony": true,
+	"brown_mushroom": true, "red_mushroom": true, "bamboo_sapling": true,
+	"wheat": true, "carrots": true, "potatoes": true, "beetroots": true, "nether_wart": true,
+	"pumpkin_stem": true, "melon_stem": true, "attached_pumpkin_stem": true, "attached_melon_stem": true,
+	"sugar_cane": true, "sweet_berry_bush": true, "vine": true, "scaffolding": true,
+	"redstone_wire": true, "lever": true, "tripwire": true, "tripwire_hook": true,
+	"fire": true, "nether_portal": true, "end_portal": true, "end_gateway": true,
+	"cobweb": true, "structure_void": true, "moving_piston": true,
+	"soul_fire": true, "weeping_vines": true, "weeping_vines_plant": true, // 1.16
+	"twisting_vines": true, "twisting_vines_plant": true, // 1.16
+}
+
+// The suffixes of the names of the blocks without collision boxes
+var passableSuffixes = []string{
+	"_sapling", "_tulip", "torch", "rail", "_sign", "_button", "_pressure_plate",
+	"_banner", "_coral", "_coral_fan", "_coral_wall_fan", "_roots", "_fungus",
+}
+
+// Shapes of the blocks not depending on the state properties
+var blockShapes = map[string][]BlockBox{
+	"soul_sand":         shape(box(0, 0, 0, 16, 14, 16)),
+	"farmland":          shape(box(0, 0, 0, 16, 15, 16)),
+	"grass_path":        shape(box(0, 0, 0, 16, 15, 16)),
+	"cactus":            shape(box(1, 0, 1, 15, 15, 15)),
+	"honey_block":       shape(box(1, 0, 1, 15, 15, 15)),
+	"enchanting_table":  shape(box(0, 0, 0, 16, 12, 16)),
+	"chest":             shape(box(1, 0, 1, 15, 14, 15)),
+	"trapped_chest":     shape(box(1, 0, 1, 15, 14, 15)),
+	"ender_chest":       shape(box(1, 0, 1, 15, 14, 15)),
+	"dragon_egg":        shape(box(1, 0, 1, 15, 16, 15)),
+	"lily_pad":          shape(box(1, 0, 1, 15, 1.5, 15)),
+	"stonecutter":       shape(box(0, 0, 0, 16, 9, 16)),
+	"conduit":           shape(box(5, 5, 5, 11, 11, 11)),
+	"flower_pot":        shape(box(5, 0, 5, 11, 6, 11)),
+	"brewing_stand":     shape(box(1, 0, 1, 15, 2, 15), box(7, 0, 7, 9, 14, 9)),
+	"lectern":           shape(box(0, 0, 0, 16, 2, 16), box(4, 2, 4, 12, 14, 12)),
+	"hopper":            shape(box(0, 10, 0, 16, 16, 16), box(4, 4, 4, 12, 10, 12)),
+	"cauldron":          bowl(4),
+	"composter":         bowl(2),
+	"grindstone":        shape(box(2, 0, 2, 14, 16, 14)),
+	"bell":              shape(box(4, 4, 4, 12, 16, 12)),
+	"bamboo":            shape(box(6.5, 0, 6.5, 9.5, 16, 9.5)),
+	"cocoa":             shape(box(5, 3, 5, 11, 12, 11)),
+	"skeleton_skull":    shape(box(4, 0, 4, 12, 8, 12)),
+	"daylight_detector": shape(box(0, 0, 0, 16, 6, 16)),
+	"repeater":          shape(box(0, 0, 0, 16, 2, 16)),
+	"comparator":        shape(box(0, 0, 0, 16, 2, 16)),
+	"campfire":          shape(box(0, 0, 0, 16, 7, 16)),
+}
+
+// The full cubes not matched by cubeSuffixes.
+// Every block of 1.15.2 must be found in these tables, see TestBlockStates_explicit.
+var cubeBlocks = map[string]bool{
+	"stone": true, "granite": true, "polished_granite": true, "diorite": true, "polished_diorite": true,
+	"andesite": true, "polished_andesite": true, "cobblestone": true, "mossy_cobblestone": true,
+	"smooth_stone": true, "stone_bricks": true, "mossy_stone_bricks": true,
+	"cracked_stone_bricks": true, "chiseled_stone_bricks": true, "bricks": true,
+	"infested_stone": true, "infested_cobblestone": true, "infested_stone_bricks": true,
+	"infested_mossy_stone_bricks": true, "infested_cracked_stone_bricks": true, "infested_chiseled_stone_bricks": true,
+	"sandstone": true, "chiseled_sandstone": true, "cut_sandstone": true, "smooth_sandstone": true,
+	"red_sandstone": true, "chiseled_red_sandstone": true, "cut_red_sandstone": true, "smooth_red_sandstone": true,
+	"bedrock": true, "obsidian": true, "barrier": true,
+	"dirt": true, "coarse_dirt": true, "podzol": true, "grass_block": true, "mycelium": true, "clay": true,
+	"sand": true, "red_sand": true, "gravel": true, "snow_block": true,
+	"ice": true, "packed_ice": true, "blue_ice": true, "frosted_ice": true,
+
+	"coal_block": true, "iron_block": true, "gold_block": true, "diamond_block": true,
+	"emerald_block": true, "lapis_block": true, "redstone_block": true,
+	"quartz_block": true, "chiseled_quartz_block": true, "quartz_pillar": true, "smooth_quartz": true,
+	"purpur_block": true, "purpur_pillar": true, "end_stone": true, "end_stone_bricks": true,
+	"prismarine": true, "prismarine_bricks": true, "dark_prismarine": true, "sea_lantern": true,
+	"netherrack": true, "nether_bricks": true, "red_nether_bricks": true, "nether_wart_block": true,
+	"magma_block": true, "glowstone": true, "bone_block": true, "terracotta": true, "glass": true,
+	"sponge": true, "wet_sponge": true, "slime_block": true, "honeycomb_block": true,
+	"hay_block": true, "dried_kelp_block": true, "tnt": true, "bookshelf": true,
+	"pumpkin": true, "carved_pumpkin": true, "jack_o_lantern": true, "melon": true, "chorus_flower": true,
+	"brown_mushroom_block": true, "red_mushroom_block": true, "mushroom_stem": true,
+
+	"crafting_table": true, "cartography_table": true, "fletching_table": true, "smithing_table": true,
+	"loom": true, "barrel": true, "furnace": true, "smoker": true, "blast_furnace": true,
+	"dispenser": true, "dropper": true, "observer": true, "note_block": true, "jukebox": true,
+	"redstone_lamp": true, "spawner": true, "beacon": true, "bee_nest": true, "beehive": true,
+	"command_block": true, "chain_command_block": true, "repeating_command_block": true,
+	"structure_block": true, "jigsaw": true,
+	"crimson_stem": true, "warped_stem": true, "stripped_crimson_stem": true, "stripped_warped_stem": true, // 1.16
+}
+
+// The suffixes of the names of the full cubes
+var cubeSuffixes = []string{
+	"_planks", "_log", "_wood", "_leaves", "_ore", "_coral_block",
+	"_wool", "_terracotta", "_concrete", "_concrete_powder", "_stained_glass", "shulker_box",
+	"_hyphae", "_nylium", // 1.16
+}
+
+// bowl is the shape of the cauldron and the composter, with the bottom at y.
+func bowl(y float64) []BlockBox {
+	return shape(
+		box(0, 0, 0, 16, y, 16),
+		box(0, y, 0, 2, 16, 16), box(14, y, 0, 16, 16, 16),
+		box(2, y, 0, 14, 16, 2), box(2, y, 14, 14, 16, 16),
+	)
+}
+
+// collisionShape return the collision boxes of the block state.
+// ok is false if the block isn't in the tables, and a full cube is returned.
+func collisionShape(name string, p map[string]string) (s []BlockBox, ok bool) {
+	if strings.HasPrefix(name, "potted_") {
+		return blockShapes["flower_pot"], true
+	}
+	if passableBlocks[name] {
+		return nil, true
+	}
+	for _, suffix := range passableSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return nil, true
+		}
+	}
+	if s, ok := blockShapes[name]; ok {
+		return s, true
+	}
+	if cubeBlocks[name] {
+		return fullCube, true
+	}
+	for _, suffix := range cubeSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return fullCube, true
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(name, "_slab"):
+		switch p["type"] {
+		case "top":
+			return shape(box(0, 8, 0, 16, 16, 16)), true
+		case "double":
+			return fullCube, true
+		}
+		return shape(box(0, 0, 0, 16, 8, 16)), true
+	case strings.HasSuffix(name, "_stairs"):
+		return stairsShape(p), true
+	case strings.HasSuffix(name, "_fence_gate"):
+		if p["open"] == "true" {
+			return nil, true
+		}
+		if f := p["facing"]; f == "east" || f == "west" {
+			return shape(box(6, 0, 0, 10, 24, 16)), true
+		}
+		return shape(box(0, 0, 6, 16, 24, 10)), true
+	case strings.HasSuffix(name, "_fence"):
+		return fourWayShape(p, 6, 6, 24), true
+	case strings.HasSuffix(name, "_wall"):
+		return fourWayShape(p, 4, 5, 24), true
+	case name == "iron_bars", strings.HasSuffix(name, "glass_pane"):
+		return fourWayShape(p, 7, 7, 16), true
+	case strings.HasSuffix(name, "_door"):
+		return doorShape(p), true
+	case strings.HasSuffix(name, "_trapdoor"):
+		if p["open"] == "true" {
+			return facingPlate(p["facing"], 3, false), true
+		}
+		if p["half"] == "top" {
+			return shape(box(0, 13, 0, 16, 16, 16)), true
+		}
+		return shape(box(0, 0, 0, 16, 3, 16)), true
+	case strings.HasSuffix(name, "_carpet"):
+		return shape(box(0, 0, 0, 16, 1, 16)), true
+	case strings.HasSuffix(name, "_bed"):
+		return shape(box(0, 0, 0, 16, 9, 16)), true
+	case strings.HasSuffix(name, "_skull"), strings.HasSuffix(name, "_head"):
+		if strings.Contains(name, "_wall_") {
+			return wallHeadShape(p["facing"]), true
+		}
+		return blockShapes["skeleton_skull"], true
+	case strings.HasSuffix(name, "anvil"):
+		if f := p["facing"]; f == "east" || f == "west" {
+			return shape(box(2, 0, 2, 14, 4, 14), box(4, 4, 4, 12, 10, 12), box(0, 10, 3, 16, 16, 13)), true
+		}
+		return shape(box(2, 0, 2, 14, 4, 14), box(4, 4, 4, 12, 10, 12), box(3, 10, 0, 13, 16, 16)), true
+	}
+
+	switch name {
+	case "snow":
+		if layers := atoi(p["layers"]); layers > 1 {
+			return shape(box(0, 0, 0, 16, float64(layers-1)*2, 16)), true
+		}
+		return nil, true
+	case "ladder":
+		return facingPlate(p["facing"], 3, false), true
+	case "cake":
+		return shape(box(1+2*float64(atoi(p["bites"])), 0, 1, 15, 8, 15)), true
+	case "end_portal_frame":
+		if p["eye"] == "true" {
+			return shape(box(0, 0, 0, 16, 13, 16), box(4, 13, 4, 12, 16, 12)), true
+		}
+		return shape(box(0, 0, 0, 16, 13, 16)), true
+	case "sea_pickle":
+		switch p["pickles"] {
+		case "2":
+			return shape(box(3, 0, 3, 13, 6, 13)), true
+		case "3":
+			return shape(box(2, 0, 2, 14, 6, 14)), true
+		case "4":
+			return shape(box(2, 0, 2, 14, 7, 14)), true
+		}
+		return shape(box(6, 0, 6, 10, 6, 10)), true
+	case "turtle_egg":
+		if p["eggs"] == "1" {
+			return shape(box(3, 0, 3, 12, 7, 12)), true
+		}
+		return shape(box(1, 0, 1, 15, 7, 15)), true
+	case "lantern":
+		if p["hanging"] == "true" {
+			return shape(box(5, 1, 5, 11, 8, 11), box(6, 8, 6, 10, 10, 10)), true
+		}
+		return shape(box(5, 0, 5, 11, 7, 11), box(6, 7, 6, 10, 9, 10)), true
+	case "end_rod":
+		switch p["facing"] {
+		case "east", "west":
+			return shape(box(0, 6, 6, 16, 10, 10)), true
+		case "north", "south":
+			return shape(box(6, 6, 0, 10, 10, 16)), true
+		}
+		return shape(box(6, 0, 6, 10, 16, 10)), true
+	case "chorus_plant":
+		return chorusShape(p), true
+	case "piston", "sticky_piston":
+		if p["extended"] == "true" {
+			return facingPlate(p["facing"], 12, false), true
+		}
+		return fullCube, true
+	case "piston_head":
+		return append(facingPlate(p["facing"], 4, true), pistonArm(p["facing"])), true
+	}
+	return fullCube, false
+}
+
+// outlineShape return the boxes selected by the cursor.
+// They are the same as the collision boxes, except for the passable blocks.
+// ok is false if the block isn't in the tables, and a guess is returned.
+func outlineShape(name string, p map[string]string, collision []BlockBox) (s []BlockBox, ok bool) {
+	switch name {
+	case "air", "cave_air", "void_air", "water", "lava", "bubble_column",
+		"moving_piston", "structure_void":
+		return nil, true
+	case "snow":
+		return shape(box(0, 0, 0, 16, float64(atoi(p["layers"]))*2, 16)), true
+	case "soul_sand", "honey_block", "scaffolding", "cobweb",
+		"nether_portal", "end_gateway":
+		return fullCube, true
+	case "cactus":
+		return shape(box(1, 0, 1, 15, 16, 15)), true
+	}
+	if strings.HasSuffix(name, "_fence_gate") {
+		s, _ := collisionShape(name, map[string]string{"facing": p["facing"]})
+		return s, true
+	}
+	if strings.HasSuffix(name, "_fence") || strings.HasSuffix(name, "_wall") {
+		// lower than the collision boxes
+		for _, b := range collision {
+			b.MaxY = 1
+			s = append(s, b)
+		}
+		return s, true
+	}
+	if collision == nil {
+		return passableOutline(name, p)
+	}
+	return collision, true
+}
+
+// Outlines of the blocks without collision boxes, not depending on the state properties
+var passableOutlines = map[string][]BlockBox{
+	"grass": shape(box(2, 0, 2, 14, 13, 14)), "fern": shape(box(2, 0, 2, 14, 13, 14)),
+	"dead_bush":  shape(box(2, 0, 2, 14, 13, 14)),
+	"tall_grass": fullCube, "large_fern": fullCube,
+	"sunflower": fullCube, "lilac": fullCube, "rose_bush": fullCube, "peony": fullCube,
+	"dandelion": shape(box(5, 0, 5, 11, 10, 11)), "poppy": shape(box(5, 0, 5, 11, 10, 11)),
+	"blue_orchid": shape(box(5, 0, 5, 11, 10, 11)), "allium": shape(box(5, 0, 5, 11, 10, 11)),
+	"azure_bluet": shape(box(5, 0, 5, 11, 10, 11)), "oxeye_daisy": shape(box(5, 0, 5, 11, 10, 11)),
+	"cornflower": shape(box(5, 0, 5, 11, 10, 11)), "lily_of_the_valley": shape(box(5, 0, 5, 11, 10, 11)),
+	"wither_rose":    shape(box(5, 0, 5, 11, 10, 11)),
+	"brown_mushroom": shape(box(5, 0, 5, 11, 6, 11)), "red_mushroom": shape(box(5, 0, 5, 11, 6, 11)),
+	"bamboo_sapling": shape(box(4, 0, 4, 12, 12, 12)),
+	"seagrass":       shape(box(2, 0, 2, 14, 12, 14)), "tall_seagrass": shape(box(2, 0, 2, 14, 16, 14)),
+	"kelp": shape(box(0, 0, 0, 16, 9, 16)), "kelp_plant": fullCube,
+	"sugar_cane":    shape(box(2, 0, 2, 14, 16, 14)),
+	"redstone_wire": shape(box(0, 0, 0, 16, 1, 16)),
+	"fire":          shape(box(0, 0, 0, 16, 1, 16)),
+	"end_portal":    shape(box(0, 0, 0, 16, 12, 16)),
+
+	"soul_fire":     shape(box(0, 0, 0, 16, 1, 16)),                                                          // 1.16
+	"weeping_vines": shape(box(4, 9, 4, 12, 16, 12)), "weeping_vines_plant": shape(box(1, 0, 1, 15, 16, 15)), // 1.16
+	"twisting_vines": shape(box(4, 0, 4, 12, 15, 12)), "twisting_vines_plant": shape(box(1, 0, 1, 15, 16, 15)), // 1.16
+}
+
+// passableOutline return the outline of the block state without collision boxes.
+func passableOutline(name string, p map[string]string) ([]BlockBox, bool) {
+	if s, ok := passableOutlines[name]; ok {
+		return s, true
+	}
+	switch {
+	case strings.HasSuffix(name, "_sapling"):
+		return shape(box(2, 0, 2, 14, 12, 14)), true
+	case strings.HasSuffix(name, "_tulip"):
+		return passableOutlines["poppy"], true
+	case strings.HasSuffix(name, "wall_torch"):
+		return shape(rotate(box(5.5, 3, 11, 10.5, 13, 16), p["facing"])), true
+	case strings.HasSuffix(name, "torch"):
+		return shape(box(6, 0, 6, 10, 10, 10)), true
+	case strings.HasSuffix(name, "_wall_sign"):
+		return shape(rotate(box(0, 4.5, 14, 16, 12.5, 16), p["facing"])), true
+	case strings.HasSuffix(name, "_wall_banner"):
+		return shape(rotate(box(0, 0, 14, 16, 12.5, 16), p["facing"])), true
+	case strings.HasSuffix(name, "_sign"), strings.HasSuffix(name, "_banner"):
+		return shape(box(4, 0, 4, 12, 16, 12)), true
+	case strings.HasSuffix(name, "_coral_wall_fan"):
+		return shape(rotate(box(0, 4, 5, 16, 12, 16), p["facing"])), true
+	case strings.HasSuffix(name, "_coral_fan"):
+		return shape(box(2, 0, 2, 14, 4, 14)), true
+	case strings.HasSuffix(name, "_coral"):
+		return shape(box(2, 0, 2, 14, 15, 14)), true
+	case strings.HasSuffix(name, "_button"):
+		h := 2.0
+		if p["powered"] == "true" {
+			h = 1
+		}
+		return attachedShape(p, box(5, 6, 16-h, 11, 10, 16)), true
+	case strings.HasSuffix(name, "_pressure_plate"):
+		if p["powered"] == "true" || atoi(p["power"]) > 0 {
+			return shape(box(1, 0, 1, 15, 0.5, 15)), true
+		}
+		return shape(box(1, 0, 1, 15, 1, 15)), true
+	case strings.HasSuffix(name, "rail"):
+		if strings.HasPrefix(p["shape"], "ascending_") {
+			return shape(box(0, 0, 0, 16, 8, 16)), true
+		}
+		return shape(box(0, 0, 0, 16, 2, 16)), true
+	case strings.HasSuffix(name, "_roots"): // 1.16
+		return shape(box(2, 0, 2, 14, 13, 14)), true
+	case strings.HasSuffix(name, "_fungus"): // 1.16
+		return shape(box(4, 0, 4, 12, 9, 12)), true
+	}
+
+	switch age := float64(atoi(p["age"])); name {
+	case "wheat", "carrots", "potatoes", "beetroots":
+		return shape(box(0, 0, 0, 16, 2*(age+1), 16)), true
+	case "nether_wart":
+		return shape(box(0, 0, 0, 16, 5+3*age, 16)), true
+	case "pumpkin_stem", "melon_stem":
+		return shape(box(7, 0, 7, 9, 2+2*age, 9)), true
+	case "attached_pumpkin_stem", "attached_melon_stem":
+		// the stem bends to the fruit in front of it
+		return shape(rotate(box(6, 0, 0, 10, 10, 10), p["facing"])), true
+	case "sweet_berry_bush":
+		if age == 0 {
+			return shape(box(3, 0, 3, 13, 8, 13)), true
+		}
+		return shape(box(1, 0, 1, 15, 16, 15)), true
+	case "tripwire":
+		if p["attached"] == "true" {
+			return shape(box(0, 1, 0, 16, 2.5, 16)), true
+		}
+		return shape(box(0, 0, 0, 16, 8, 16)), true
+	case "tripwire_hook":
+		return shape(rotate(box(5, 0, 10, 11, 10, 16), p["facing"])), true
+	case "lever":
+		return attachedShape(p, box(5, 4, 10, 11, 12, 16)), true
+	case "vine":
+		var s []BlockBox
+		sides := map[string]BlockBox{
+			"north": box(0, 0, 0, 16, 16, 1), "south": box(0, 0, 15, 16, 16, 16),
+			"west": box(0, 0, 0, 1, 16, 16), "east": box(15, 0, 0, 16, 16, 16),
+			"up": box(0, 15, 0, 16, 16, 16),
+		}
+		for _, d := range []string{"north", "south", "west", "east", "up"} {
+			if p[d] == "true" {
+				s = append(s, sides[d])
+			}
+		}
+		return s, true
+	}
+	return shape(box(2, 0, 2, 14, 13, 14)), false
+}
+
+// attachedShape is the shape of the buttons and the levers, which are attached to a face of the block.
+// wall is the box on the wall facing north, and it's laid on the floor or the ceiling by the face property.
+func attachedShape(p map[string]string, wall BlockBox) []BlockBox {
+	// the depth from the wall becomes the height
+	b := BlockBox{MinX: wall.MinX, MinY: 0, MinZ: wall.MinY, MaxX: wall.MaxX, MaxY: wall.MaxZ - wall.MinZ, MaxZ: wall.MaxY}
+	if f := p["facing"]; f == "east" || f == "west" {
+		b.MinX, b.MinZ, b.MaxX, b.MaxZ = b.MinZ, b.MinX, b.MaxZ, b.MaxX
+	}
+	switch p["face"] {
+	case "floor":
+		return shape(b)
+	case "ceiling":
+		b.MinY, b.MaxY = 1-b.MaxY, 1
+		return shape(b)
+	}
+	return shape(rotate(wall, p["facing"]))
+}
+
+// rotate turn the box of a block facing north to the direction.
+func rotate(b BlockBox, facing string) BlockBox {
+	switch facing {
+	case "south":
+		return BlockBox{MinX: 1 - b.MaxX, MinY: b.MinY, MinZ: 1 - b.MaxZ, MaxX: 1 - b.MinX, MaxY: b.MaxY, MaxZ: 1 - b.MinZ}
+	case "west":
+		return BlockBox{MinX: b.MinZ, MinY: b.MinY, MinZ: b.MinX, MaxX: b.MaxZ, MaxY: b.MaxY, MaxZ: b.MaxX}
+	case "east":
+		return BlockBox{MinX: 1 - b.MaxZ, MinY: b.MinY, MinZ: 1 - b.MaxX, MaxX: 1 - b.MinZ, MaxY: b.MaxY, MaxZ: 1 - b.MinX}
+	}
+	return b
+}
+
+// stairsShape return the shape of the stairs by the facing, half and shape properties.
+func stairsShape(p map[string]string) []BlockBox {
+	bottom, top := 0.0, 8.0 // the slab and the step
+	if p["half"] == "top" {
+		bottom, top = 8, 0
+	}
+	s := shape(box(0, bottom, 0, 16, bottom+8, 16))
+
+	// quarters in facing direction, the left side and the right side of the step
+	facing, left := p["facing"], leftOf(p["facing"])
+	right := opposite(left)
+	step := func(dirs ...string) BlockBox {
+		b := box(0, top, 0, 16, top+8, 16)
+		for _, d := range dirs {
+			switch d {
+			case "north":
+				b.MaxZ = 0.5
+			case "south":
+				b.MinZ = 0.5
+			case "west":
+				b.MaxX = 0.5
+			case "east":
+				b.MinX = 0.5
+			}
+		}
+		return b
+	}
+	switch p["shape"] {
+	case "outer_left":
+		return append(s, step(facing, left))
+	case "outer_right":
+		return append(s, step(facing, right))
+	case "inner_left":
+		return append(s, step(facing), step(opposite(facing), left))
+	case "inner_right":
+		return append(s, step(facing), step(opposite(facing), right))
+	}
+	return append(s, step(facing))
+}
+
+// fourWayShape is the shape of fences, walls and panes, with a post and the connected sides.
+// The post is in [post, 16-post], the sides are in [side, 16-side].
+func fourWayShape(p map[string]string, post, side, height float64) []BlockBox {
+	s := shape(box(post, 0, post, 16-post, height, 16-post))
+	connected := func(dir string) bool {
+		v, ok := p[dir]
+		return ok && v != "false" && v != "none"
+	}
+	if connected("north") {
+		s = append(s, box(side, 0, 0, 16-side, height, post))
+	}
+	if connected("south") {
+		s = append(s, box(side, 0, 16-post, 16-side, height, 16))
+	}
+	if connected("west") {
+		s = append(s, box(0, 0, side, post, height, 16-side))
+	}
+	if connected("east") {
+		s = append(s, box(16-post, 0, side, 16, height, 16-side))
+	}
+	return s
+}
+
+// doorShape is the plate of the door, moved to a side when opened.
+func doorShape(p map[string]string) []BlockBox {
+	facing := p["facing"]
+	if p["open"] == "true" {
+		if p["hinge"] == "right" {
+			facing = leftOf(facing)
+		} else {
+			facing = opposite(leftOf(facing))
+		}
+	}
+	// the closed door is on the back side
+	return facingPlate(facing, 3, false)
+}
+
+// facingPlate return a plate of the thickness, on the back side of a block facing the direction,
+// or on the front side if front is true.
+func facingPlate(facing string, thickness float64, front bool) []BlockBox {
+	if front {
+		facing = opposite(facing)
+	}
+	t := thickness
+	switch facing {
+	case "north":
+		return shape(box(0, 0, 16-t, 16, 16, 16))
+	case "south":
+		return shape(box(0, 0, 0, 16, 16, t))
+	case "west":
+		return shape(box(16-t, 0, 0, 16, 16, 16))
+	case "east":
+		return shape(box(0, 0, 0, t, 16, 16))
+	case "up":
+		return shape(box(0, 0, 0, 16, t, 16))
+	case "down":
+		return shape(box(0, 16-t, 0, 16, 16, 16))
+	}
+	return fullCube
+}
+
+// pistonArm is the arm of the piston head facing the direction
+func pistonArm(facing string) BlockBox {
+	switch facing {
+	case "north":
+		return box(6, 6, 4, 10, 10, 20)
+	case "south":
+		return box(6, 6, -4, 10, 10, 12)
+	case "west":
+		return box(4, 6, 6, 20, 10, 10)
+	case "east":
+		return box(-4, 6, 6, 12, 10, 10)
+	case "up":
+		return box(6, -4, 6, 10, 12, 10)
+	}
+	return box(6, 4, 6, 10, 20, 10)
+}
+
+func wallHeadShape(facing string) []BlockBox {
+	switch facing {
+	case "north":
+		return shape(box(4, 4, 8, 12, 12, 16))
+	case "south":
+		return shape(box(4, 4, 0, 12, 12, 8))
+	case "west":
+		return shape(box(8, 4, 4, 16, 12, 12))
+	}
+	return shape(box(0, 4, 4, 8, 12, 12))
+}
+
+func chorusShape(p map[string]string) []BlockBox {
+	s := shape(box(3, 3, 3, 13, 13, 13))
+	arms := map[string]BlockBox{
+		"north": box(3, 3, 0, 13, 13, 3), "south": box(3, 3, 13, 13, 13, 16),
+		"west": box(0, 3, 3, 3, 13, 13), "east": box(13, 3, 3, 16, 13, 13),
+		"down": box(3, 0, 3, 13, 3, 13), "up": box(3, 13, 3, 13, 16, 13),
+	}
+	for _, d := range []string{"north", "south", "west", "east", "down", "up"} {
+		if p[d] == "true" {
+			s = append(s, arms[d])
+		}
+	}
+	return s
+}
+
+func opposite(dir string) string {
+	switch dir {
+	case "north":
+		return "south"
+	case "south":
+		return "north"
+	case "west":
+		return "east"
+	case "east":
+		return "west"
+	case "up":
+		return "down"
+	case "down":
+		return "up"
+	}
+	return dir
+}
+
+// leftOf return the direction on the left when looking to the dir.
+func leftOf(dir string) string {
+	switch dir {
+	case "north":
+		return "west"
+	case "west":
+		return "south"
+	case "south":
+		return "east"
+	case "east":
+		return "north"
+	}
+	return dir
+}
@@ -2,11 +2,11 @@ package data
 
 import "encoding/json"
 
-var entityIDs map[string]struct {
-	ProtocolID int `json:"protocol_id"`
-}
+var entityIDs registryEntries
 
-// EntityNameByID store Item's ID and
+// EntityNameByID store the entity type names by their IDs.
+//
+// It is the table of 1.15.x, see Entities for other versions.
 var EntityNameByID []string
 
 func init() {
@@ -15,6 +15,10 @@ func init() {
 	for i, v := range entityIDs {
 		EntityNameByID[v.ProtocolID] = i
 	}
+	r := newRegistry(entityIDs)
+	for _, protocol := range []int{573, 575, 578} { // 1.15 - 1.15.2
+		entityTables[protocol] = r
+	}
 }
 
 // Generate with follow steps:
@@ -0,0 +1,193 @@
+// The generator reads the packet IDs in packets.txt
+// and generates the packet tables of package data.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	input  = "generator/packets.txt"
+	output = "packets_gen.go"
+)
+
+// the same orders as data.State and data.Direction
+var (
+	states     = []string{"handshake", "status", "login", "play"}
+	directions = []string{"serverbound", "clientbound"}
+)
+
+// version is a group of protocol versions sharing the same packet IDs.
+type version struct {
+	Protocols []int
+	Comment   string
+	// Names are the packet names by state, direction and ID.
+	Names [][][]string
+}
+
+func main() {
+	f, err := os.Open(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	versions, err := parse(bufio.NewScanner(f))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(versions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d versions are written to %s", len(versions), filepath.Clean(output))
+}
+
+// parse the packet IDs file:
+//
+//	protocol 573 575 578 // 1.15  the versions sharing the packet IDs below, with a comment
+//	[play clientbound]            a section of packets
+//	spawn_object                  the packet of the next ID, from 0x00 in each section
+//	spawn_object SpawnObject      a packet with its struct, see net/packets/generator
+//		EntityID VarInt           a field of the struct, ignored here
+//	type Item                     a struct used by the packets, ignored here
+func parse(s *bufio.Scanner) (versions []*version, err error) {
+	var (
+		current *version
+		section *[]string
+		seen    = make(map[int]bool)
+	)
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		comment := ""
+		if i := strings.Index(text, "//"); i >= 0 {
+			text, comment = text[:i], strings.TrimSpace(text[i+2:])
+		}
+		if strings.HasPrefix(text, "#") || strings.HasPrefix(text, "\t") || strings.TrimSpace(text) == "" {
+			continue // comments and the fields of structs
+		}
+		words := strings.Fields(text)
+		switch {
+		case words[0] == "protocol": // versions
+			if len(words) < 2 {
+				return nil, fmt.Errorf("line %d: no protocol version", line)
+			}
+			current = &version{Comment: comment, Names: make([][][]string, len(states))}
+			for i := range current.Names {
+				current.Names[i] = make([][]string, len(directions))
+			}
+			for _, w := range words[1:] {
+				p, err := strconv.Atoi(w)
+				if err != nil || seen[p] {
+					return nil, fmt.Errorf("line %d: bad protocol version %q", line, w)
+				}
+				seen[p] = true
+				current.Protocols = append(current.Protocols, p)
+			}
+			versions = append(versions, current)
+			section = nil
+
+		case text[0] == '[': // section
+			words = strings.Fields(strings.Trim(text, "[] "))
+			st, dir := -1, -1
+			if len(words) == 2 {
+				st, dir = indexOf(states, words[0]), indexOf(directions, words[1])
+			}
+			if current == nil || st < 0 || dir < 0 {
+				return nil, fmt.Errorf("line %d: bad section %q", line, text)
+			}
+			section = &current.Names[st][dir]
+			if len(*section) > 0 {
+				return nil, fmt.Errorf("line %d: duplicated section %q", line, text)
+			}
+
+		case words[0] == "type": // struct
+
+		case len(words) <= 2: // packet, may be followed by the struct name
+			if section == nil {
+				return nil, fmt.Errorf("line %d: packet out of section", line)
+			}
+			if indexOf(*section, words[0]) >= 0 {
+				return nil, fmt.Errorf("line %d: duplicated packet %s", line, words[0])
+			}
+			if len(*section) > 0xFF {
+				return nil, fmt.Errorf("line %d: too many packets", line)
+			}
+			*section = append(*section, words[0])
+
+		default:
+			return nil, fmt.Errorf("line %d: cannot parse %q", line, text)
+		}
+	}
+	return versions, s.Err()
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func generate(versions []*version) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by "go run ./generator"; DO NOT EDIT.
+
+package data
+
+// packetNames are the packet names by the IDs, in each protocol version, state and direction.
+var packetNames = map[int]*[len(stateNames)][len(directionNames)][]string{
+`)
+	for _, v := range versions {
+		for _, p := range v.Protocols {
+			fmt.Fprintf(&b, "%d: &%s,", p, varName(v))
+			if v.Comment != "" {
+				fmt.Fprintf(&b, " // %s", v.Comment)
+			}
+			b.WriteByte('\n')
+		}
+	}
+	b.WriteString("}\n")
+
+	for _, v := range versions {
+		fmt.Fprintf(&b, "\nvar %s = [len(stateNames)][len(directionNames)][]string{\n", varName(v))
+		for st, dirs := range v.Names {
+			fmt.Fprintf(&b, "{ // %s\n", states[st])
+			for dir, names := range dirs {
+				if len(names) == 0 {
+					fmt.Fprintf(&b, "nil, // %s\n", directions[dir])
+					continue
+				}
+				fmt.Fprintf(&b, "{ // %s\n", directions[dir])
+				for id, name := range names {
+					fmt.Fprintf(&b, "%q, // 0x%02X\n", name, id)
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// varName is named by the last protocol version of the group.
+func varName(v *version) string {
+	return fmt.Sprintf("packets%d", v.Protocols[len(v.Protocols)-1])
+}
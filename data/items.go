@@ -2,11 +2,11 @@ package data
 
 import "encoding/json"
 
-var itemIDs map[string]struct {
-	ProtocolID int `json:"protocol_id"`
-}
+var itemIDs registryEntries
 
-// ItemNameByID store Item's ID and
+// ItemNameByID store the item names by their IDs.
+//
+// It is the table of 1.15.x, see Items for other versions.
 var ItemNameByID []string
 
 func init() {
@@ -15,6 +15,10 @@ func init() {
 	for i, v := range itemIDs {
 		ItemNameByID[v.ProtocolID] = i
 	}
+	r := newRegistry(itemIDs)
+	for _, protocol := range []int{573, 575, 578} { // 1.15 - 1.15.2
+		itemTables[protocol] = r
+	}
 }
 
 // Generate with follow steps:
@@ -0,0 +1,49 @@
+package data
+
+import "strings"
+
+// The items which can't be stacked, or only stack to 16.
+// The other items stack to 64.
+var (
+	unstackableItems = map[string]bool{
+		"bow": true, "crossbow": true, "trident": true, "shield": true, "elytra": true,
+		"fishing_rod": true, "carrot_on_a_stick": true, "flint_and_steel": true, "shears": true,
+		"saddle": true, "cake": true, "totem_of_undying": true, "minecart": true, "debug_stick": true,
+		"potion": true, "splash_potion": true, "lingering_potion": true,
+		"enchanted_book": true, "writable_book": true, "written_book": true, "knowledge_book": true,
+		"mushroom_stew": true, "rabbit_stew": true, "beetroot_soup": true, "suspicious_stew": true,
+		"shulker_box": true, "warped_fungus_on_a_stick": true,
+	}
+	unstackableSuffixes = []string{
+		"_sword", "_shovel", "_pickaxe", "_axe", "_hoe",
+		"_helmet", "_chestplate", "_leggings", "_boots", "_horse_armor",
+		"_bucket", "_boat", "_minecart", "_shulker_box", "_bed",
+	}
+	stack16Items = map[string]bool{
+		"ender_pearl": true, "snowball": true, "egg": true, "bucket": true,
+		"armor_stand": true, "honey_bottle": true,
+	}
+	stack16Suffixes = []string{"_sign", "_banner"}
+)
+
+// MaxStackSize return the max count of the item in one slot, such as 1 for the tools.
+// The name is like "minecraft:diamond_pickaxe".
+func MaxStackSize(item string) int {
+	name := strings.TrimPrefix(item, "minecraft:")
+	switch {
+	case unstackableItems[name], strings.HasPrefix(name, "music_disc_"), hasSuffix(name, unstackableSuffixes):
+		return 1
+	case stack16Items[name], hasSuffix(name, stack16Suffixes):
+		return 16
+	}
+	return 64
+}
+
+func hasSuffix(name string, suffixes []string) bool {
+	for _, s := range suffixes {
+		if strings.HasSuffix(name, s) {
+			return true
+		}
+	}
+	return false
+}
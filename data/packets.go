@@ -0,0 +1,115 @@
+package data
+
+//go:generate go run ./generator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// State is the state of a Minecraft connection.
+// Each state has its own set of packets.
+type State int
+
+// All states of a Minecraft connection
+const (
+	Handshaking State = iota
+	Status
+	Login
+	Play
+)
+
+var stateNames = [...]string{"handshake", "status", "login", "play"}
+
+func (s State) String() string {
+	if s < 0 || int(s) >= len(stateNames) {
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+	return stateNames[s]
+}
+
+// Direction is the direction which a packet is sent to.
+type Direction int
+
+// Both directions of packets
+const (
+	Serverbound Direction = iota
+	Clientbound
+)
+
+var directionNames = [...]string{"serverbound", "clientbound"}
+
+func (d Direction) String() string {
+	if d < 0 || int(d) >= len(directionNames) {
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+	return directionNames[d]
+}
+
+// ErrIllegalPacket is returned when a packet ID is not allowed in current state.
+var ErrIllegalPacket = errors.New("illegal packet ID")
+
+// PacketTable maps the packet IDs of one protocol version,
+// connection state and direction to the names of packets.
+//
+// The names are the same in all protocol versions, such as "keep_alive" or "chunk_data".
+// The methods of a nil PacketTable report every packet as unknown.
+type PacketTable struct {
+	names map[byte]string
+	ids   map[string]byte
+}
+
+// Name return the name of the packet ID. ok is false if the ID is illegal.
+func (t *PacketTable) Name(id byte) (name string, ok bool) {
+	if t == nil {
+		return "", false
+	}
+	name, ok = t.names[id]
+	return
+}
+
+// ID return the packet ID of the name. ok is false if the packet doesn't exist.
+func (t *PacketTable) ID(name string) (id byte, ok bool) {
+	if t == nil {
+		return 0, false
+	}
+	id, ok = t.ids[name]
+	return
+}
+
+var packetTables = make(map[int]*[len(stateNames)][len(directionNames)]PacketTable)
+
+// Packets return the PacketTable of the protocol version, state and direction.
+// It returns nil if the protocol version is not supported.
+func Packets(protocol int, state State, dir Direction) *PacketTable {
+	tables, ok := packetTables[protocol]
+	if !ok || state < 0 || int(state) >= len(stateNames) || dir < 0 || int(dir) >= len(directionNames) {
+		return nil
+	}
+	return &tables[state][dir]
+}
+
+func init() {
+	loaded := make(map[*[len(stateNames)][len(directionNames)][]string]*[len(stateNames)][len(directionNames)]PacketTable)
+	for protocol, names := range packetNames {
+		if tables, ok := loaded[names]; ok { // shared by versions with same IDs
+			packetTables[protocol] = tables
+			continue
+		}
+
+		tables := new([len(stateNames)][len(directionNames)]PacketTable)
+		for s := range names {
+			for d := range names[s] {
+				t := &tables[s][d]
+				t.names = make(map[byte]string, len(names[s][d]))
+				t.ids = make(map[string]byte, len(names[s][d]))
+				for id, name := range names[s][d] {
+					t.names[byte(id)] = name
+					t.ids[name] = byte(id)
+				}
+			}
+		}
+		packetTables[protocol] = tables
+		loaded[names] = tables
+	}
+}
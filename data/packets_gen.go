@@ -0,0 +1,547 @@
+// Code generated by "go run ./generator"; DO NOT EDIT.
+
+package data
+
+// packetNames are the packet names by the IDs, in each protocol version, state and direction.
+var packetNames = map[int]*[len(stateNames)][len(directionNames)][]string{
+	498: &packets498, // 1.14.4
+	573: &packets578, // 1.15 - 1.15.2
+	575: &packets578, // 1.15 - 1.15.2
+	578: &packets578, // 1.15 - 1.15.2
+	735: &packets736, // 1.16 - 1.16.1
+	736: &packets736, // 1.16 - 1.16.1
+}
+
+var packets498 = [len(stateNames)][len(directionNames)][]string{
+	{ // handshake
+		{ // serverbound
+			"handshake", // 0x00
+		},
+		nil, // clientbound
+	},
+	{ // status
+		{ // serverbound
+			"request", // 0x00
+			"ping",    // 0x01
+		},
+		{ // clientbound
+			"response", // 0x00
+			"pong",     // 0x01
+		},
+	},
+	{ // login
+		{ // serverbound
+			"login_start",           // 0x00
+			"encryption_response",   // 0x01
+			"login_plugin_response", // 0x02
+		},
+		{ // clientbound
+			"disconnect",           // 0x00
+			"encryption_request",   // 0x01
+			"login_success",        // 0x02
+			"set_compression",      // 0x03
+			"login_plugin_request", // 0x04
+		},
+	},
+	{ // play
+		{ // serverbound
+			"teleport_confirm",              // 0x00
+			"query_block_nbt",               // 0x01
+			"set_difficulty",                // 0x02
+			"chat_message",                  // 0x03
+			"client_status",                 // 0x04
+			"client_settings",               // 0x05
+			"tab_complete",                  // 0x06
+			"confirm_transaction",           // 0x07
+			"click_window_button",           // 0x08
+			"click_window",                  // 0x09
+			"close_window",                  // 0x0A
+			"plugin_message",                // 0x0B
+			"edit_book",                     // 0x0C
+			"query_entity_nbt",              // 0x0D
+			"use_entity",                    // 0x0E
+			"keep_alive",                    // 0x0F
+			"lock_difficulty",               // 0x10
+			"player_position",               // 0x11
+			"player_position_and_look",      // 0x12
+			"player_look",                   // 0x13
+			"player",                        // 0x14
+			"vehicle_move",                  // 0x15
+			"steer_boat",                    // 0x16
+			"pick_item",                     // 0x17
+			"craft_recipe_request",          // 0x18
+			"player_abilities",              // 0x19
+			"player_digging",                // 0x1A
+			"entity_action",                 // 0x1B
+			"steer_vehicle",                 // 0x1C
+			"recipe_book_data",              // 0x1D
+			"name_item",                     // 0x1E
+			"resource_pack_status",          // 0x1F
+			"advancement_tab",               // 0x20
+			"select_trade",                  // 0x21
+			"set_beacon_effect",             // 0x22
+			"held_item_change",              // 0x23
+			"update_command_block",          // 0x24
+			"update_command_block_minecart", // 0x25
+			"creative_inventory_action",     // 0x26
+			"update_jigsaw_block",           // 0x27
+			"update_structure_block",        // 0x28
+			"update_sign",                   // 0x29
+			"animation",                     // 0x2A
+			"spectate",                      // 0x2B
+			"player_block_placement",        // 0x2C
+			"use_item",                      // 0x2D
+		},
+		{ // clientbound
+			"spawn_object",                  // 0x00
+			"spawn_experience_orb",          // 0x01
+			"spawn_global_entity",           // 0x02
+			"spawn_mob",                     // 0x03
+			"spawn_painting",                // 0x04
+			"spawn_player",                  // 0x05
+			"animation",                     // 0x06
+			"statistics",                    // 0x07
+			"block_break_animation",         // 0x08
+			"update_block_entity",           // 0x09
+			"block_action",                  // 0x0A
+			"block_change",                  // 0x0B
+			"boss_bar",                      // 0x0C
+			"server_difficulty",             // 0x0D
+			"chat_message",                  // 0x0E
+			"multi_block_change",            // 0x0F
+			"tab_complete",                  // 0x10
+			"declare_commands",              // 0x11
+			"confirm_transaction",           // 0x12
+			"close_window",                  // 0x13
+			"window_items",                  // 0x14
+			"window_property",               // 0x15
+			"set_slot",                      // 0x16
+			"set_cooldown",                  // 0x17
+			"plugin_message",                // 0x18
+			"named_sound_effect",            // 0x19
+			"disconnect",                    // 0x1A
+			"entity_status",                 // 0x1B
+			"explosion",                     // 0x1C
+			"unload_chunk",                  // 0x1D
+			"change_game_state",             // 0x1E
+			"open_horse_window",             // 0x1F
+			"keep_alive",                    // 0x20
+			"chunk_data",                    // 0x21
+			"effect",                        // 0x22
+			"particle",                      // 0x23
+			"update_light",                  // 0x24
+			"join_game",                     // 0x25
+			"map_data",                      // 0x26
+			"trade_list",                    // 0x27
+			"entity_relative_move",          // 0x28
+			"entity_look_and_relative_move", // 0x29
+			"entity_look",                   // 0x2A
+			"entity",                        // 0x2B
+			"vehicle_move",                  // 0x2C
+			"open_book",                     // 0x2D
+			"open_window",                   // 0x2E
+			"open_sign_editor",              // 0x2F
+			"craft_recipe_response",         // 0x30
+			"player_abilities",              // 0x31
+			"combat_event",                  // 0x32
+			"player_info",                   // 0x33
+			"face_player",                   // 0x34
+			"player_position_and_look",      // 0x35
+			"unlock_recipes",                // 0x36
+			"destroy_entities",              // 0x37
+			"remove_entity_effect",          // 0x38
+			"resource_pack_send",            // 0x39
+			"respawn",                       // 0x3A
+			"entity_head_look",              // 0x3B
+			"select_advancement_tab",        // 0x3C
+			"world_border",                  // 0x3D
+			"camera",                        // 0x3E
+			"held_item_change",              // 0x3F
+			"update_view_position",          // 0x40
+			"update_view_distance",          // 0x41
+			"display_scoreboard",            // 0x42
+			"entity_metadata",               // 0x43
+			"attach_entity",                 // 0x44
+			"entity_velocity",               // 0x45
+			"entity_equipment",              // 0x46
+			"set_experience",                // 0x47
+			"update_health",                 // 0x48
+			"scoreboard_objective",          // 0x49
+			"set_passengers",                // 0x4A
+			"teams",                         // 0x4B
+			"update_score",                  // 0x4C
+			"spawn_position",                // 0x4D
+			"time_update",                   // 0x4E
+			"title",                         // 0x4F
+			"entity_sound_effect",           // 0x50
+			"sound_effect",                  // 0x51
+			"stop_sound",                    // 0x52
+			"player_list_header_and_footer", // 0x53
+			"nbt_query_response",            // 0x54
+			"collect_item",                  // 0x55
+			"entity_teleport",               // 0x56
+			"advancements",                  // 0x57
+			"entity_properties",             // 0x58
+			"entity_effect",                 // 0x59
+			"declare_recipes",               // 0x5A
+			"tags",                          // 0x5B
+			"acknowledge_player_digging",    // 0x5C
+		},
+	},
+}
+
+var packets578 = [len(stateNames)][len(directionNames)][]string{
+	{ // handshake
+		{ // serverbound
+			"handshake", // 0x00
+		},
+		nil, // clientbound
+	},
+	{ // status
+		{ // serverbound
+			"request", // 0x00
+			"ping",    // 0x01
+		},
+		{ // clientbound
+			"response", // 0x00
+			"pong",     // 0x01
+		},
+	},
+	{ // login
+		{ // serverbound
+			"login_start",           // 0x00
+			"encryption_response",   // 0x01
+			"login_plugin_response", // 0x02
+		},
+		{ // clientbound
+			"disconnect",           // 0x00
+			"encryption_request",   // 0x01
+			"login_success",        // 0x02
+			"set_compression",      // 0x03
+			"login_plugin_request", // 0x04
+		},
+	},
+	{ // play
+		{ // serverbound
+			"teleport_confirm",              // 0x00
+			"query_block_nbt",               // 0x01
+			"set_difficulty",                // 0x02
+			"chat_message",                  // 0x03
+			"client_status",                 // 0x04
+			"client_settings",               // 0x05
+			"tab_complete",                  // 0x06
+			"confirm_transaction",           // 0x07
+			"click_window_button",           // 0x08
+			"click_window",                  // 0x09
+			"close_window",                  // 0x0A
+			"plugin_message",                // 0x0B
+			"edit_book",                     // 0x0C
+			"query_entity_nbt",              // 0x0D
+			"use_entity",                    // 0x0E
+			"keep_alive",                    // 0x0F
+			"lock_difficulty",               // 0x10
+			"player_position",               // 0x11
+			"player_position_and_look",      // 0x12
+			"player_look",                   // 0x13
+			"player",                        // 0x14
+			"vehicle_move",                  // 0x15
+			"steer_boat",                    // 0x16
+			"pick_item",                     // 0x17
+			"craft_recipe_request",          // 0x18
+			"player_abilities",              // 0x19
+			"player_digging",                // 0x1A
+			"entity_action",                 // 0x1B
+			"steer_vehicle",                 // 0x1C
+			"recipe_book_data",              // 0x1D
+			"name_item",                     // 0x1E
+			"resource_pack_status",          // 0x1F
+			"advancement_tab",               // 0x20
+			"select_trade",                  // 0x21
+			"set_beacon_effect",             // 0x22
+			"held_item_change",              // 0x23
+			"update_command_block",          // 0x24
+			"update_command_block_minecart", // 0x25
+			"creative_inventory_action",     // 0x26
+			"update_jigsaw_block",           // 0x27
+			"update_structure_block",        // 0x28
+			"update_sign",                   // 0x29
+			"animation",                     // 0x2A
+			"spectate",                      // 0x2B
+			"player_block_placement",        // 0x2C
+			"use_item",                      // 0x2D
+		},
+		{ // clientbound
+			"spawn_object",                  // 0x00
+			"spawn_experience_orb",          // 0x01
+			"spawn_global_entity",           // 0x02
+			"spawn_mob",                     // 0x03
+			"spawn_painting",                // 0x04
+			"spawn_player",                  // 0x05
+			"animation",                     // 0x06
+			"statistics",                    // 0x07
+			"acknowledge_player_digging",    // 0x08
+			"block_break_animation",         // 0x09
+			"update_block_entity",           // 0x0A
+			"block_action",                  // 0x0B
+			"block_change",                  // 0x0C
+			"boss_bar",                      // 0x0D
+			"server_difficulty",             // 0x0E
+			"chat_message",                  // 0x0F
+			"multi_block_change",            // 0x10
+			"tab_complete",                  // 0x11
+			"declare_commands",              // 0x12
+			"confirm_transaction",           // 0x13
+			"close_window",                  // 0x14
+			"window_items",                  // 0x15
+			"window_property",               // 0x16
+			"set_slot",                      // 0x17
+			"set_cooldown",                  // 0x18
+			"plugin_message",                // 0x19
+			"named_sound_effect",            // 0x1A
+			"disconnect",                    // 0x1B
+			"entity_status",                 // 0x1C
+			"explosion",                     // 0x1D
+			"unload_chunk",                  // 0x1E
+			"change_game_state",             // 0x1F
+			"open_horse_window",             // 0x20
+			"keep_alive",                    // 0x21
+			"chunk_data",                    // 0x22
+			"effect",                        // 0x23
+			"particle",                      // 0x24
+			"update_light",                  // 0x25
+			"join_game",                     // 0x26
+			"map_data",                      // 0x27
+			"trade_list",                    // 0x28
+			"entity_relative_move",          // 0x29
+			"entity_look_and_relative_move", // 0x2A
+			"entity_look",                   // 0x2B
+			"entity",                        // 0x2C
+			"vehicle_move",                  // 0x2D
+			"open_book",                     // 0x2E
+			"open_window",                   // 0x2F
+			"open_sign_editor",              // 0x30
+			"craft_recipe_response",         // 0x31
+			"player_abilities",              // 0x32
+			"combat_event",                  // 0x33
+			"player_info",                   // 0x34
+			"face_player",                   // 0x35
+			"player_position_and_look",      // 0x36
+			"unlock_recipes",                // 0x37
+			"destroy_entities",              // 0x38
+			"remove_entity_effect",          // 0x39
+			"resource_pack_send",            // 0x3A
+			"respawn",                       // 0x3B
+			"entity_head_look",              // 0x3C
+			"select_advancement_tab",        // 0x3D
+			"world_border",                  // 0x3E
+			"camera",                        // 0x3F
+			"held_item_change",              // 0x40
+			"update_view_position",          // 0x41
+			"update_view_distance",          // 0x42
+			"display_scoreboard",            // 0x43
+			"entity_metadata",               // 0x44
+			"attach_entity",                 // 0x45
+			"entity_velocity",               // 0x46
+			"entity_equipment",              // 0x47
+			"set_experience",                // 0x48
+			"update_health",                 // 0x49
+			"scoreboard_objective",          // 0x4A
+			"set_passengers",                // 0x4B
+			"teams",                         // 0x4C
+			"update_score",                  // 0x4D
+			"spawn_position",                // 0x4E
+			"time_update",                   // 0x4F
+			"title",                         // 0x50
+			"entity_sound_effect",           // 0x51
+			"sound_effect",                  // 0x52
+			"stop_sound",                    // 0x53
+			"player_list_header_and_footer", // 0x54
+			"nbt_query_response",            // 0x55
+			"collect_item",                  // 0x56
+			"entity_teleport",               // 0x57
+			"advancements",                  // 0x58
+			"entity_properties",             // 0x59
+			"entity_effect",                 // 0x5A
+			"declare_recipes",               // 0x5B
+			"tags",                          // 0x5C
+		},
+	},
+}
+
+var packets736 = [len(stateNames)][len(directionNames)][]string{
+	{ // handshake
+		{ // serverbound
+			"handshake", // 0x00
+		},
+		nil, // clientbound
+	},
+	{ // status
+		{ // serverbound
+			"request", // 0x00
+			"ping",    // 0x01
+		},
+		{ // clientbound
+			"response", // 0x00
+			"pong",     // 0x01
+		},
+	},
+	{ // login
+		{ // serverbound
+			"login_start",           // 0x00
+			"encryption_response",   // 0x01
+			"login_plugin_response", // 0x02
+		},
+		{ // clientbound
+			"disconnect",           // 0x00
+			"encryption_request",   // 0x01
+			"login_success",        // 0x02
+			"set_compression",      // 0x03
+			"login_plugin_request", // 0x04
+		},
+	},
+	{ // play
+		{ // serverbound
+			"teleport_confirm",              // 0x00
+			"query_block_nbt",               // 0x01
+			"set_difficulty",                // 0x02
+			"chat_message",                  // 0x03
+			"client_status",                 // 0x04
+			"client_settings",               // 0x05
+			"tab_complete",                  // 0x06
+			"confirm_transaction",           // 0x07
+			"click_window_button",           // 0x08
+			"click_window",                  // 0x09
+			"close_window",                  // 0x0A
+			"plugin_message",                // 0x0B
+			"edit_book",                     // 0x0C
+			"query_entity_nbt",              // 0x0D
+			"use_entity",                    // 0x0E
+			"generate_structure",            // 0x0F
+			"keep_alive",                    // 0x10
+			"lock_difficulty",               // 0x11
+			"player_position",               // 0x12
+			"player_position_and_look",      // 0x13
+			"player_look",                   // 0x14
+			"player",                        // 0x15
+			"vehicle_move",                  // 0x16
+			"steer_boat",                    // 0x17
+			"pick_item",                     // 0x18
+			"craft_recipe_request",          // 0x19
+			"player_abilities",              // 0x1A
+			"player_digging",                // 0x1B
+			"entity_action",                 // 0x1C
+			"steer_vehicle",                 // 0x1D
+			"recipe_book_data",              // 0x1E
+			"name_item",                     // 0x1F
+			"resource_pack_status",          // 0x20
+			"advancement_tab",               // 0x21
+			"select_trade",                  // 0x22
+			"set_beacon_effect",             // 0x23
+			"held_item_change",              // 0x24
+			"update_command_block",          // 0x25
+			"update_command_block_minecart", // 0x26
+			"creative_inventory_action",     // 0x27
+			"update_jigsaw_block",           // 0x28
+			"update_structure_block",        // 0x29
+			"update_sign",                   // 0x2A
+			"animation",                     // 0x2B
+			"spectate",                      // 0x2C
+			"player_block_placement",        // 0x2D
+			"use_item",                      // 0x2E
+		},
+		{ // clientbound
+			"spawn_object",                  // 0x00
+			"spawn_experience_orb",          // 0x01
+			"spawn_mob",                     // 0x02
+			"spawn_painting",                // 0x03
+			"spawn_player",                  // 0x04
+			"animation",                     // 0x05
+			"statistics",                    // 0x06
+			"acknowledge_player_digging",    // 0x07
+			"block_break_animation",         // 0x08
+			"update_block_entity",           // 0x09
+			"block_action",                  // 0x0A
+			"block_change",                  // 0x0B
+			"boss_bar",                      // 0x0C
+			"server_difficulty",             // 0x0D
+			"chat_message",                  // 0x0E
+			"multi_block_change",            // 0x0F
+			"tab_complete",                  // 0x10
+			"declare_commands",              // 0x11
+			"confirm_transaction",           // 0x12
+			"close_window",                  // 0x13
+			"window_items",                  // 0x14
+			"window_property",               // 0x15
+			"set_slot",                      // 0x16
+			"set_cooldown",                  // 0x17
+			"plugin_message",                // 0x18
+			"named_sound_effect",            // 0x19
+			"disconnect",                    // 0x1A
+			"entity_status",                 // 0x1B
+			"explosion",                     // 0x1C
+			"unload_chunk",                  // 0x1D
+			"change_game_state",             // 0x1E
+			"open_horse_window",             // 0x1F
+			"keep_alive",                    // 0x20
+			"chunk_data",                    // 0x21
+			"effect",                        // 0x22
+			"particle",                      // 0x23
+			"update_light",                  // 0x24
+			"join_game",                     // 0x25
+			"map_data",                      // 0x26
+			"trade_list",                    // 0x27
+			"entity_relative_move",          // 0x28
+			"entity_look_and_relative_move", // 0x29
+			"entity_look",                   // 0x2A
+			"entity",                        // 0x2B
+			"vehicle_move",                  // 0x2C
+			"open_book",                     // 0x2D
+			"open_window",                   // 0x2E
+			"open_sign_editor",              // 0x2F
+			"craft_recipe_response",         // 0x30
+			"player_abilities",              // 0x31
+			"combat_event",                  // 0x32
+			"player_info",                   // 0x33
+			"face_player",                   // 0x34
+			"player_position_and_look",      // 0x35
+			"unlock_recipes",                // 0x36
+			"destroy_entities",              // 0x37
+			"remove_entity_effect",          // 0x38
+			"resource_pack_send",            // 0x39
+			"respawn",                       // 0x3A
+			"entity_head_look",              // 0x3B
+			"select_advancement_tab",        // 0x3C
+			"world_border",                  // 0x3D
+			"camera",                        // 0x3E
+			"held_item_change",              // 0x3F
+			"update_view_position",          // 0x40
+			"update_view_distance",          // 0x41
+			"spawn_position",                // 0x42
+			"display_scoreboard",            // 0x43
+			"entity_metadata",               // 0x44
+			"attach_entity",                 // 0x45
+			"entity_velocity",               // 0x46
+			"entity_equipment",              // 0x47
+			"set_experience",                // 0x48
+			"update_health",                 // 0x49
+			"scoreboard_objective",          // 0x4A
+			"set_passengers",                // 0x4B
+			"teams",                         // 0x4C
+			"update_score",                  // 0x4D
+			"time_update",                   // 0x4E
+			"title",                         // 0x4F
+			"entity_sound_effect",           // 0x50
+			"sound_effect",                  // 0x51
+			"stop_sound",                    // 0x52
+			"player_list_header_and_footer", // 0x53
+			"nbt_query_response",            // 0x54
+			"collect_item",                  // 0x55
+			"entity_teleport",               // 0x56
+			"advancements",                  // 0x57
+			"entity_properties",             // 0x58
+			"entity_effect",                 // 0x59
+			"declare_recipes",               // 0x5A
+			"tags",                          // 0x5B
+		},
+	},
+}
@@ -0,0 +1,38 @@
+package data
+
+import "testing"
+
+func TestPackets(t *testing.T) {
+	for _, v := range []struct {
+		state State
+		dir   Direction
+		name  string
+		id    byte
+	}{
+		{Handshaking, Serverbound, "handshake", 0x00},
+		{Login, Clientbound, "set_compression", 0x03},
+		{Play, Clientbound, "keep_alive", KeepAliveClientbound},
+		{Play, Clientbound, "chunk_data", ChunkData},
+		{Play, Clientbound, "tags", Tags},
+		{Play, Serverbound, "keep_alive", KeepAliveServerbound},
+		{Play, Serverbound, "use_item", UseItem},
+	} {
+		table := Packets(578, v.state, v.dir)
+		if id, ok := table.ID(v.name); !ok || id != v.id {
+			t.Errorf("%v %v %q should be 0x%02X, get 0x%02X", v.state, v.dir, v.name, v.id, id)
+		}
+		if name, ok := table.Name(v.id); !ok || name != v.name {
+			t.Errorf("%v %v 0x%02X should be %q, get %q", v.state, v.dir, v.id, v.name, name)
+		}
+	}
+
+	if _, ok := Packets(578, Login, Clientbound).Name(0x05); ok {
+		t.Error("0x05 should be illegal in login state")
+	}
+	if Packets(1, Play, Clientbound) != nil {
+		t.Error("unknown protocol should return nil table")
+	}
+	if _, ok := Packets(1, Play, Clientbound).Name(0x00); ok {
+		t.Error("nil table should report every packet as unknown")
+	}
+}
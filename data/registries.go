@@ -0,0 +1,103 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Registry maps the names and the protocol IDs of a registry,
+// like the items or the entity types of a protocol version.
+// The methods of a nil Registry report every name and ID as unknown.
+type Registry struct {
+	// NameByID stores the name of each ID, empty if the ID is unused.
+	NameByID []string
+	// IDByName stores the ID of each name.
+	IDByName map[string]int
+}
+
+// Name return the name of the ID, empty if the ID is unknown.
+func (r *Registry) Name(id int) string {
+	if r == nil || id < 0 || id >= len(r.NameByID) {
+		return ""
+	}
+	return r.NameByID[id]
+}
+
+// ID return the ID of the name, such as "minecraft:stone". ok is false if the name is unknown.
+func (r *Registry) ID(name string) (id int, ok bool) {
+	if r == nil {
+		return 0, false
+	}
+	id, ok = r.IDByName[name]
+	return
+}
+
+type registryEntries map[string]struct {
+	ProtocolID int `json:"protocol_id"`
+}
+
+func newRegistry(entries registryEntries) *Registry {
+	r := &Registry{IDByName: make(map[string]int, len(entries))}
+	for name, v := range entries {
+		if v.ProtocolID >= len(r.NameByID) {
+			r.NameByID = append(r.NameByID, make([]string, v.ProtocolID+1-len(r.NameByID))...)
+		}
+		r.NameByID[v.ProtocolID] = name
+		r.IDByName[name] = v.ProtocolID
+	}
+	return r
+}
+
+var (
+	itemTables   = make(map[int]*Registry)
+	entityTables = make(map[int]*Registry)
+	registryLock sync.RWMutex
+)
+
+// LoadRegistries read the registries report of another Minecraft version,
+// and use its items and entity types for the protocol version.
+// Only the tables of 1.15.x are built in.
+//
+// The report is generated by vanilla server:
+// java -cp minecraft_server.jar net.minecraft.data.Main --reports
+// {reports/registries.json}
+func LoadRegistries(protocol int, r io.Reader) error {
+	var report map[string]struct {
+		Entries registryEntries `json:"entries"`
+	}
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return fmt.Errorf("data: read registries fail: %v", err)
+	}
+	items, ok := report["minecraft:item"]
+	if !ok {
+		return fmt.Errorf("data: read registries fail: no minecraft:item")
+	}
+	entities, ok := report["minecraft:entity_type"]
+	if !ok {
+		return fmt.Errorf("data: read registries fail: no minecraft:entity_type")
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	itemTables[protocol] = newRegistry(items.Entries)
+	entityTables[protocol] = newRegistry(entities.Entries)
+	return nil
+}
+
+// Items return the item registry of the protocol version.
+// It returns nil if the registry of this version is not loaded.
+func Items(protocol int) *Registry {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return itemTables[protocol]
+}
+
+// Entities return the entity type registry of the protocol version.
+// It returns nil if the registry of this version is not loaded.
+func Entities(protocol int) *Registry {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return entityTables[protocol]
+}
@@ -0,0 +1,32 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadRegistries(t *testing.T) {
+	if id, ok := Items(578).ID("minecraft:stone"); !ok || id != 1 {
+		t.Errorf("wrong stone ID: %d", id)
+	}
+	if name := Entities(578).Name(95); name != "minecraft:zombie" {
+		t.Errorf("wrong entity 95: %q", name)
+	}
+	if Items(1) != nil || Items(1).Name(1) != "" {
+		t.Error("unknown protocol should return nil registry")
+	}
+
+	report := `{
+  "minecraft:item": {"entries": {"minecraft:air": {"protocol_id": 0}, "minecraft:stone": {"protocol_id": 1}}},
+  "minecraft:entity_type": {"entries": {"minecraft:piglin": {"protocol_id": 60}}}
+}`
+	if err := LoadRegistries(1, strings.NewReader(report)); err != nil {
+		t.Fatal(err)
+	}
+	if id, ok := Entities(1).ID("minecraft:piglin"); !ok || id != 60 || Entities(1).Name(59) != "" {
+		t.Errorf("wrong piglin ID: %d", id)
+	}
+	if err := LoadRegistries(2, strings.NewReader(`{}`)); err == nil {
+		t.Error("loaded a report without items")
+	}
+}
@@ -0,0 +1,96 @@
+package data
+
+import "strings"
+
+// toolMaterials are the levels and the dig speeds of the tool materials.
+var toolMaterials = map[string]struct {
+	level HarvestLevel
+	speed float64
+}{
+	"wooden":    {LevelWood, 2},
+	"stone":     {LevelStone, 4},
+	"iron":      {LevelIron, 6},
+	"diamond":   {LevelDiamond, 8},
+	"netherite": {LevelDiamond, 9},
+	"golden":    {LevelWood, 12},
+}
+
+var toolSuffixes = map[string]Tool{
+	"_pickaxe": ToolPickaxe,
+	"_axe":     ToolAxe,
+	"_shovel":  ToolShovel,
+	"_hoe":     ToolHoe,
+	"_sword":   ToolSword,
+}
+
+// swordBlocks are dug faster by the swords, which are the plants,
+// the living coral blocks and the gourds of vanilla. The leaves are also dug faster.
+var swordBlocks = map[string]bool{
+	"oak_sapling": true, "spruce_sapling": true, "birch_sapling": true,
+	"jungle_sapling": true, "acacia_sapling": true, "dark_oak_sapling": true,
+	"grass": true, "fern": true, "dead_bush": true, "tall_grass": true, "large_fern": true, "vine": true,
+	"dandelion": true, "poppy": true, "blue_orchid": true, "allium": true, "azure_bluet": true,
+	"red_tulip": true, "orange_tulip": true, "white_tulip": true, "pink_tulip": true,
+	"oxeye_daisy": true, "cornflower": true, "wither_rose": true, "lily_of_the_valley": true,
+	"sunflower": true, "lilac": true, "rose_bush": true, "peony": true,
+	"brown_mushroom": true, "red_mushroom": true, "lily_pad": true, "sugar_cane": true,
+	"wheat": true, "carrots": true, "potatoes": true, "beetroots": true, "nether_wart": true,
+	"pumpkin_stem": true, "melon_stem": true, "attached_pumpkin_stem": true, "attached_melon_stem": true,
+	"cocoa": true, "sweet_berry_bush": true, "chorus_plant": true, "chorus_flower": true,
+
+	"tube_coral_block": true, "brain_coral_block": true, "bubble_coral_block": true,
+	"fire_coral_block": true, "horn_coral_block": true,
+
+	"pumpkin": true, "carved_pumpkin": true, "jack_o_lantern": true, "melon": true,
+
+	"crimson_fungus": true, "warped_fungus": true, // 1.16
+	"weeping_vines": true, "weeping_vines_plant": true, // 1.16
+	"twisting_vines": true, "twisting_vines_plant": true, // 1.16
+}
+
+// ItemTool return the kind, the harvest level and the dig speed of the tool,
+// ToolNone if the item isn't a tool. The name is like "minecraft:diamond_pickaxe".
+func ItemTool(item string) (tool Tool, level HarvestLevel, speed float64) {
+	name := strings.TrimPrefix(item, "minecraft:")
+	if name == "shears" {
+		return ToolShears, LevelWood, 2
+	}
+	i := strings.LastIndexByte(name, '_')
+	if i < 0 {
+		return ToolNone, LevelWood, 1
+	}
+	tool, ok := toolSuffixes[name[i:]]
+	m, ok2 := toolMaterials[name[:i]]
+	if !ok || !ok2 {
+		return ToolNone, LevelWood, 1
+	}
+	return tool, m.level, m.speed
+}
+
+// DigSpeed return how fast the item digs the block, 1 for the hand or an unsuitable item,
+// and whether the block drops anything when it's broken by the item.
+// The Efficiency enchantment and the status effects aren't counted.
+func (s *BlockState) DigSpeed(item string) (speed float64, harvest bool) {
+	tool, level, speed := ItemTool(item)
+	name := strings.TrimPrefix(s.Name, "minecraft:")
+	switch tool {
+	case ToolSword:
+		if name == "cobweb" {
+			return 15, true
+		}
+		if swordBlocks[name] || strings.HasSuffix(name, "_leaves") {
+			return 1.5, !s.RequiresTool
+		}
+	case ToolShears:
+		switch {
+		case name == "cobweb", strings.HasSuffix(name, "_leaves"):
+			return 15, true
+		case strings.HasSuffix(name, "_wool"):
+			speed = 5
+		}
+	}
+	if tool == ToolNone || tool != s.Tool {
+		return 1, !s.RequiresTool
+	}
+	return speed, !s.RequiresTool || level >= s.HarvestLevel
+}
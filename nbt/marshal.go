@@ -5,6 +5,8 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"sort"
+	"strconv"
 )
 
 func Marshal(w io.Writer, v interface{}) error {
@@ -25,130 +27,233 @@ func (e *Encoder) Encode(v interface{}) error {
 }
 
 func (e *Encoder) marshal(val reflect.Value, tagName string) error {
+	tagType, err := getTagType(val)
+	if err != nil {
+		return err
+	}
+	if err := e.writeTag(tagType, tagName); err != nil {
+		return err
+	}
+	return e.writeValue(val, tagType)
+}
+
+// getTagType return the tag type of the value.
+// Nil pointers and interfaces are TagEnd, which are omitted in compounds.
+func getTagType(val reflect.Value) (byte, error) {
 	switch vk := val.Kind(); vk {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return TagEnd, nil
+		}
+		return getTagType(val.Elem())
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		return TagByte, nil
+	case reflect.Int16, reflect.Uint16:
+		return TagShort, nil
+	case reflect.Int32, reflect.Uint32, reflect.Int:
+		return TagInt, nil
+	case reflect.Int64, reflect.Uint64:
+		return TagLong, nil
+	case reflect.Float32:
+		return TagFloat, nil
+	case reflect.Float64:
+		return TagDouble, nil
+	case reflect.String:
+		return TagString, nil
+	case reflect.Struct, reflect.Map:
+		return TagCompound, nil
+	case reflect.Array, reflect.Slice:
+		switch val.Type().Elem().Kind() {
+		case reflect.Uint8:
+			return TagByteArray, nil
+		case reflect.Int32:
+			return TagIntArray, nil
+		case reflect.Int64:
+			return TagLongArray, nil
+		}
+		return TagList, nil
+	default:
+		return 0, errors.New("unknown type " + vk.String())
+	}
+}
+
+func (e *Encoder) writeValue(val reflect.Value, tagType byte) error {
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.writeValue(val.Elem(), tagType)
+	}
+
+	switch tagType {
 	default:
-		return errors.New("unknown type " + vk.String())
+		return errors.New("unknown tag type " + strconv.Itoa(int(tagType)))
 
-	case reflect.Uint8:
-		if err := e.writeTag(TagByte, tagName); err != nil {
-			return err
+	case TagByte:
+		var b byte
+		if val.Kind() == reflect.Bool {
+			if val.Bool() {
+				b = 1
+			}
+		} else {
+			b = byte(intValue(val))
 		}
-		_, err := e.w.Write([]byte{byte(val.Uint())})
+		_, err := e.w.Write([]byte{b})
 		return err
 
-	case reflect.Int16, reflect.Uint16:
-		if err := e.writeTag(TagShort, tagName); err != nil {
-			return err
-		}
-		return e.writeInt16(int16(val.Int()))
+	case TagShort:
+		return e.writeInt16(int16(intValue(val)))
 
-	case reflect.Int32, reflect.Uint32:
-		if err := e.writeTag(TagInt, tagName); err != nil {
-			return err
-		}
-		return e.writeInt32(int32(val.Int()))
+	case TagInt:
+		return e.writeInt32(int32(intValue(val)))
 
-	case reflect.Float32:
-		if err := e.writeTag(TagFloat, tagName); err != nil {
-			return err
-		}
+	case TagFloat:
 		return e.writeInt32(int32(math.Float32bits(float32(val.Float()))))
 
-	case reflect.Int64, reflect.Uint64:
-		if err := e.writeTag(TagLong, tagName); err != nil {
-			return err
-		}
-		return e.writeInt64(int64(val.Int()))
+	case TagLong:
+		return e.writeInt64(intValue(val))
 
-	case reflect.Float64:
-		if err := e.writeTag(TagDouble, tagName); err != nil {
-			return err
-		}
+	case TagDouble:
 		return e.writeInt64(int64(math.Float64bits(val.Float())))
 
-	case reflect.Array, reflect.Slice:
-		switch val.Type().Elem().Kind() {
-		case reflect.Uint8: // []byte
-			if err := e.writeTag(TagByteArray, tagName); err != nil {
-				return err
-			}
-			if err := e.writeInt32(int32(val.Len())); err != nil {
-				return err
-			}
-			_, err := e.w.Write(val.Bytes())
+	case TagByteArray, TagIntArray, TagLongArray:
+		n := val.Len()
+		if err := e.writeInt32(int32(n)); err != nil {
 			return err
-
-		case reflect.Int32:
-			if err := e.writeTag(TagIntArray, tagName); err != nil {
-				return err
-			}
-			n := val.Len()
-			if err := e.writeInt32(int32(n)); err != nil {
-				return err
-			}
-			for i := 0; i < n; i++ {
-				if err := e.writeInt32(int32(val.Index(i).Int())); err != nil {
-					return err
-				}
-			}
-
-		case reflect.Int64:
-			if err := e.writeTag(TagLongArray, tagName); err != nil {
-				return err
+		}
+		for i := 0; i < n; i++ {
+			var err error
+			switch tagType {
+			case TagByteArray:
+				_, err = e.w.Write([]byte{byte(val.Index(i).Uint())})
+			case TagIntArray:
+				err = e.writeInt32(int32(val.Index(i).Int()))
+			case TagLongArray:
+				err = e.writeInt64(val.Index(i).Int())
 			}
-			n := val.Len()
-			if err := e.writeInt32(int32(n)); err != nil {
+			if err != nil {
 				return err
 			}
-			for i := 0; i < n; i++ {
-				if err := e.writeInt64(val.Index(i).Int()); err != nil {
-					return err
-				}
-			}
-
-		default:
-			return errors.New("unknown type " + val.Type().String() + " slice")
 		}
+		return nil
 
-	case reflect.String:
-		if err := e.writeTag(TagString, tagName); err != nil {
-			return err
-		}
+	case TagString:
 		if err := e.writeInt16(int16(val.Len())); err != nil {
 			return err
 		}
 		_, err := e.w.Write([]byte(val.String()))
 		return err
 
-	case reflect.Struct:
-		if err := e.writeTag(TagCompound, ""); err != nil {
+	case TagList:
+		// the element type is decided by the first element,
+		// and an empty list is a list of TagEnd as Minecraft does.
+		n := val.Len()
+		elemType := TagEnd
+		if n > 0 {
+			var err error
+			if elemType, err = getTagType(val.Index(0)); err != nil {
+				return err
+			}
+		}
+		if err := e.writeNamelessTag(elemType, ""); err != nil {
+			return err
+		}
+		if err := e.writeInt32(int32(n)); err != nil {
 			return err
 		}
-
-		n := val.NumField()
 		for i := 0; i < n; i++ {
-			f := val.Type().Field(i)
-			tag := f.Tag.Get("nbt")
-			if (f.PkgPath != "" && !f.Anonymous) || tag == "-" {
-				continue // Private field
+			if t, err := getTagType(val.Index(i)); err != nil {
+				return err
+			} else if t != elemType {
+				return errors.New("elements of different types in a list")
 			}
-
-			tagName := f.Name
-			if tag != "" {
-				tagName = tag
+			if err := e.writeValue(val.Index(i), elemType); err != nil {
+				return err
 			}
+		}
+		return nil
 
-			err := e.marshal(val.Field(i), tagName)
-			if err != nil {
+	case TagCompound:
+		if val.Kind() == reflect.Map {
+			if err := e.writeMap(val); err != nil {
 				return err
 			}
+		} else if err := e.writeStruct(val); err != nil {
+			return err
 		}
 		_, err := e.w.Write([]byte{TagEnd})
 		return err
 	}
+}
+
+func (e *Encoder) writeStruct(val reflect.Value) error {
+	n := val.NumField()
+	for i := 0; i < n; i++ {
+		f := val.Type().Field(i)
+		tagName, omitEmpty, ok := parseTag(f)
+		if !ok {
+			continue // Private field
+		}
+		v := val.Field(i)
+		if omitEmpty && isEmptyValue(v) {
+			continue
+		}
+		if t, err := getTagType(v); err != nil {
+			return err
+		} else if t == TagEnd {
+			continue // nil
+		}
+		if err := e.marshal(v, tagName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) writeMap(val reflect.Value) error {
+	if val.Type().Key().Kind() != reflect.String {
+		return errors.New("cannot encode " + val.Type().String() + " as TagCompound")
+	}
+	keys := val.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	for _, k := range keys {
+		v := val.MapIndex(k)
+		if t, err := getTagType(v); err != nil {
+			return err
+		} else if t == TagEnd {
+			continue // nil
+		}
+		if err := e.marshal(v, k.String()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func intValue(val reflect.Value) int64 {
+	switch val.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(val.Uint())
+	}
+	return val.Int()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
 func (e *Encoder) writeTag(tagType byte, tagName string) error {
 	if _, err := e.w.Write([]byte{tagType}); err != nil {
 		return err
@@ -0,0 +1,87 @@
+package nbt
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMarshal_struct(t *testing.T) {
+	type Item struct {
+		ID    string `nbt:"id"`
+		Count byte
+	}
+	type Player struct {
+		Name      string
+		Health    float32
+		Pos       [3]float64
+		Flying    bool
+		Score     int32   `nbt:"score,omitempty"`
+		Inventory []Item  `nbt:"inv"`
+		Motion    *[3]int `nbt:",omitempty"`
+	}
+	want := Player{
+		Name:   "Steve",
+		Health: 20,
+		Pos:    [3]float64{1, 64, -3},
+		Flying: true,
+		Inventory: []Item{
+			{ID: "minecraft:stone", Count: 64},
+			{ID: "minecraft:dirt", Count: 1},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	var got Player
+	if err := Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip fail: get %+v, want %+v", got, want)
+	}
+
+	var m map[string]interface{}
+	if err := Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["score"]; ok {
+		t.Error("empty score isn't omitted")
+	}
+	if inv, ok := m["inv"].([]interface{}); !ok || len(inv) != 2 {
+		t.Errorf("wrong inventory: %v", m["inv"])
+	}
+}
+
+func TestMarshal_map(t *testing.T) {
+	want := map[string]interface{}{
+		"Damage": int32(5),
+		"Enchantments": []interface{}{
+			map[string]interface{}{"id": "minecraft:efficiency", "lvl": int16(5)},
+		},
+		"display": map[string]interface{}{
+			"Lore": []interface{}{`"line 1"`, `"line 2"`},
+		},
+		"Unbreakable": byte(1),
+		"Empty":       []interface{}{},
+		"Colors":      []int32{1, 2, 3},
+	}
+	var buf bytes.Buffer
+	if err := Marshal(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	var got interface{}
+	if err := Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip fail: get %v, want %v", got, want)
+	}
+
+	bad := map[string]interface{}{"list": []interface{}{int32(1), "2"}}
+	if err := Marshal(&buf, bad); err == nil {
+		t.Error("list of different types should be rejected")
+	}
+}
@@ -2,6 +2,7 @@ package nbt
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -23,8 +24,8 @@ func getTypeInfo(typ reflect.Type) *typeInfo {
 		n := typ.NumField()
 		for i := 0; i < n; i++ {
 			f := typ.Field(i)
-			tag := f.Tag.Get("nbt")
-			if (f.PkgPath != "" && !f.Anonymous) || tag == "-" {
+			tag, _, ok := parseTag(f)
+			if !ok {
 				continue // Private field
 			}
 
@@ -46,3 +47,20 @@ func (t *typeInfo) findIndexByName(name string) int {
 	}
 	return i
 }
+
+// parseTag return the tag name of the field and whether it has the omitempty option,
+// such as `nbt:"name,omitempty"`. ok is false for the private or ignored fields.
+func parseTag(f reflect.StructField) (tagName string, omitEmpty, ok bool) {
+	tag := f.Tag.Get("nbt")
+	if (f.PkgPath != "" && !f.Anonymous) || tag == "-" {
+		return "", false, false
+	}
+	// the names of the tags may contain commas
+	if strings.HasSuffix(tag, ",omitempty") {
+		tag, omitEmpty = strings.TrimSuffix(tag, ",omitempty"), true
+	}
+	if tag == "" {
+		tag = f.Name
+	}
+	return tag, omitEmpty, true
+}
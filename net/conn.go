@@ -39,7 +39,14 @@ type Conn struct {
 	io.ByteReader
 	io.Writer
 
+	// MaxPacketSize and MaxDataSize limit the size of received packets,
+	// before and after decompressed. The default values are
+	// pk.MaxPacketSize and pk.MaxDataSize if they are zero.
+	// Packets exceed the limits make ReadPacket return a *pk.ProtocolError.
+	MaxPacketSize, MaxDataSize int
+
 	threshold int
+	reader    *pk.Reader
 }
 
 // DialMC create a Minecraft connection
@@ -76,12 +83,36 @@ func WrapConn(conn net.Conn) *Conn {
 func (c *Conn) Close() error { return c.Socket.Close() }
 
 // ReadPacket read a Packet from Conn.
+//
+// If the peer violates the protocol, eg. sending a packet too big,
+// the returned error is a *pk.ProtocolError. Otherwise it's an I/O error.
 func (c *Conn) ReadPacket() (pk.Packet, error) {
-	p, err := pk.RecvPacket(c.ByteReader, c.threshold > 0)
-	if err != nil {
-		return pk.Packet{}, err
+	r, ok := c.ByteReader.(io.Reader)
+	if !ok {
+		r = byteReader{c.ByteReader}
+	}
+
+	if c.reader == nil {
+		c.reader = pk.NewReader(r)
+	} else {
+		c.reader.Reset(r) // the ByteReader may be replaced, eg. by SetCipher
+	}
+	c.reader.MaxPacketSize = c.MaxPacketSize
+	c.reader.MaxDataSize = c.MaxDataSize
+	return c.reader.ReadPacket(c.threshold)
+}
+
+// byteReader makes an io.ByteReader also an io.Reader
+type byteReader struct{ io.ByteReader }
+
+func (r byteReader) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if p[n], err = r.ReadByte(); err != nil {
+			return
+		}
+		n++
 	}
-	return *p, err
+	return
 }
 
 //WritePacket write a Packet to Conn.
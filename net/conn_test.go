@@ -0,0 +1,32 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestConn_MaxPacketSize(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go func() {
+		c := WrapConn(client)
+		_ = c.WritePacket(pk.Marshal(0x00, pk.String("small")))
+		_ = c.WritePacket(pk.Marshal(0x00, pk.ByteArray(make([]byte, 1024))))
+	}()
+
+	c := WrapConn(server)
+	c.MaxPacketSize = 1024
+	if _, err := c.ReadPacket(); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.ReadPacket()
+	var pe *pk.ProtocolError
+	if !errors.As(err, &pe) || !errors.Is(err, pk.ErrPacketTooBig) {
+		t.Errorf("large packet should fail with ErrPacketTooBig, get %v", err)
+	}
+}
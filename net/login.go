@@ -0,0 +1,402 @@
+package net
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Tnze/go-mc/net/CFB8"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// Next state requested by the client in the Handshake packet
+const (
+	IntentionStatus = 1
+	IntentionLogin  = 2
+)
+
+// Handshake is the first packet sent by the client.
+type Handshake struct {
+	Protocol      int32
+	ServerAddress string
+	ServerPort    uint16
+	Intention     int32
+}
+
+// Decode implement net.packet.FieldDecoder
+func (h *Handshake) Decode(r pk.DecodeReader) error {
+	var (
+		Protocol, Intention pk.VarInt
+		ServerAddress       pk.String
+		ServerPort          pk.UnsignedShort
+	)
+	for _, v := range []pk.FieldDecoder{&Protocol, &ServerAddress, &ServerPort, &Intention} {
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+	}
+
+	h.Protocol = int32(Protocol)
+	h.ServerAddress = string(ServerAddress)
+	h.ServerPort = uint16(ServerPort)
+	h.Intention = int32(Intention)
+	return nil
+}
+
+// ReadHandshake read the Handshake packet from Conn.
+func (c *Conn) ReadHandshake() (hs Handshake, err error) {
+	p, err := c.ReadPacket()
+	if err != nil {
+		return
+	}
+	if p.ID != 0x00 {
+		err = fmt.Errorf("not a handshake packet: 0x%02X", p.ID)
+		return
+	}
+	err = p.Scan(&hs)
+	return
+}
+
+// Property is a property of player's profile, such as the skin.
+type Property struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// PlayerConn is the connection of a player who has finished the login process.
+// The connection is in play state.
+type PlayerConn struct {
+	*Conn
+	Handshake
+
+	Name       string
+	UUID       uuid.UUID
+	Properties []Property
+}
+
+// An Acceptor accepts the players connecting to a server.
+// It reads the handshake, answers status requests and
+// runs the login process for the login requests.
+//
+// The zero value of Acceptor is an offline-mode acceptor without compression.
+type Acceptor struct {
+	// OnlineMode enables encryption and authenticates the
+	// players with Mojang's session server.
+	OnlineMode bool
+	// Threshold is the compression threshold sent to the clients.
+	// The packets will not be compressed if Threshold <= 0.
+	Threshold int
+	// Protocol is the protocol version supported by the server.
+	// Clients with other versions are disconnected. Zero means accepting any version.
+	Protocol int32
+
+	// Status is called when a client requests the server status,
+	// including the legacy server list ping sent by the clients before 1.7.
+	// If nil, the connection is closed without response.
+	Status func(hs Handshake) (StatusResponse, error)
+
+	keyOnce sync.Once
+	key     *rsa.PrivateKey
+	keyErr  error
+}
+
+// Accept handles a newly accepted connection until the player joins the game.
+//
+// If the client only requested the server status, Accept answers it and
+// returns a nil PlayerConn with nil error. The connection is closed in this case.
+func (a *Acceptor) Accept(conn Conn) (*PlayerConn, error) {
+	if isLegacyPing(&conn) {
+		defer conn.Close()
+		if err := a.handleLegacyPing(&conn); err != nil {
+			return nil, fmt.Errorf("net: handle legacy ping fail: %v", err)
+		}
+		return nil, nil
+	}
+
+	hs, err := conn.ReadHandshake()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("net: read handshake fail: %v", err)
+	}
+
+	switch hs.Intention {
+	case IntentionStatus:
+		defer conn.Close()
+		if err := a.handleStatus(&conn, hs); err != nil {
+			return nil, fmt.Errorf("net: handle status fail: %v", err)
+		}
+		return nil, nil
+	case IntentionLogin:
+		p, err := a.Login(&conn, hs)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return p, nil
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("net: unknown intention: %d", hs.Intention)
+	}
+}
+
+// Login runs the login process after the handshake.
+// It reads the Login Start packet, authenticates the player if OnlineMode is enabled,
+// sets the compression and sends the Login Success packet.
+func (a *Acceptor) Login(conn *Conn, hs Handshake) (*PlayerConn, error) {
+	p, err := conn.ReadPacket()
+	if err != nil {
+		return nil, fmt.Errorf("net: read login start packet fail: %v", err)
+	}
+	if p.ID != 0x00 {
+		return nil, fmt.Errorf("net: not a login start packet: 0x%02X", p.ID)
+	}
+	var name pk.String
+	if err := p.Scan(&name); err != nil {
+		return nil, fmt.Errorf("net: scan login start packet fail: %v", err)
+	}
+
+	player := &PlayerConn{
+		Conn:      conn,
+		Handshake: hs,
+		Name:      string(name),
+	}
+
+	if a.Protocol != 0 && hs.Protocol != a.Protocol {
+		var reason string
+		if hs.Protocol < a.Protocol {
+			reason = "Outdated client!"
+		} else {
+			reason = "Outdated server!"
+		}
+		_ = conn.LoginDisconnect(reason)
+		return nil, fmt.Errorf("net: protocol version not match: %d", hs.Protocol)
+	}
+
+	if a.OnlineMode {
+		player.UUID, player.Name, player.Properties, err = a.Encrypt(conn, player.Name)
+		if err != nil {
+			_ = conn.LoginDisconnect("Failed to verify username!")
+			return nil, fmt.Errorf("net: encryption fail: %v", err)
+		}
+	} else {
+		player.UUID = OfflineUUID(player.Name)
+	}
+
+	if a.Threshold > 0 {
+		err = conn.WritePacket(pk.Marshal(0x03, pk.VarInt(a.Threshold)))
+		if err != nil {
+			return nil, fmt.Errorf("net: send set compression packet fail: %v", err)
+		}
+		conn.SetThreshold(a.Threshold)
+	}
+
+	err = conn.WritePacket(pk.Marshal(0x02,
+		pk.String(player.UUID.String()),
+		pk.String(player.Name),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("net: send login success packet fail: %v", err)
+	}
+
+	return player, nil
+}
+
+// LoginDisconnect sends the Disconnect packet in login state.
+func (c *Conn) LoginDisconnect(reason string) error {
+	msg, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{reason})
+	if err != nil {
+		return err
+	}
+	return c.WritePacket(pk.Marshal(0x00, pk.String(msg)))
+}
+
+func (a *Acceptor) privateKey() (*rsa.PrivateKey, error) {
+	a.keyOnce.Do(func() {
+		a.key, a.keyErr = rsa.GenerateKey(rand.Reader, 1024)
+	})
+	return a.key, a.keyErr
+}
+
+// Encrypt sends the Encryption Request to the client and enables
+// AES/CFB8 encryption on conn with the shared secret from the response.
+// Then the player is verified by Mojang's session server,
+// which returns the player's UUID, name and profile properties.
+func (a *Acceptor) Encrypt(conn *Conn, name string) (id uuid.UUID, realName string, props []Property, err error) {
+	key, err := a.privateKey()
+	if err != nil {
+		err = fmt.Errorf("generate rsa key fail: %v", err)
+		return
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		err = fmt.Errorf("marshal public key fail: %v", err)
+		return
+	}
+
+	verifyToken := make([]byte, 4)
+	if _, err = rand.Read(verifyToken); err != nil {
+		return
+	}
+
+	// Encryption Request
+	err = conn.WritePacket(pk.Marshal(0x01,
+		pk.String(""), // Server ID, empty since 1.7
+		pk.ByteArray(publicKey),
+		pk.ByteArray(verifyToken),
+	))
+	if err != nil {
+		err = fmt.Errorf("send encryption request fail: %v", err)
+		return
+	}
+
+	// Encryption Response
+	p, err := conn.ReadPacket()
+	if err != nil {
+		err = fmt.Errorf("read encryption response fail: %v", err)
+		return
+	}
+	if p.ID != 0x01 {
+		err = fmt.Errorf("not a encryption response packet: 0x%02X", p.ID)
+		return
+	}
+	var cryptSecret, cryptToken pk.ByteArray
+	if err = p.Scan(&cryptSecret, &cryptToken); err != nil {
+		err = fmt.Errorf("scan encryption response fail: %v", err)
+		return
+	}
+
+	sharedSecret, err := rsa.DecryptPKCS1v15(rand.Reader, key, cryptSecret)
+	if err != nil {
+		err = fmt.Errorf("decrypt shared secret fail: %v", err)
+		return
+	}
+	token, err := rsa.DecryptPKCS1v15(rand.Reader, key, cryptToken)
+	if err != nil {
+		err = fmt.Errorf("decrypt verify token fail: %v", err)
+		return
+	}
+	if !bytes.Equal(token, verifyToken) {
+		err = errors.New("verify token not match")
+		return
+	}
+
+	b, err := aes.NewCipher(sharedSecret)
+	if err != nil {
+		err = fmt.Errorf("load aes key fail: %v", err)
+		return
+	}
+	conn.SetCipher(
+		CFB8.NewCFB8Encrypt(b, sharedSecret),
+		CFB8.NewCFB8Decrypt(b, sharedSecret),
+	)
+
+	return hasJoined(name, AuthDigest("", sharedSecret, publicKey))
+}
+
+// sessionClient requests the session server,
+// the timeout stops a slow server blocking the login forever.
+var sessionClient = http.Client{Timeout: 30 * time.Second}
+
+func hasJoined(name, serverID string) (id uuid.UUID, realName string, props []Property, err error) {
+	resp, err := sessionClient.Get("https://sessionserver.mojang.com/session/minecraft/hasJoined?" +
+		url.Values{"username": {name}, "serverId": {serverID}}.Encode())
+	if err != nil {
+		err = fmt.Errorf("request session server fail: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("auth fail: %s", resp.Status)
+		return
+	}
+
+	var profile struct {
+		ID         string     `json:"id"`
+		Name       string     `json:"name"`
+		Properties []Property `json:"properties"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		err = fmt.Errorf("decode profile fail: %v", err)
+		return
+	}
+
+	id, err = uuid.Parse(profile.ID)
+	if err != nil {
+		err = fmt.Errorf("parse uuid fail: %v", err)
+		return
+	}
+	return id, profile.Name, profile.Properties, nil
+}
+
+// AuthDigest computes a special SHA-1 digest required for Minecraft web
+// authentication on Premium servers (online-mode=true).
+// Source: http://wiki.vg/Protocol_Encryption#Server
+//
+// Also many, many thanks to SirCmpwn and his wonderful gist (C#):
+// https://gist.github.com/SirCmpwn/404223052379e82f91e6
+func AuthDigest(serverID string, sharedSecret, publicKey []byte) string {
+	h := sha1.New()
+	h.Write([]byte(serverID))
+	h.Write(sharedSecret)
+	h.Write(publicKey)
+	hash := h.Sum(nil)
+
+	// Check for negative hashes
+	negative := (hash[0] & 0x80) == 0x80
+	if negative {
+		hash = twosComplement(hash)
+	}
+
+	// Trim away zeroes
+	res := strings.TrimLeft(fmt.Sprintf("%x", hash), "0")
+	if negative {
+		res = "-" + res
+	}
+
+	return res
+}
+
+// little endian
+func twosComplement(p []byte) []byte {
+	carry := true
+	for i := len(p) - 1; i >= 0; i-- {
+		p[i] = byte(^p[i])
+		if carry {
+			carry = p[i] == 0xff
+			p[i]++
+		}
+	}
+	return p
+}
+
+// OfflineUUID return the UUID from player name in offline mode
+func OfflineUUID(name string) uuid.UUID {
+	var version = 3
+	h := md5.New()
+	h.Reset()
+	h.Write([]byte("OfflinePlayer:" + name))
+	s := h.Sum(nil)
+	var uuid uuid.UUID
+	copy(uuid[:], s)
+	uuid[6] = (uuid[6] & 0x0f) | uint8((version&0xf)<<4)
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return uuid
+}
@@ -0,0 +1,78 @@
+package net
+
+import (
+	"net"
+	"testing"
+
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestAuthDigest(t *testing.T) {
+	for name, want := range map[string]string{
+		"Notch": "4ed1f46bbe04bc756bcb17c0c7ce3e4632f06a48",
+		"jeb_":  "-7c9d5b0044c130109a5d7b5fb5c317c02b4e28c1",
+		"simon": "88e16a1019277b15d58faf0541e11910eb756f6",
+	} {
+		if got := AuthDigest(name, nil, nil); got != want {
+			t.Errorf("digest of %q should be %s, get %s", name, want, got)
+		}
+	}
+}
+
+func TestAcceptor_Login(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	type result struct {
+		p   *PlayerConn
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		a := Acceptor{Threshold: 256, Protocol: 578}
+		p, err := a.Accept(*WrapConn(server))
+		done <- result{p, err}
+	}()
+
+	c := WrapConn(client)
+	if err := c.WritePacket(pk.Marshal(0x00,
+		pk.VarInt(578), pk.String("localhost"), pk.UnsignedShort(25565), pk.VarInt(IntentionLogin),
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WritePacket(pk.Marshal(0x00, pk.String("Tnze"))); err != nil {
+		t.Fatal(err)
+	}
+
+	// Set Compression
+	p, err := c.ReadPacket()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var threshold pk.VarInt
+	if err := p.Scan(&threshold); err != nil || p.ID != 0x03 || threshold != 256 {
+		t.Fatalf("unexpected set compression packet: %v, %v", p, err)
+	}
+	c.SetThreshold(int(threshold))
+
+	// Login Success
+	p, err = c.ReadPacket()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id, name pk.String
+	if err := p.Scan(&id, &name); err != nil || p.ID != 0x02 {
+		t.Fatalf("unexpected login success packet: %v, %v", p, err)
+	}
+	if id != "c7b9eece-2f2e-325c-8da8-6fc8f3d0edb0" || name != "Tnze" {
+		t.Errorf("unexpected profile: %s %s", id, name)
+	}
+
+	r := <-done
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if r.p.Name != "Tnze" || r.p.UUID.String() != string(id) || r.p.Protocol != 578 {
+		t.Errorf("unexpected player: %+v", r.p)
+	}
+}
@@ -0,0 +1,456 @@
+package packet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/Tnze/go-mc/nbt"
+)
+
+// MarshalStruct generate Packet with the ID and the exported fields of the struct v.
+//
+// Each field is encoded in order. Fields implementing FieldEncoder are encoded by themselves,
+// others are encoded by their kinds:
+//
+//	bool                   Boolean
+//	int8, uint8            Byte, UnsignedByte
+//	int16, uint16          Short, UnsignedShort
+//	int32, int64           Int, Long
+//	float32, float64       Float, Double
+//	string                 String
+//	[]byte                 ByteArray
+//	[N]T                   N elements one by one, so uuid.UUID is encoded as UUID
+//	struct                 fields one by one
+//
+// The encoding can be changed by the "mc" tag, multiple options are separated by comma:
+//
+//	mc:"-"                 the field is ignored
+//	mc:"varint"            int32 (or int) is encoded as VarInt
+//	mc:"varlong"           int64 (or int) is encoded as VarLong
+//	mc:"optional"          pointer is encoded as a Boolean, followed by the value if it's not nil
+//	mc:"prefixed-array"    slice is encoded as VarInt length, followed by the elements
+//	mc:"rest"              slice is encoded without length, and takes all remaining data when decoding
+//	mc:"nbt"               the value is encoded as NBT, nil is encoded as TagEnd
+//
+// Options except optional, prefixed-array and rest are also applied to the elements, eg.
+//
+//	Entities []int32 `mc:"prefixed-array,varint"`
+func MarshalStruct(ID byte, v interface{}) (pk Packet, err error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return pk, fmt.Errorf("packet: cannot marshal %v as packet", val.Type())
+	}
+
+	var buf bytes.Buffer
+	if err := encodeValue(&buf, val, fieldOptions{}); err != nil {
+		return pk, fmt.Errorf("packet: marshal %v fail: %w", val.Type(), err)
+	}
+	pk.ID = ID
+	pk.Data = buf.Bytes()
+	return
+}
+
+// Unmarshal decode the packet into the struct pointed by v.
+// See MarshalStruct for the encoding of each field.
+func (p Packet) Unmarshal(v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.New("packet: non-pointer passed to Unmarshal")
+	}
+	val = val.Elem()
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("packet: cannot unmarshal packet into %v", val.Type())
+	}
+
+	if err := decodeValue(bytes.NewReader(p.Data), val, fieldOptions{}); err != nil {
+		return fmt.Errorf("packet: unmarshal %v fail: %w", val.Type(), err)
+	}
+	return nil
+}
+
+type fieldOptions struct {
+	skip          bool
+	varint        bool
+	varlong       bool
+	optional      bool
+	prefixedArray bool
+	rest          bool
+	nbt           bool
+}
+
+// array reports whether the slice should be encoded element by element.
+func (o fieldOptions) array() bool {
+	return o.prefixedArray || o.rest
+}
+
+// container reports whether the options about pointer or slice are left unused.
+func (o fieldOptions) container() bool {
+	return o.optional || o.prefixedArray || o.rest
+}
+
+func parseFieldOptions(tag string) (o fieldOptions, err error) {
+	if tag == "" {
+		return
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		switch opt {
+		case "-":
+			o.skip = true
+		case "varint":
+			o.varint = true
+		case "varlong":
+			o.varlong = true
+		case "optional":
+			o.optional = true
+		case "prefixed-array":
+			o.prefixedArray = true
+		case "rest":
+			o.rest = true
+		case "nbt":
+			o.nbt = true
+		default:
+			return o, fmt.Errorf("unknown option %q", opt)
+		}
+	}
+	return
+}
+
+type structField struct {
+	index int
+	name  string
+	opts  fieldOptions
+}
+
+type structInfo struct {
+	fields []structField
+	err    error
+}
+
+var structInfoMap sync.Map
+
+func getStructInfo(typ reflect.Type) *structInfo {
+	if si, ok := structInfoMap.Load(typ); ok {
+		return si.(*structInfo)
+	}
+
+	sInfo := new(structInfo)
+	n := typ.NumField()
+	for i := 0; i < n; i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue // Private field
+		}
+		opts, err := parseFieldOptions(f.Tag.Get("mc"))
+		if err != nil {
+			sInfo.err = fmt.Errorf("field %s: %v", f.Name, err)
+			break
+		}
+		if opts.skip {
+			continue
+		}
+		sInfo.fields = append(sInfo.fields, structField{index: i, name: f.Name, opts: opts})
+	}
+
+	si, _ := structInfoMap.LoadOrStore(typ, sInfo)
+	return si.(*structInfo)
+}
+
+var (
+	fieldEncoderType = reflect.TypeOf((*FieldEncoder)(nil)).Elem()
+	fieldDecoderType = reflect.TypeOf((*FieldDecoder)(nil)).Elem()
+)
+
+func encodeValue(w *bytes.Buffer, val reflect.Value, o fieldOptions) error {
+	typ := val.Type()
+	switch {
+	case o.array() && typ.Kind() == reflect.Slice:
+		if o.prefixedArray {
+			w.Write(VarInt(val.Len()).Encode())
+		} else if typ.Elem().Kind() == reflect.Uint8 && !o.optional {
+			w.Write(val.Bytes())
+			return nil
+		}
+		o.prefixedArray, o.rest = false, false
+		for i := 0; i < val.Len(); i++ {
+			if err := encodeValue(w, val.Index(i), o); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case o.optional && typ.Kind() == reflect.Ptr:
+		w.Write(Boolean(!val.IsNil()).Encode())
+		if val.IsNil() {
+			return nil
+		}
+		o.optional = false
+		return encodeValue(w, val.Elem(), o)
+
+	case o.container():
+		return fmt.Errorf("%v cannot be optional, prefixed-array or rest", typ)
+
+	case o.nbt:
+		if (typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Interface) && val.IsNil() {
+			w.WriteByte(nbt.TagEnd)
+			return nil
+		}
+		return nbt.NewEncoder(w).Encode(val.Interface())
+
+	case typ.Implements(fieldEncoderType):
+		w.Write(val.Interface().(FieldEncoder).Encode())
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		w.Write(Boolean(val.Bool()).Encode())
+	case reflect.Int8:
+		w.Write(Byte(val.Int()).Encode())
+	case reflect.Uint8:
+		w.Write(UnsignedByte(val.Uint()).Encode())
+	case reflect.Int16:
+		w.Write(Short(val.Int()).Encode())
+	case reflect.Uint16:
+		w.Write(UnsignedShort(val.Uint()).Encode())
+	case reflect.Int32, reflect.Int64, reflect.Int:
+		switch {
+		case o.varint:
+			w.Write(VarInt(val.Int()).Encode())
+		case o.varlong:
+			w.Write(VarLong(val.Int()).Encode())
+		case typ.Kind() == reflect.Int32:
+			w.Write(Int(val.Int()).Encode())
+		case typ.Kind() == reflect.Int64:
+			w.Write(Long(val.Int()).Encode())
+		default:
+			return errors.New("int field must be tagged as varint or varlong")
+		}
+	case reflect.Float32:
+		w.Write(Float(val.Float()).Encode())
+	case reflect.Float64:
+		w.Write(Double(val.Float()).Encode())
+	case reflect.String:
+		w.Write(String(val.String()).Encode())
+
+	case reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := encodeValue(w, val.Index(i), o); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("slice %v must be tagged as prefixed-array or rest", typ)
+		}
+		w.Write(ByteArray(val.Bytes()).Encode())
+
+	case reflect.Struct:
+		si := getStructInfo(typ)
+		if si.err != nil {
+			return si.err
+		}
+		for _, f := range si.fields {
+			if err := encodeValue(w, val.Field(f.index), f.opts); err != nil {
+				return fmt.Errorf("field %s: %w", f.name, err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("cannot encode %v", typ)
+	}
+	return nil
+}
+
+func decodeValue(r *bytes.Reader, val reflect.Value, o fieldOptions) error {
+	typ := val.Type()
+	switch {
+	case o.array() && typ.Kind() == reflect.Slice:
+		if o.rest && typ.Elem().Kind() == reflect.Uint8 && !o.optional {
+			bs := make([]byte, r.Len())
+			if _, err := io.ReadFull(r, bs); err != nil {
+				return err
+			}
+			val.SetBytes(bs)
+			return nil
+		}
+		s := reflect.MakeSlice(typ, 0, 0)
+		if o.prefixedArray {
+			var n VarInt
+			if err := n.Decode(r); err != nil {
+				return err
+			}
+			if n < 0 || int(n) > r.Len() { // each element takes at least one byte
+				return fmt.Errorf("invalid array length %d", n)
+			}
+			s = reflect.MakeSlice(typ, int(n), int(n))
+		}
+		rest := o.rest
+		o.prefixedArray, o.rest = false, false
+		for i := 0; rest && r.Len() > 0 || i < s.Len(); i++ {
+			if rest {
+				s = reflect.Append(s, reflect.Zero(typ.Elem()))
+			}
+			if err := decodeValue(r, s.Index(i), o); err != nil {
+				return err
+			}
+		}
+		val.Set(s)
+		return nil
+
+	case o.optional && typ.Kind() == reflect.Ptr:
+		var present Boolean
+		if err := present.Decode(r); err != nil {
+			return err
+		}
+		if !present {
+			val.Set(reflect.Zero(typ))
+			return nil
+		}
+		v := reflect.New(typ.Elem())
+		o.optional = false
+		if err := decodeValue(r, v.Elem(), o); err != nil {
+			return err
+		}
+		val.Set(v)
+		return nil
+
+	case o.container():
+		return fmt.Errorf("%v cannot be optional, prefixed-array or rest", typ)
+
+	case o.nbt:
+		if b, err := r.ReadByte(); err != nil {
+			return err
+		} else if b == nbt.TagEnd {
+			val.Set(reflect.Zero(typ))
+			return nil
+		}
+		_ = r.UnreadByte()
+		return nbt.NewDecoder(r).Decode(val.Addr().Interface())
+
+	case reflect.PtrTo(typ).Implements(fieldDecoderType):
+		return val.Addr().Interface().(FieldDecoder).Decode(r)
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		var v Boolean
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetBool(bool(v))
+	case reflect.Int8:
+		var v Byte
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetInt(int64(v))
+	case reflect.Uint8:
+		var v UnsignedByte
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetUint(uint64(v))
+	case reflect.Int16:
+		var v Short
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetInt(int64(v))
+	case reflect.Uint16:
+		var v UnsignedShort
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetUint(uint64(v))
+	case reflect.Int32, reflect.Int64, reflect.Int:
+		var v int64
+		switch {
+		case o.varint:
+			var vi VarInt
+			if err := vi.Decode(r); err != nil {
+				return err
+			}
+			v = int64(vi)
+		case o.varlong:
+			var vl VarLong
+			if err := vl.Decode(r); err != nil {
+				return err
+			}
+			v = int64(vl)
+		case typ.Kind() == reflect.Int32:
+			var i Int
+			if err := i.Decode(r); err != nil {
+				return err
+			}
+			v = int64(i)
+		case typ.Kind() == reflect.Int64:
+			var l Long
+			if err := l.Decode(r); err != nil {
+				return err
+			}
+			v = int64(l)
+		default:
+			return errors.New("int field must be tagged as varint or varlong")
+		}
+		val.SetInt(v)
+	case reflect.Float32:
+		var v Float
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetFloat(float64(v))
+	case reflect.Float64:
+		var v Double
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetFloat(float64(v))
+	case reflect.String:
+		var v String
+		if err := v.Decode(r); err != nil {
+			return err
+		}
+		val.SetString(string(v))
+
+	case reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := decodeValue(r, val.Index(i), o); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("slice %v must be tagged as prefixed-array or rest", typ)
+		}
+		var bs ByteArray
+		if err := bs.Decode(r); err != nil {
+			return err
+		}
+		val.SetBytes(bs)
+
+	case reflect.Struct:
+		si := getStructInfo(typ)
+		if si.err != nil {
+			return si.err
+		}
+		for _, f := range si.fields {
+			if err := decodeValue(r, val.Field(f.index), f.opts); err != nil {
+				return fmt.Errorf("field %s: %w", f.name, err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("cannot decode %v", typ)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package packet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type testItem struct {
+	ItemID int32 `mc:"varint"`
+	Count  int8
+}
+
+type testPacket struct {
+	EntityID   int32 `mc:"varint"`
+	UUID       uuid.UUID
+	Name       string
+	Location   Position
+	OnGround   bool
+	Unused     int `mc:"-"`
+	hidden     int
+	Passengers []int32     `mc:"prefixed-array,varint"`
+	Item       *testItem   `mc:"optional"`
+	Empty      *testItem   `mc:"optional"`
+	Slots      []*testItem `mc:"prefixed-array,optional"`
+	Data       []byte      `mc:"rest"`
+}
+
+func TestMarshalStruct(t *testing.T) {
+	id := uuid.MustParse("853c80ef-3c37-49fd-aa49-938b674adae6")
+	v := testPacket{
+		EntityID:   300,
+		UUID:       id,
+		Name:       "Tnze",
+		Location:   Position{X: 1, Y: 2, Z: 3},
+		OnGround:   true,
+		Passengers: []int32{1, 128},
+		Item:       &testItem{ItemID: 1, Count: 64},
+		Slots:      []*testItem{nil, {ItemID: 2, Count: 1}},
+		Data:       []byte{0xCA, 0xFE},
+	}
+	p, err := MarshalStruct(0x10, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Marshal(0x10,
+		VarInt(300), UUID(id), String("Tnze"), Position{X: 1, Y: 2, Z: 3}, Boolean(true),
+		VarInt(2), VarInt(1), VarInt(128),
+		Boolean(true), VarInt(1), Byte(64),
+		Boolean(false),
+		VarInt(2), Boolean(false), Boolean(true), VarInt(2), Byte(1),
+	)
+	want.Data = append(want.Data, 0xCA, 0xFE)
+	if p.ID != want.ID || !bytes.Equal(p.Data, want.Data) {
+		t.Fatalf("marshal result wrong:\nget  % x\nwant % x", p.Data, want.Data)
+	}
+
+	var got testPacket
+	if err := p.Unmarshal(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, v) {
+		t.Errorf("unmarshal result wrong:\nget  %+v\nwant %+v", got, v)
+	}
+}
+
+func TestMarshalStruct_badTag(t *testing.T) {
+	var v struct {
+		Slice []int32
+	}
+	if _, err := MarshalStruct(0x00, v); err == nil {
+		t.Error("untagged slice should be an error")
+	}
+	if err := Marshal(0x00, VarInt(0)).Unmarshal(&v); err == nil {
+		t.Error("untagged slice should be an error")
+	}
+}
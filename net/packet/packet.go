@@ -64,6 +64,7 @@ func (p *Packet) Pack(threshold int) (pack []byte) {
 }
 
 // RecvPacket receive a packet from server
+// For reading many packets from a stream, Reader is faster.
 func RecvPacket(r io.ByteReader, useZlib bool) (*Packet, error) {
 	var len int
 	for i := 0; i < 5; i++ { //读数据前的长度标记
@@ -80,6 +81,9 @@ func RecvPacket(r io.ByteReader, useZlib bool) (*Packet, error) {
 	if len < 1 {
 		return nil, fmt.Errorf("packet length too short")
 	}
+	if len > MaxPacketSize {
+		return nil, &ProtocolError{Err: ErrPacketTooBig, Detail: fmt.Sprintf("%d > %d", len, MaxPacketSize)}
+	}
 
 	data := make([]byte, len) //读包内容
 	var err error
@@ -109,6 +113,9 @@ func UnCompress(data []byte) (*Packet, error) {
 	if err := sizeUncompressed.Decode(reader); err != nil {
 		return nil, err
 	}
+	if sizeUncompressed < 0 || sizeUncompressed > MaxDataSize {
+		return nil, &ProtocolError{Err: ErrDataTooBig, Detail: fmt.Sprintf("%d > %d", sizeUncompressed, MaxDataSize)}
+	}
 
 	uncompressData := make([]byte, sizeUncompressed)
 	if sizeUncompressed != 0 { // != 0 means compressed, let's decompress
@@ -125,6 +132,9 @@ func UnCompress(data []byte) (*Packet, error) {
 	} else {
 		uncompressData = data[1:]
 	}
+	if len(uncompressData) < 1 {
+		return nil, &ProtocolError{Err: ErrBadLength, Detail: "empty packet"}
+	}
 	return &Packet{
 		ID:   uncompressData[0],
 		Data: uncompressData[1:],
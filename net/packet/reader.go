@@ -0,0 +1,211 @@
+package packet
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Default limits of the received packets, same as the vanilla server.
+const (
+	// MaxPacketSize is the max length of a packet, it's the max value of a 3-byte VarInt.
+	MaxPacketSize = 1<<21 - 1
+	// MaxDataSize is the max length of a packet after decompressed.
+	MaxDataSize = 1 << 23
+)
+
+// Errors returned by Reader when the peer violates the protocol.
+// They are wrapped in a ProtocolError, and can be checked by errors.Is.
+var (
+	ErrBadLength      = errors.New("bad packet length")
+	ErrPacketTooBig   = errors.New("packet is too big")
+	ErrDataTooBig     = errors.New("uncompressed data is too big")
+	ErrBadCompression = errors.New("badly compressed packet")
+)
+
+// A ProtocolError is returned when the received data isn't a valid packet,
+// which means the connection should be closed.
+// The other errors returned by Reader are I/O errors.
+type ProtocolError struct {
+	Err    error // one of ErrBadLength, ErrPacketTooBig, ErrDataTooBig and ErrBadCompression
+	Detail string
+}
+
+func (e *ProtocolError) Error() string {
+	if e.Detail == "" {
+		return "packet: " + e.Err.Error()
+	}
+	return "packet: " + e.Err.Error() + ": " + e.Detail
+}
+
+// Unwrap return the Err
+func (e *ProtocolError) Unwrap() error { return e.Err }
+
+// A Reader reads packets from a stream.
+//
+// Unlike RecvPacket, the packets are read by io.ReadFull instead of byte by byte.
+// The compressed data is read into pooled buffers and
+// the zlib decompressor is reused between packets,
+// so only the Data of each returned packet is allocated.
+//
+// A Reader is not safe for concurrent use.
+type Reader struct {
+	// MaxPacketSize is the max length of the received packets (compressed, if enabled).
+	// If zero, the default MaxPacketSize is used.
+	MaxPacketSize int
+	// MaxDataSize is the max length of the received packets after decompressed.
+	// If zero, the default MaxDataSize is used.
+	MaxDataSize int
+
+	r  io.Reader
+	br io.ByteReader
+
+	frame bytes.Reader  // the compressed data of current packet
+	zr    io.ReadCloser // reused zlib decompressor, nil before the first compressed packet
+}
+
+// NewReader create a packet Reader reading from r.
+// If r doesn't implement io.ByteReader, it's wrapped by a bufio.Reader.
+func NewReader(r io.Reader) *Reader {
+	pr := new(Reader)
+	pr.Reset(r)
+	return pr
+}
+
+// Reset makes the Reader read from r, and keeps the zlib decompressor.
+// If r doesn't implement io.ByteReader, it's wrapped by a bufio.Reader.
+func (r *Reader) Reset(rd io.Reader) {
+	br, ok := rd.(io.ByteReader)
+	if !ok {
+		b := bufio.NewReader(rd)
+		rd, br = b, b
+	}
+	r.r, r.br = rd, br
+}
+
+// ReadPacket read a packet. The threshold is the one set by Set Compression,
+// and the packets are not compressed if it's not positive.
+func (r *Reader) ReadPacket(threshold int) (p Packet, err error) {
+	maxPacketSize, maxDataSize := r.MaxPacketSize, r.MaxDataSize
+	if maxPacketSize <= 0 {
+		maxPacketSize = MaxPacketSize
+	}
+	if maxDataSize <= 0 {
+		maxDataSize = MaxDataSize
+	}
+
+	length, _, err := readVarInt(r.br)
+	if err != nil {
+		return p, fmt.Errorf("read len of packet fail: %w", err)
+	}
+	switch {
+	case length < 1:
+		return p, &ProtocolError{Err: ErrBadLength, Detail: fmt.Sprintf("%d", length)}
+	case int(length) > maxPacketSize:
+		return p, &ProtocolError{Err: ErrPacketTooBig, Detail: fmt.Sprintf("%d > %d", length, maxPacketSize)}
+	}
+
+	var data []byte
+	if threshold > 0 {
+		data, err = r.readCompressed(int(length), threshold, maxDataSize)
+	} else {
+		data = make([]byte, length)
+		if _, err = io.ReadFull(r.r, data); err != nil {
+			err = fmt.Errorf("read content of packet fail: %w", err)
+		}
+	}
+	if err != nil {
+		return p, err
+	}
+	if len(data) < 1 {
+		return p, &ProtocolError{Err: ErrBadLength, Detail: "empty packet"}
+	}
+
+	p.ID, p.Data = data[0], data[1:]
+	return
+}
+
+// readCompressed read the rest of a compressed packet, which has the length.
+func (r *Reader) readCompressed(length, threshold, maxDataSize int) ([]byte, error) {
+	size, n, err := readVarInt(r.br)
+	if err != nil {
+		return nil, fmt.Errorf("read data length fail: %w", err)
+	}
+	length -= n
+	switch {
+	case length < 0 || size < 0:
+		return nil, &ProtocolError{Err: ErrBadLength, Detail: "bad data length"}
+	case int(size) > maxDataSize:
+		return nil, &ProtocolError{Err: ErrDataTooBig, Detail: fmt.Sprintf("%d > %d", size, maxDataSize)}
+	case size == 0: // not compressed
+		data := make([]byte, length)
+		if _, err := io.ReadFull(r.r, data); err != nil {
+			return nil, fmt.Errorf("read content of packet fail: %w", err)
+		}
+		return data, nil
+	case int(size) < threshold:
+		return nil, &ProtocolError{Err: ErrBadCompression, Detail: fmt.Sprintf("data length %d is below the threshold %d", size, threshold)}
+	}
+
+	buf := getBuffer(length)
+	defer putBuffer(buf)
+	if _, err := io.ReadFull(r.r, *buf); err != nil {
+		return nil, fmt.Errorf("read content of packet fail: %w", err)
+	}
+	r.frame.Reset(*buf)
+
+	// All data has been received, so the errors below are caused by the bad data.
+	if r.zr == nil {
+		r.zr, err = zlib.NewReader(&r.frame)
+	} else {
+		err = r.zr.(zlib.Resetter).Reset(&r.frame, nil)
+	}
+	if err != nil {
+		return nil, &ProtocolError{Err: ErrBadCompression, Detail: err.Error()}
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.zr, data); err != nil {
+		return nil, &ProtocolError{Err: ErrBadCompression, Detail: err.Error()}
+	}
+	return data, nil
+}
+
+// readVarInt read a VarInt and return how many bytes it takes.
+func readVarInt(r io.ByteReader) (v int32, n int, err error) {
+	var num uint32
+	for n < 5 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, n, err
+		}
+		num |= uint32(b&0x7F) << uint(7*n)
+		n++
+		if b&0x80 == 0 {
+			return int32(num), n, nil
+		}
+	}
+	return 0, n, &ProtocolError{Err: ErrBadLength, Detail: "VarInt is too big"}
+}
+
+// bufferPool stores the buffers of compressed data.
+// They are shared by all Readers since the packets are
+// usually decompressed immediately after received.
+var bufferPool sync.Pool
+
+func getBuffer(n int) *[]byte {
+	if buf, ok := bufferPool.Get().(*[]byte); ok && cap(*buf) >= n {
+		*buf = (*buf)[:n]
+		return buf
+	}
+	buf := make([]byte, n)
+	return &buf
+}
+
+func putBuffer(buf *[]byte) {
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,149 @@
+package packet
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+// packets used for testing, including small packets and a chunk-like large packet
+func testPackets() []Packet {
+	chunk := make([]byte, 64*1024)
+	rand.New(rand.NewSource(1)).Read(chunk[:len(chunk)/4]) // partly compressible
+	return []Packet{
+		Marshal(0x21, Long(0x0102030405060708)),
+		Marshal(0x00),
+		Marshal(0x0F, String("Hello, world"), Byte(0)),
+		{ID: 0x22, Data: chunk},
+	}
+}
+
+func packStream(ps []Packet, threshold, times int) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < times; i++ {
+		for _, p := range ps {
+			buf.Write(p.Pack(threshold))
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestReader_ReadPacket(t *testing.T) {
+	ps := testPackets()
+	for _, threshold := range []int{-1, 0, 1, 256} {
+		r := NewReader(bytes.NewReader(packStream(ps, threshold, 2)))
+		for i := 0; i < 2*len(ps); i++ {
+			p, err := r.ReadPacket(threshold)
+			if err != nil {
+				t.Fatalf("threshold %d: %v", threshold, err)
+			}
+			want := ps[i%len(ps)]
+			if p.ID != want.ID || !bytes.Equal(p.Data, want.Data) {
+				t.Errorf("threshold %d: packet %d is wrong", threshold, i)
+			}
+		}
+		if _, err := r.ReadPacket(threshold); err == nil {
+			t.Errorf("threshold %d: read after end should fail", threshold)
+		}
+	}
+}
+
+func TestReader_ReadPacket_bad(t *testing.T) {
+	bomb := Packet{ID: 0x22, Data: make([]byte, MaxDataSize)} // compressed to 8KiB
+	bombData := bomb.Pack(256)
+	for _, v := range []struct {
+		data      []byte
+		threshold int
+		err       error
+	}{
+		{[]byte{0x00}, -1, ErrBadLength},                               // zero length
+		{[]byte{0x01, 0x00}, 256, ErrBadLength},                        // empty packet
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, -1, ErrBadLength}, // VarInt too big
+		{[]byte{0x80, 0x80, 0x80, 0x01}, -1, ErrPacketTooBig},          // 2097152
+		{[]byte{0x03, 0x80, 0x80, 0x80, 0x08}, 256, ErrBadLength},      // data length longer than packet
+		{[]byte{0x05, 0x80, 0x80, 0x80, 0x08}, 256, ErrDataTooBig},     // 16777216
+		{[]byte{0x03, 0x05, 0xFF, 0xFF}, 256, ErrBadCompression},       // below threshold
+		{[]byte{0x04, 0x80, 0x02, 0xFF, 0xFF}, 256, ErrBadCompression}, // bad zlib data
+		{bombData, 256, ErrDataTooBig},                                 // MaxDataSize + 1 (with ID)
+		{[]byte{0x05, 0x01}, -1, io.ErrUnexpectedEOF},                  // I/O error
+	} {
+		_, err := NewReader(bytes.NewReader(v.data)).ReadPacket(v.threshold)
+		if !errors.Is(err, v.err) {
+			t.Errorf("read % .8x should fail with %v, get %v", v.data, v.err, err)
+		}
+		var pe *ProtocolError
+		if errors.As(err, &pe) != (v.err != io.ErrUnexpectedEOF) {
+			t.Errorf("%v should be a ProtocolError: %v", err, v.err != io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestReader_limits(t *testing.T) {
+	ps := testPackets()
+	r := NewReader(bytes.NewReader(packStream(ps, 256, 1)))
+	r.MaxDataSize = 1024
+	for _, want := range ps[:len(ps)-1] {
+		if _, err := r.ReadPacket(256); err != nil {
+			t.Fatalf("read packet 0x%02X fail: %v", want.ID, err)
+		}
+	}
+	if _, err := r.ReadPacket(256); !errors.Is(err, ErrDataTooBig) {
+		t.Errorf("large packet should fail with ErrDataTooBig, get %v", err)
+	}
+
+	r = NewReader(bytes.NewReader(packStream(ps, -1, 1)))
+	r.MaxPacketSize = 1024
+	for range ps[:len(ps)-1] {
+		if _, err := r.ReadPacket(-1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := r.ReadPacket(-1); !errors.Is(err, ErrPacketTooBig) {
+		t.Errorf("large packet should fail with ErrPacketTooBig, get %v", err)
+	}
+}
+
+func benchmarkStream(b *testing.B, threshold int, read func(r io.Reader, n int) error) {
+	ps := testPackets()
+	stream := packStream(ps, threshold, 16)
+	b.SetBytes(int64(len(stream)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := read(bytes.NewReader(stream), 16*len(ps)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func recvPackets(useZlib bool) func(r io.Reader, n int) error {
+	return func(r io.Reader, n int) error {
+		br := bufio.NewReader(r)
+		for i := 0; i < n; i++ {
+			if _, err := RecvPacket(br, useZlib); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func readPackets(threshold int) func(r io.Reader, n int) error {
+	return func(r io.Reader, n int) error {
+		pr := NewReader(bufio.NewReader(r))
+		for i := 0; i < n; i++ {
+			if _, err := pr.ReadPacket(threshold); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func BenchmarkRecvPacket(b *testing.B)             { benchmarkStream(b, -1, recvPackets(false)) }
+func BenchmarkReader_ReadPacket(b *testing.B)      { benchmarkStream(b, -1, readPackets(-1)) }
+func BenchmarkRecvPacket_zlib(b *testing.B)        { benchmarkStream(b, 256, recvPackets(true)) }
+func BenchmarkReader_ReadPacket_zlib(b *testing.B) { benchmarkStream(b, 256, readPackets(256)) }
@@ -414,7 +414,7 @@ func (b *ByteArray) Decode(r DecodeReader) error {
 		return err
 	}
 	*b = make([]byte, Len)
-	_, err := r.Read(*b)
+	_, err := io.ReadFull(r, *b)
 	return err
 }
 
@@ -429,6 +429,11 @@ func (u *UUID) Decode(r DecodeReader) error {
 	return err
 }
 
+// Encode an Angle
+func (a Angle) Encode() []byte {
+	return []byte{byte(a)}
+}
+
 // Decode an Angle
 func (a *Angle) Decode(r DecodeReader) error {
 	v, err := r.ReadByte()
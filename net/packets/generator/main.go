@@ -0,0 +1,339 @@
+// The generator reads the packet definitions of protocol 578 in data/generator/packets.txt,
+// which also generates the packet IDs of package data,
+// and generates the packet structs of package packets.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Tnze/go-mc/data"
+)
+
+const (
+	protocol = 578 // 1.15.2
+	input    = "../../data/generator/packets.txt"
+	output   = "packets_gen.go"
+)
+
+type field struct {
+	Name, Type string
+	Comment    string
+}
+
+type definition struct {
+	Name   string
+	Doc    []string
+	Fields []field
+
+	// only for packets
+	Packet    string
+	ID        byte
+	State     data.State
+	Direction data.Direction
+}
+
+var (
+	states = map[string]data.State{
+		"handshake": data.Handshaking,
+		"status":    data.Status,
+		"login":     data.Login,
+		"play":      data.Play,
+	}
+	directions = map[string]data.Direction{
+		"serverbound": data.Serverbound,
+		"clientbound": data.Clientbound,
+	}
+)
+
+func main() {
+	f, err := os.Open(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	defs, err := parse(bufio.NewScanner(f))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(defs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d definitions are written to %s", len(defs), filepath.Clean(output))
+}
+
+// parse the definitions file:
+//
+//	protocol 573 575 578     the versions sharing the packets below, only those including protocol are read
+//	[play clientbound]       a section of packets
+//	spawn_object SpawnObject a packet, which ID is its index in the section
+//	type Item                a struct used by the packets
+//		EntityID VarInt      a field of the packet or struct, can be followed by a "//" comment
+//	// Doc                   the doc comment of the next packet or struct
+func parse(s *bufio.Scanner) (defs []*definition, err error) {
+	var (
+		state   data.State
+		dir     data.Direction
+		current *definition
+		doc     []string
+		reading bool
+		ids     = make(map[[2]int]int) // the next ID of each section
+	)
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		comment := ""
+		if i := strings.Index(text, "//"); i >= 0 {
+			text, comment = text[:i], strings.TrimSpace(text[i+2:])
+		}
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			if comment != "" && text == "" {
+				doc = append(doc, comment)
+			}
+			continue
+		}
+		words := strings.Fields(text)
+		if words[0] == "protocol" {
+			reading = false
+			for _, w := range words[1:] {
+				reading = reading || w == strconv.Itoa(protocol)
+			}
+			current, doc = nil, nil
+			continue
+		}
+		if !reading {
+			doc = nil
+			continue
+		}
+		switch {
+		case text[0] == '\t': // field
+			if current == nil || len(words) != 2 {
+				return nil, fmt.Errorf("line %d: bad field", line)
+			}
+			current.Fields = append(current.Fields, field{Name: words[0], Type: words[1], Comment: comment})
+
+		case text[0] == '[': // section
+			var ok1, ok2 bool
+			words = strings.Fields(strings.Trim(text, "[] "))
+			if len(words) == 2 {
+				state, ok1 = states[words[0]]
+				dir, ok2 = directions[words[1]]
+			}
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("line %d: bad section %q", line, text)
+			}
+
+		case len(words) == 2 && words[0] == "type":
+			current = &definition{Name: words[1], Doc: doc}
+			defs = append(defs, current)
+			doc = nil
+
+		case len(words) == 1:
+			return nil, fmt.Errorf("line %d: packet %s has no struct", line, words[0])
+
+		case len(words) == 2: // packet
+			k := [2]int{int(state), int(dir)}
+			id := ids[k]
+			ids[k]++
+			current = &definition{
+				Name:      words[1],
+				Doc:       doc,
+				Packet:    words[0],
+				ID:        byte(id),
+				State:     state,
+				Direction: dir,
+			}
+			defs = append(defs, current)
+			doc = nil
+
+		default:
+			return nil, fmt.Errorf("line %d: cannot parse %q", line, text)
+		}
+	}
+	return defs, s.Err()
+}
+
+// goType return the Go type and the "mc" tag options of the type in definitions.
+func goType(t string) (string, []string, error) {
+	if i := strings.IndexByte(t, '<'); i > 0 && strings.HasSuffix(t, ">") {
+		elem, opts, err := goType(t[i+1 : len(t)-1])
+		if err != nil {
+			return "", nil, err
+		}
+		switch t[:i] {
+		case "Optional":
+			return "*" + elem, append([]string{"optional"}, opts...), nil
+		case "Array", "RestArray":
+			if len(opts) > 0 && (opts[0] == "prefixed-array" || opts[0] == "rest") {
+				return "", nil, fmt.Errorf("nested array %s is not supported", t)
+			}
+			opt := "prefixed-array"
+			if t[:i] == "RestArray" {
+				opt = "rest"
+			}
+			return "[]" + elem, append([]string{opt}, opts...), nil
+		}
+		return "", nil, fmt.Errorf("unknown type %s", t)
+	}
+
+	switch t {
+	case "Boolean":
+		return "bool", nil, nil
+	case "Byte":
+		return "int8", nil, nil
+	case "UByte":
+		return "uint8", nil, nil
+	case "Short":
+		return "int16", nil, nil
+	case "UShort":
+		return "uint16", nil, nil
+	case "Int":
+		return "int32", nil, nil
+	case "Long":
+		return "int64", nil, nil
+	case "Float":
+		return "float32", nil, nil
+	case "Double":
+		return "float64", nil, nil
+	case "String", "Chat", "Identifier":
+		return "string", nil, nil
+	case "VarInt":
+		return "int32", []string{"varint"}, nil
+	case "VarLong":
+		return "int64", []string{"varlong"}, nil
+	case "Position":
+		return "pk.Position", nil, nil
+	case "Angle":
+		return "pk.Angle", nil, nil
+	case "UUID":
+		return "uuid.UUID", nil, nil
+	case "ByteArray":
+		return "[]byte", nil, nil
+	case "Rest":
+		return "[]byte", []string{"rest"}, nil
+	case "NBT":
+		return "interface{}", []string{"nbt"}, nil
+	case "Slot":
+		return goType("Optional<Item>")
+	}
+	if t == "" || t[0] < 'A' || t[0] > 'Z' {
+		return "", nil, fmt.Errorf("unknown type %s", t)
+	}
+	return t, nil, nil // types in definitions
+}
+
+func generate(defs []*definition) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by "go run ./generator"; DO NOT EDIT.
+
+package packets
+
+import (
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+	"github.com/google/uuid"
+)
+
+`)
+	for _, d := range defs {
+		if d.Packet != "" {
+			fmt.Fprintf(&b, "// %s is the %s packet %s (0x%02X) in %s state.\n",
+				d.Name, d.Direction, d.Packet, d.ID, d.State)
+		} else if len(d.Doc) == 0 {
+			fmt.Fprintf(&b, "// %s is a structure used by the packets.\n", d.Name)
+		}
+		for _, l := range d.Doc {
+			fmt.Fprintf(&b, "// %s\n", l)
+		}
+		fmt.Fprintf(&b, "type %s struct {\n", d.Name)
+		for _, f := range d.Fields {
+			typ, opts, err := goType(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", d.Name, f.Name, err)
+			}
+			fmt.Fprintf(&b, "\t%s %s", f.Name, typ)
+			if len(opts) > 0 {
+				fmt.Fprintf(&b, " `mc:\"%s\"`", strings.Join(opts, ","))
+			}
+			if f.Comment != "" {
+				fmt.Fprintf(&b, " // %s", f.Comment)
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString("}\n\n")
+
+		if d.Packet != "" {
+			fmt.Fprintf(&b, "// ID return the packet ID of %s.\n", d.Name)
+			fmt.Fprintf(&b, "func (%s) ID() byte { return 0x%02X }\n\n", d.Name, d.ID)
+		}
+	}
+
+	// the registry used by Unmarshal
+	type key struct {
+		state data.State
+		dir   data.Direction
+	}
+	sections := make(map[key][]*definition)
+	var keys []key
+	for _, d := range defs {
+		if d.Packet == "" {
+			continue
+		}
+		k := key{d.State, d.Direction}
+		if _, ok := sections[k]; !ok {
+			keys = append(keys, k)
+		}
+		sections[k] = append(sections[k], d)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].state != keys[j].state {
+			return keys[i].state < keys[j].state
+		}
+		return keys[i].dir < keys[j].dir
+	})
+
+	stateNames := map[data.State]string{
+		data.Handshaking: "data.Handshaking",
+		data.Status:      "data.Status",
+		data.Login:       "data.Login",
+		data.Play:        "data.Play",
+	}
+	dirNames := map[data.Direction]string{
+		data.Serverbound: "data.Serverbound",
+		data.Clientbound: "data.Clientbound",
+	}
+	b.WriteString("var registry = map[data.State]map[data.Direction]map[byte]func() Packet{\n")
+	for i, k := range keys {
+		if i == 0 || keys[i-1].state != k.state {
+			fmt.Fprintf(&b, "%s: {\n", stateNames[k.state])
+		}
+		fmt.Fprintf(&b, "%s: {\n", dirNames[k.dir])
+		for _, d := range sections[k] {
+			fmt.Fprintf(&b, "0x%02X: func() Packet { return new(%s) },\n", d.ID, d.Name)
+		}
+		b.WriteString("},\n")
+		if i == len(keys)-1 || keys[i+1].state != k.state {
+			b.WriteString("},\n")
+		}
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
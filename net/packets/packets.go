@@ -0,0 +1,43 @@
+// Package packets defines the packets of Minecraft 1.15.2 as structs,
+// which are encoded and decoded by packet.MarshalStruct and packet.Packet.Unmarshal.
+//
+// The structs are generated from data/generator/packets.txt, which also generates the packet IDs of package data.
+// The fields depending on the value of others are kept as raw bytes in a field named Data.
+package packets
+
+//go:generate go run ./generator
+
+import (
+	"fmt"
+
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// Protocol is the protocol version of the packets in this package.
+const Protocol = 578
+
+// Packet is implemented by all packet structs.
+type Packet interface {
+	// ID return the packet ID in protocol 578.
+	ID() byte
+}
+
+// Marshal encode the packet struct into a Packet.
+func Marshal(p Packet) (pk.Packet, error) {
+	return pk.MarshalStruct(p.ID(), p)
+}
+
+// Unmarshal decode the packet received in the state from the direction.
+// The returned value is a pointer to the packet struct, eg. *KeepAliveClientbound.
+func Unmarshal(state data.State, dir data.Direction, p pk.Packet) (Packet, error) {
+	newPacket, ok := registry[state][dir][p.ID]
+	if !ok {
+		return nil, fmt.Errorf("packets: %w in %v %v: 0x%02X", data.ErrIllegalPacket, dir, state, p.ID)
+	}
+	v := newPacket()
+	if err := p.Unmarshal(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,1783 @@
+// Code generated by "go run ./generator"; DO NOT EDIT.
+
+package packets
+
+import (
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+	"github.com/google/uuid"
+)
+
+// Item is the data of a slot, the empty slots are nil.
+type Item struct {
+	ItemID int32 `mc:"varint"`
+	Count  int8
+	NBT    interface{} `mc:"nbt"`
+}
+
+// Handshake is the serverbound packet handshake (0x00) in handshake state.
+type Handshake struct {
+	ProtocolVersion int32 `mc:"varint"`
+	ServerAddress   string
+	ServerPort      uint16
+	NextState       int32 `mc:"varint"`
+}
+
+// ID return the packet ID of Handshake.
+func (Handshake) ID() byte { return 0x00 }
+
+// Response is the clientbound packet response (0x00) in status state.
+type Response struct {
+	JSONResponse string
+}
+
+// ID return the packet ID of Response.
+func (Response) ID() byte { return 0x00 }
+
+// Pong is the clientbound packet pong (0x01) in status state.
+type Pong struct {
+	Payload int64
+}
+
+// ID return the packet ID of Pong.
+func (Pong) ID() byte { return 0x01 }
+
+// Request is the serverbound packet request (0x00) in status state.
+type Request struct {
+}
+
+// ID return the packet ID of Request.
+func (Request) ID() byte { return 0x00 }
+
+// Ping is the serverbound packet ping (0x01) in status state.
+type Ping struct {
+	Payload int64
+}
+
+// ID return the packet ID of Ping.
+func (Ping) ID() byte { return 0x01 }
+
+// DisconnectLogin is the clientbound packet disconnect (0x00) in login state.
+type DisconnectLogin struct {
+	Reason string
+}
+
+// ID return the packet ID of DisconnectLogin.
+func (DisconnectLogin) ID() byte { return 0x00 }
+
+// EncryptionRequest is the clientbound packet encryption_request (0x01) in login state.
+type EncryptionRequest struct {
+	ServerID    string
+	PublicKey   []byte
+	VerifyToken []byte
+}
+
+// ID return the packet ID of EncryptionRequest.
+func (EncryptionRequest) ID() byte { return 0x01 }
+
+// LoginSuccess is the clientbound packet login_success (0x02) in login state.
+type LoginSuccess struct {
+	UUID     string // with hyphens
+	Username string
+}
+
+// ID return the packet ID of LoginSuccess.
+func (LoginSuccess) ID() byte { return 0x02 }
+
+// SetCompression is the clientbound packet set_compression (0x03) in login state.
+type SetCompression struct {
+	Threshold int32 `mc:"varint"`
+}
+
+// ID return the packet ID of SetCompression.
+func (SetCompression) ID() byte { return 0x03 }
+
+// LoginPluginRequest is the clientbound packet login_plugin_request (0x04) in login state.
+type LoginPluginRequest struct {
+	MessageID int32 `mc:"varint"`
+	Channel   string
+	Data      []byte `mc:"rest"`
+}
+
+// ID return the packet ID of LoginPluginRequest.
+func (LoginPluginRequest) ID() byte { return 0x04 }
+
+// LoginStart is the serverbound packet login_start (0x00) in login state.
+type LoginStart struct {
+	Name string
+}
+
+// ID return the packet ID of LoginStart.
+func (LoginStart) ID() byte { return 0x00 }
+
+// EncryptionResponse is the serverbound packet encryption_response (0x01) in login state.
+type EncryptionResponse struct {
+	SharedSecret []byte
+	VerifyToken  []byte
+}
+
+// ID return the packet ID of EncryptionResponse.
+func (EncryptionResponse) ID() byte { return 0x01 }
+
+// LoginPluginResponse is the serverbound packet login_plugin_response (0x02) in login state.
+type LoginPluginResponse struct {
+	MessageID  int32 `mc:"varint"`
+	Successful bool
+	Data       []byte `mc:"rest"`
+}
+
+// ID return the packet ID of LoginPluginResponse.
+func (LoginPluginResponse) ID() byte { return 0x02 }
+
+// SpawnObject is the clientbound packet spawn_object (0x00) in play state.
+type SpawnObject struct {
+	EntityID   int32 `mc:"varint"`
+	ObjectUUID uuid.UUID
+	Type       int32 `mc:"varint"`
+	X          float64
+	Y          float64
+	Z          float64
+	Pitch      pk.Angle
+	Yaw        pk.Angle
+	Data       int32
+	VelocityX  int16
+	VelocityY  int16
+	VelocityZ  int16
+}
+
+// ID return the packet ID of SpawnObject.
+func (SpawnObject) ID() byte { return 0x00 }
+
+// SpawnExperienceOrb is the clientbound packet spawn_experience_orb (0x01) in play state.
+type SpawnExperienceOrb struct {
+	EntityID int32 `mc:"varint"`
+	X        float64
+	Y        float64
+	Z        float64
+	Count    int16
+}
+
+// ID return the packet ID of SpawnExperienceOrb.
+func (SpawnExperienceOrb) ID() byte { return 0x01 }
+
+// SpawnGlobalEntity is the clientbound packet spawn_global_entity (0x02) in play state.
+type SpawnGlobalEntity struct {
+	EntityID int32 `mc:"varint"`
+	Type     int8
+	X        float64
+	Y        float64
+	Z        float64
+}
+
+// ID return the packet ID of SpawnGlobalEntity.
+func (SpawnGlobalEntity) ID() byte { return 0x02 }
+
+// SpawnMob is the clientbound packet spawn_mob (0x03) in play state.
+type SpawnMob struct {
+	EntityID   int32 `mc:"varint"`
+	EntityUUID uuid.UUID
+	Type       int32 `mc:"varint"`
+	X          float64
+	Y          float64
+	Z          float64
+	Yaw        pk.Angle
+	Pitch      pk.Angle
+	HeadPitch  pk.Angle
+	VelocityX  int16
+	VelocityY  int16
+	VelocityZ  int16
+}
+
+// ID return the packet ID of SpawnMob.
+func (SpawnMob) ID() byte { return 0x03 }
+
+// SpawnPainting is the clientbound packet spawn_painting (0x04) in play state.
+type SpawnPainting struct {
+	EntityID   int32 `mc:"varint"`
+	EntityUUID uuid.UUID
+	Motive     int32 `mc:"varint"`
+	Location   pk.Position
+	Direction  int8
+}
+
+// ID return the packet ID of SpawnPainting.
+func (SpawnPainting) ID() byte { return 0x04 }
+
+// SpawnPlayer is the clientbound packet spawn_player (0x05) in play state.
+type SpawnPlayer struct {
+	EntityID   int32 `mc:"varint"`
+	PlayerUUID uuid.UUID
+	X          float64
+	Y          float64
+	Z          float64
+	Yaw        pk.Angle
+	Pitch      pk.Angle
+}
+
+// ID return the packet ID of SpawnPlayer.
+func (SpawnPlayer) ID() byte { return 0x05 }
+
+// AnimationClientbound is the clientbound packet animation (0x06) in play state.
+type AnimationClientbound struct {
+	EntityID  int32 `mc:"varint"`
+	Animation uint8
+}
+
+// ID return the packet ID of AnimationClientbound.
+func (AnimationClientbound) ID() byte { return 0x06 }
+
+// Statistics is the clientbound packet statistics (0x07) in play state.
+type Statistics struct {
+	Statistics []Statistic `mc:"prefixed-array"`
+}
+
+// ID return the packet ID of Statistics.
+func (Statistics) ID() byte { return 0x07 }
+
+// AcknowledgePlayerDigging is the clientbound packet acknowledge_player_digging (0x08) in play state.
+type AcknowledgePlayerDigging struct {
+	Location   pk.Position
+	Block      int32 `mc:"varint"`
+	Status     int32 `mc:"varint"`
+	Successful bool
+}
+
+// ID return the packet ID of AcknowledgePlayerDigging.
+func (AcknowledgePlayerDigging) ID() byte { return 0x08 }
+
+// BlockBreakAnimation is the clientbound packet block_break_animation (0x09) in play state.
+type BlockBreakAnimation struct {
+	EntityID     int32 `mc:"varint"`
+	Location     pk.Position
+	DestroyStage int8
+}
+
+// ID return the packet ID of BlockBreakAnimation.
+func (BlockBreakAnimation) ID() byte { return 0x09 }
+
+// UpdateBlockEntity is the clientbound packet update_block_entity (0x0A) in play state.
+type UpdateBlockEntity struct {
+	Location pk.Position
+	Action   uint8
+	NBTData  interface{} `mc:"nbt"`
+}
+
+// ID return the packet ID of UpdateBlockEntity.
+func (UpdateBlockEntity) ID() byte { return 0x0A }
+
+// BlockAction is the clientbound packet block_action (0x0B) in play state.
+type BlockAction struct {
+	Location    pk.Position
+	ActionID    uint8
+	ActionParam uint8
+	BlockType   int32 `mc:"varint"`
+}
+
+// ID return the packet ID of BlockAction.
+func (BlockAction) ID() byte { return 0x0B }
+
+// BlockChange is the clientbound packet block_change (0x0C) in play state.
+type BlockChange struct {
+	Location pk.Position
+	BlockID  int32 `mc:"varint"`
+}
+
+// ID return the packet ID of BlockChange.
+func (BlockChange) ID() byte { return 0x0C }
+
+// BossBar is the clientbound packet boss_bar (0x0D) in play state.
+type BossBar struct {
+	UUID   uuid.UUID
+	Action int32  `mc:"varint"`
+	Data   []byte `mc:"rest"` // depends on Action
+}
+
+// ID return the packet ID of BossBar.
+func (BossBar) ID() byte { return 0x0D }
+
+// ServerDifficulty is the clientbound packet server_difficulty (0x0E) in play state.
+type ServerDifficulty struct {
+	Difficulty uint8
+	Locked     bool
+}
+
+// ID return the packet ID of ServerDifficulty.
+func (ServerDifficulty) ID() byte { return 0x0E }
+
+// ChatMessageClientbound is the clientbound packet chat_message (0x0F) in play state.
+type ChatMessageClientbound struct {
+	JSONData string
+	Position int8
+}
+
+// ID return the packet ID of ChatMessageClientbound.
+func (ChatMessageClientbound) ID() byte { return 0x0F }
+
+// MultiBlockChange is the clientbound packet multi_block_change (0x10) in play state.
+type MultiBlockChange struct {
+	ChunkX  int32
+	ChunkZ  int32
+	Records []BlockRecord `mc:"prefixed-array"`
+}
+
+// ID return the packet ID of MultiBlockChange.
+func (MultiBlockChange) ID() byte { return 0x10 }
+
+// TabComplete is the clientbound packet tab_complete (0x11) in play state.
+type TabComplete struct {
+	TransactionID int32              `mc:"varint"`
+	Start         int32              `mc:"varint"`
+	Length        int32              `mc:"varint"`
+	Matches       []TabCompleteMatch `mc:"prefixed-array"`
+}
+
+// ID return the packet ID of TabComplete.
+func (TabComplete) ID() byte { return 0x11 }
+
+// DeclareCommands is the clientbound packet declare_commands (0x12) in play state.
+type DeclareCommands struct {
+	Data []byte `mc:"rest"` // nodes and the root index
+}
+
+// ID return the packet ID of DeclareCommands.
+func (DeclareCommands) ID() byte { return 0x12 }
+
+// ConfirmTransaction is the clientbound packet confirm_transaction (0x13) in play state.
+type ConfirmTransaction struct {
+	WindowID     int8
+	ActionNumber int16
+	Accepted     bool
+}
+
+// ID return the packet ID of ConfirmTransaction.
+func (ConfirmTransaction) ID() byte { return 0x13 }
+
+// CloseWindow is the clientbound packet close_window (0x14) in play state.
+type CloseWindow struct {
+	WindowID uint8
+}
+
+// ID return the packet ID of CloseWindow.
+func (CloseWindow) ID() byte { return 0x14 }
+
+// WindowItems is the clientbound packet window_items (0x15) in play state.
+type WindowItems struct {
+	WindowID uint8
+	Count    int16   // must be len(SlotData)
+	SlotData []*Item `mc:"rest,optional"`
+}
+
+// ID return the packet ID of WindowItems.
+func (WindowItems) ID() byte { return 0x15 }
+
+// WindowProperty is the clientbound packet window_property (0x16) in play state.
+type WindowProperty struct {
+	WindowID uint8
+	Property int16
+	Value    int16
+}
+
+// ID return the packet ID of WindowProperty.
+func (WindowProperty) ID() byte { return 0x16 }
+
+// SetSlot is the clientbound packet set_slot (0x17) in play state.
+type SetSlot struct {
+	WindowID int8
+	Slot     int16
+	SlotData *Item `mc:"optional"`
+}
+
+// ID return the packet ID of SetSlot.
+func (SetSlot) ID() byte { return 0x17 }
+
+// SetCooldown is the clientbound packet set_cooldown (0x18) in play state.
+type SetCooldown struct {
+	ItemID        int32 `mc:"varint"`
+	CooldownTicks int32 `mc:"varint"`
+}
+
+// ID return the packet ID of SetCooldown.
+func (SetCooldown) ID() byte { return 0x18 }
+
+// PluginMessageClientbound is the clientbound packet plugin_message (0x19) in play state.
+type PluginMessageClientbound struct {
+	Channel string
+	Data    []byte `mc:"rest"`
+}
+
+// ID return the packet ID of PluginMessageClientbound.
+func (PluginMessageClientbound) ID() byte { return 0x19 }
+
+// NamedSoundEffect is the clientbound packet named_sound_effect (0x1A) in play state.
+type NamedSoundEffect struct {
+	SoundName       string
+	SoundCategory   int32 `mc:"varint"`
+	EffectPositionX int32
+	EffectPositionY int32
+	EffectPositionZ int32
+	Volume          float32
+	Pitch           float32
+}
+
+// ID return the packet ID of NamedSoundEffect.
+func (NamedSoundEffect) ID() byte { return 0x1A }
+
+// DisconnectPlay is the clientbound packet disconnect (0x1B) in play state.
+type DisconnectPlay struct {
+	Reason string
+}
+
+// ID return the packet ID of DisconnectPlay.
+func (DisconnectPlay) ID() byte { return 0x1B }
+
+// EntityStatus is the clientbound packet entity_status (0x1C) in play state.
+type EntityStatus struct {
+	EntityID     int32
+	EntityStatus int8
+}
+
+// ID return the packet ID of EntityStatus.
+func (EntityStatus) ID() byte { return 0x1C }
+
+// Explosion is the clientbound packet explosion (0x1D) in play state.
+type Explosion struct {
+	X        float32
+	Y        float32
+	Z        float32
+	Strength float32
+	Data     []byte `mc:"rest"` // records and player motion
+}
+
+// ID return the packet ID of Explosion.
+func (Explosion) ID() byte { return 0x1D }
+
+// UnloadChunk is the clientbound packet unload_chunk (0x1E) in play state.
+type UnloadChunk struct {
+	ChunkX int32
+	ChunkZ int32
+}
+
+// ID return the packet ID of UnloadChunk.
+func (UnloadChunk) ID() byte { return 0x1E }
+
+// ChangeGameState is the clientbound packet change_game_state (0x1F) in play state.
+type ChangeGameState struct {
+	Reason uint8
+	Value  float32
+}
+
+// ID return the packet ID of ChangeGameState.
+func (ChangeGameState) ID() byte { return 0x1F }
+
+// OpenHorseWindow is the clientbound packet open_horse_window (0x20) in play state.
+type OpenHorseWindow struct {
+	WindowID      int8
+	NumberOfSlots int32 `mc:"varint"`
+	EntityID      int32
+}
+
+// ID return the packet ID of OpenHorseWindow.
+func (OpenHorseWindow) ID() byte { return 0x20 }
+
+// KeepAliveClientbound is the clientbound packet keep_alive (0x21) in play state.
+type KeepAliveClientbound struct {
+	KeepAliveID int64
+}
+
+// ID return the packet ID of KeepAliveClientbound.
+func (KeepAliveClientbound) ID() byte { return 0x21 }
+
+// ChunkData is the clientbound packet chunk_data (0x22) in play state.
+type ChunkData struct {
+	ChunkX         int32
+	ChunkZ         int32
+	FullChunk      bool
+	PrimaryBitMask int32       `mc:"varint"`
+	Heightmaps     interface{} `mc:"nbt"`
+	Data           []byte      `mc:"rest"` // biomes if FullChunk, data and block entities
+}
+
+// ID return the packet ID of ChunkData.
+func (ChunkData) ID() byte { return 0x22 }
+
+// Effect is the clientbound packet effect (0x23) in play state.
+type Effect struct {
+	EffectID              int32
+	Location              pk.Position
+	Data                  int32
+	DisableRelativeVolume bool
+}
+
+// ID return the packet ID of Effect.
+func (Effect) ID() byte { return 0x23 }
+
+// Particle is the clientbound packet particle (0x24) in play state.
+type Particle struct {
+	ParticleID    int32
+	LongDistance  bool
+	X             float64
+	Y             float64
+	Z             float64
+	OffsetX       float32
+	OffsetY       float32
+	OffsetZ       float32
+	ParticleData  float32
+	ParticleCount int32
+	Data          []byte `mc:"rest"` // depends on ParticleID
+}
+
+// ID return the packet ID of Particle.
+func (Particle) ID() byte { return 0x24 }
+
+// UpdateLight is the clientbound packet update_light (0x25) in play state.
+type UpdateLight struct {
+	ChunkX              int32    `mc:"varint"`
+	ChunkZ              int32    `mc:"varint"`
+	SkyLightMask        int32    `mc:"varint"`
+	BlockLightMask      int32    `mc:"varint"`
+	EmptySkyLightMask   int32    `mc:"varint"`
+	EmptyBlockLightMask int32    `mc:"varint"`
+	LightArrays         [][]byte `mc:"rest"` // sky light arrays, followed by block light arrays
+}
+
+// ID return the packet ID of UpdateLight.
+func (UpdateLight) ID() byte { return 0x25 }
+
+// JoinGame is the clientbound packet join_game (0x26) in play state.
+type JoinGame struct {
+	EntityID            int32
+	Gamemode            uint8
+	Dimension           int32
+	HashedSeed          int64
+	MaxPlayers          uint8
+	LevelType           string
+	ViewDistance        int32 `mc:"varint"`
+	ReducedDebugInfo    bool
+	EnableRespawnScreen bool
+}
+
+// ID return the packet ID of JoinGame.
+func (JoinGame) ID() byte { return 0x26 }
+
+// MapData is the clientbound packet map_data (0x27) in play state.
+type MapData struct {
+	MapID            int32 `mc:"varint"`
+	Scale            int8
+	TrackingPosition bool
+	Locked           bool
+	Icons            []MapIcon `mc:"prefixed-array"`
+	Columns          uint8
+	Data             []byte `mc:"rest"` // rows, X, Z and data if Columns > 0
+}
+
+// ID return the packet ID of MapData.
+func (MapData) ID() byte { return 0x27 }
+
+// TradeList is the clientbound packet trade_list (0x28) in play state.
+type TradeList struct {
+	WindowID int32  `mc:"varint"`
+	Data     []byte `mc:"rest"` // trades, villager level, experience and flags
+}
+
+// ID return the packet ID of TradeList.
+func (TradeList) ID() byte { return 0x28 }
+
+// EntityRelativeMove is the clientbound packet entity_relative_move (0x29) in play state.
+type EntityRelativeMove struct {
+	EntityID int32 `mc:"varint"`
+	DeltaX   int16
+	DeltaY   int16
+	DeltaZ   int16
+	OnGround bool
+}
+
+// ID return the packet ID of EntityRelativeMove.
+func (EntityRelativeMove) ID() byte { return 0x29 }
+
+// EntityLookAndRelativeMove is the clientbound packet entity_look_and_relative_move (0x2A) in play state.
+type EntityLookAndRelativeMove struct {
+	EntityID int32 `mc:"varint"`
+	DeltaX   int16
+	DeltaY   int16
+	DeltaZ   int16
+	Yaw      pk.Angle
+	Pitch    pk.Angle
+	OnGround bool
+}
+
+// ID return the packet ID of EntityLookAndRelativeMove.
+func (EntityLookAndRelativeMove) ID() byte { return 0x2A }
+
+// EntityLook is the clientbound packet entity_look (0x2B) in play state.
+type EntityLook struct {
+	EntityID int32 `mc:"varint"`
+	Yaw      pk.Angle
+	Pitch    pk.Angle
+	OnGround bool
+}
+
+// ID return the packet ID of EntityLook.
+func (EntityLook) ID() byte { return 0x2B }
+
+// Entity is the clientbound packet entity (0x2C) in play state.
+type Entity struct {
+	EntityID int32 `mc:"varint"`
+}
+
+// ID return the packet ID of Entity.
+func (Entity) ID() byte { return 0x2C }
+
+// VehicleMoveClientbound is the clientbound packet vehicle_move (0x2D) in play state.
+type VehicleMoveClientbound struct {
+	X     float64
+	Y     float64
+	Z     float64
+	Yaw   float32
+	Pitch float32
+}
+
+// ID return the packet ID of VehicleMoveClientbound.
+func (VehicleMoveClientbound) ID() byte { return 0x2D }
+
+// OpenBook is the clientbound packet open_book (0x2E) in play state.
+type OpenBook struct {
+	Hand int32 `mc:"varint"`
+}
+
+// ID return the packet ID of OpenBook.
+func (OpenBook) ID() byte { return 0x2E }
+
+// OpenWindow is the clientbound packet open_window (0x2F) in play state.
+type OpenWindow struct {
+	WindowID    int32 `mc:"varint"`
+	WindowType  int32 `mc:"varint"`
+	WindowTitle string
+}
+
+// ID return the packet ID of OpenWindow.
+func (OpenWindow) ID() byte { return 0x2F }
+
+// OpenSignEditor is the clientbound packet open_sign_editor (0x30) in play state.
+type OpenSignEditor struct {
+	Location pk.Position
+}
+
+// ID return the packet ID of OpenSignEditor.
+func (OpenSignEditor) ID() byte { return 0x30 }
+
+// CraftRecipeResponse is the clientbound packet craft_recipe_response (0x31) in play state.
+type CraftRecipeResponse struct {
+	WindowID int8
+	Recipe   string
+}
+
+// ID return the packet ID of CraftRecipeResponse.
+func (CraftRecipeResponse) ID() byte { return 0x31 }
+
+// PlayerAbilitiesClientbound is the clientbound packet player_abilities (0x32) in play state.
+type PlayerAbilitiesClientbound struct {
+	Flags               int8
+	FlyingSpeed         float32
+	FieldOfViewModifier float32
+}
+
+// ID return the packet ID of PlayerAbilitiesClientbound.
+func (PlayerAbilitiesClientbound) ID() byte { return 0x32 }
+
+// CombatEvent is the clientbound packet combat_event (0x33) in play state.
+type CombatEvent struct {
+	Event int32  `mc:"varint"`
+	Data  []byte `mc:"rest"` // depends on Event
+}
+
+// ID return the packet ID of CombatEvent.
+func (CombatEvent) ID() byte { return 0x33 }
+
+// PlayerInfo is the clientbound packet player_info (0x34) in play state.
+type PlayerInfo struct {
+	Action int32  `mc:"varint"`
+	Data   []byte `mc:"rest"` // number of players and the players, depends on Action
+}
+
+// ID return the packet ID of PlayerInfo.
+func (PlayerInfo) ID() byte { return 0x34 }
+
+// FacePlayer is the clientbound packet face_player (0x35) in play state.
+type FacePlayer struct {
+	FeetOrEyes int32 `mc:"varint"`
+	TargetX    float64
+	TargetY    float64
+	TargetZ    float64
+	Entity     *FacePlayerEntity `mc:"optional"`
+}
+
+// ID return the packet ID of FacePlayer.
+func (FacePlayer) ID() byte { return 0x35 }
+
+// PlayerPositionAndLookClientbound is the clientbound packet player_position_and_look (0x36) in play state.
+type PlayerPositionAndLookClientbound struct {
+	X          float64
+	Y          float64
+	Z          float64
+	Yaw        float32
+	Pitch      float32
+	Flags      int8
+	TeleportID int32 `mc:"varint"`
+}
+
+// ID return the packet ID of PlayerPositionAndLookClientbound.
+func (PlayerPositionAndLookClientbound) ID() byte { return 0x36 }
+
+// UnlockRecipes is the clientbound packet unlock_recipes (0x37) in play state.
+type UnlockRecipes struct {
+	Action                         int32 `mc:"varint"`
+	CraftingRecipeBookOpen         bool
+	CraftingRecipeBookFilterActive bool
+	SmeltingRecipeBookOpen         bool
+	SmeltingRecipeBookFilterActive bool
+	RecipeIDs                      []string `mc:"prefixed-array"`
+	Data                           []byte   `mc:"rest"` // the second array of recipe IDs if Action is 0
+}
+
+// ID return the packet ID of UnlockRecipes.
+func (UnlockRecipes) ID() byte { return 0x37 }
+
+// DestroyEntities is the clientbound packet destroy_entities (0x38) in play state.
+type DestroyEntities struct {
+	EntityIDs []int32 `mc:"prefixed-array,varint"`
+}
+
+// ID return the packet ID of DestroyEntities.
+func (DestroyEntities) ID() byte { return 0x38 }
+
+// RemoveEntityEffect is the clientbound packet remove_entity_effect (0x39) in play state.
+type RemoveEntityEffect struct {
+	EntityID int32 `mc:"varint"`
+	EffectID int8
+}
+
+// ID return the packet ID of RemoveEntityEffect.
+func (RemoveEntityEffect) ID() byte { return 0x39 }
+
+// ResourcePackSend is the clientbound packet resource_pack_send (0x3A) in play state.
+type ResourcePackSend struct {
+	URL  string
+	Hash string
+}
+
+// ID return the packet ID of ResourcePackSend.
+func (ResourcePackSend) ID() byte { return 0x3A }
+
+// Respawn is the clientbound packet respawn (0x3B) in play state.
+type Respawn struct {
+	Dimension  int32
+	HashedSeed int64
+	Gamemode   uint8
+	LevelType  string
+}
+
+// ID return the packet ID of Respawn.
+func (Respawn) ID() byte { return 0x3B }
+
+// EntityHeadLook is the clientbound packet entity_head_look (0x3C) in play state.
+type EntityHeadLook struct {
+	EntityID int32 `mc:"varint"`
+	HeadYaw  pk.Angle
+}
+
+// ID return the packet ID of EntityHeadLook.
+func (EntityHeadLook) ID() byte { return 0x3C }
+
+// SelectAdvancementTab is the clientbound packet select_advancement_tab (0x3D) in play state.
+type SelectAdvancementTab struct {
+	Identifier *string `mc:"optional"`
+}
+
+// ID return the packet ID of SelectAdvancementTab.
+func (SelectAdvancementTab) ID() byte { return 0x3D }
+
+// WorldBorder is the clientbound packet world_border (0x3E) in play state.
+type WorldBorder struct {
+	Action int32  `mc:"varint"`
+	Data   []byte `mc:"rest"` // depends on Action
+}
+
+// ID return the packet ID of WorldBorder.
+func (WorldBorder) ID() byte { return 0x3E }
+
+// Camera is the clientbound packet camera (0x3F) in play state.
+type Camera struct {
+	CameraID int32 `mc:"varint"`
+}
+
+// ID return the packet ID of Camera.
+func (Camera) ID() byte { return 0x3F }
+
+// HeldItemChangeClientbound is the clientbound packet held_item_change (0x40) in play state.
+type HeldItemChangeClientbound struct {
+	Slot int8
+}
+
+// ID return the packet ID of HeldItemChangeClientbound.
+func (HeldItemChangeClientbound) ID() byte { return 0x40 }
+
+// UpdateViewPosition is the clientbound packet update_view_position (0x41) in play state.
+type UpdateViewPosition struct {
+	ChunkX int32 `mc:"varint"`
+	ChunkZ int32 `mc:"varint"`
+}
+
+// ID return the packet ID of UpdateViewPosition.
+func (UpdateViewPosition) ID() byte { return 0x41 }
+
+// UpdateViewDistance is the clientbound packet update_view_distance (0x42) in play state.
+type UpdateViewDistance struct {
+	ViewDistance int32 `mc:"varint"`
+}
+
+// ID return the packet ID of UpdateViewDistance.
+func (UpdateViewDistance) ID() byte { return 0x42 }
+
+// DisplayScoreboard is the clientbound packet display_scoreboard (0x43) in play state.
+type DisplayScoreboard struct {
+	Position  int8
+	ScoreName string
+}
+
+// ID return the packet ID of DisplayScoreboard.
+func (DisplayScoreboard) ID() byte { return 0x43 }
+
+// EntityMetadata is the clientbound packet entity_metadata (0x44) in play state.
+type EntityMetadata struct {
+	EntityID int32  `mc:"varint"`
+	Metadata []byte `mc:"rest"`
+}
+
+// ID return the packet ID of EntityMetadata.
+func (EntityMetadata) ID() byte { return 0x44 }
+
+// AttachEntity is the clientbound packet attach_entity (0x45) in play state.
+type AttachEntity struct {
+	AttachedEntityID int32
+	HoldingEntityID  int32
+}
+
+// ID return the packet ID of AttachEntity.
+func (AttachEntity) ID() byte { return 0x45 }
+
+// EntityVelocity is the clientbound packet entity_velocity (0x46) in play state.
+type EntityVelocity struct {
+	EntityID  int32 `mc:"varint"`
+	VelocityX int16
+	VelocityY int16
+	VelocityZ int16
+}
+
+// ID return the packet ID of EntityVelocity.
+func (EntityVelocity) ID() byte { return 0x46 }
+
+// EntityEquipment is the clientbound packet entity_equipment (0x47) in play state.
+type EntityEquipment struct {
+	EntityID int32 `mc:"varint"`
+	Slot     int32 `mc:"varint"`
+	Item     *Item `mc:"optional"`
+}
+
+// ID return the packet ID of EntityEquipment.
+func (EntityEquipment) ID() byte { return 0x47 }
+
+// SetExperience is the clientbound packet set_experience (0x48) in play state.
+type SetExperience struct {
+	ExperienceBar   float32
+	Level           int32 `mc:"varint"`
+	TotalExperience int32 `mc:"varint"`
+}
+
+// ID return the packet ID of SetExperience.
+func (SetExperience) ID() byte { return 0x48 }
+
+// UpdateHealth is the clientbound packet update_health (0x49) in play state.
+type UpdateHealth struct {
+	Health         float32
+	Food           int32 `mc:"varint"`
+	FoodSaturation float32
+}
+
+// ID return the packet ID of UpdateHealth.
+func (UpdateHealth) ID() byte { return 0x49 }
+
+// ScoreboardObjective is the clientbound packet scoreboard_objective (0x4A) in play state.
+type ScoreboardObjective struct {
+	ObjectiveName string
+	Mode          int8
+	Data          []byte `mc:"rest"` // value and type if Mode is 0 or 2
+}
+
+// ID return the packet ID of ScoreboardObjective.
+func (ScoreboardObjective) ID() byte { return 0x4A }
+
+// SetPassengers is the clientbound packet set_passengers (0x4B) in play state.
+type SetPassengers struct {
+	EntityID   int32   `mc:"varint"`
+	Passengers []int32 `mc:"prefixed-array,varint"`
+}
+
+// ID return the packet ID of SetPassengers.
+func (SetPassengers) ID() byte { return 0x4B }
+
+// Teams is the clientbound packet teams (0x4C) in play state.
+type Teams struct {
+	TeamName string
+	Mode     int8
+	Data     []byte `mc:"rest"` // depends on Mode
+}
+
+// ID return the packet ID of Teams.
+func (Teams) ID() byte { return 0x4C }
+
+// UpdateScore is the clientbound packet update_score (0x4D) in play state.
+type UpdateScore struct {
+	EntityName    string
+	Action        int8
+	ObjectiveName string
+	Data          []byte `mc:"rest"` // value if Action isn't 1
+}
+
+// ID return the packet ID of UpdateScore.
+func (UpdateScore) ID() byte { return 0x4D }
+
+// SpawnPosition is the clientbound packet spawn_position (0x4E) in play state.
+type SpawnPosition struct {
+	Location pk.Position
+}
+
+// ID return the packet ID of SpawnPosition.
+func (SpawnPosition) ID() byte { return 0x4E }
+
+// TimeUpdate is the clientbound packet time_update (0x4F) in play state.
+type TimeUpdate struct {
+	WorldAge  int64
+	TimeOfDay int64
+}
+
+// ID return the packet ID of TimeUpdate.
+func (TimeUpdate) ID() byte { return 0x4F }
+
+// Title is the clientbound packet title (0x50) in play state.
+type Title struct {
+	Action int32  `mc:"varint"`
+	Data   []byte `mc:"rest"` // depends on Action
+}
+
+// ID return the packet ID of Title.
+func (Title) ID() byte { return 0x50 }
+
+// EntitySoundEffect is the clientbound packet entity_sound_effect (0x51) in play state.
+type EntitySoundEffect struct {
+	SoundID       int32 `mc:"varint"`
+	SoundCategory int32 `mc:"varint"`
+	EntityID      int32 `mc:"varint"`
+	Volume        float32
+	Pitch         float32
+}
+
+// ID return the packet ID of EntitySoundEffect.
+func (EntitySoundEffect) ID() byte { return 0x51 }
+
+// SoundEffect is the clientbound packet sound_effect (0x52) in play state.
+type SoundEffect struct {
+	SoundID         int32 `mc:"varint"`
+	SoundCategory   int32 `mc:"varint"`
+	EffectPositionX int32
+	EffectPositionY int32
+	EffectPositionZ int32
+	Volume          float32
+	Pitch           float32
+}
+
+// ID return the packet ID of SoundEffect.
+func (SoundEffect) ID() byte { return 0x52 }
+
+// StopSound is the clientbound packet stop_sound (0x53) in play state.
+type StopSound struct {
+	Flags int8
+	Data  []byte `mc:"rest"` // source and sound, depends on Flags
+}
+
+// ID return the packet ID of StopSound.
+func (StopSound) ID() byte { return 0x53 }
+
+// PlayerListHeaderAndFooter is the clientbound packet player_list_header_and_footer (0x54) in play state.
+type PlayerListHeaderAndFooter struct {
+	Header string
+	Footer string
+}
+
+// ID return the packet ID of PlayerListHeaderAndFooter.
+func (PlayerListHeaderAndFooter) ID() byte { return 0x54 }
+
+// NBTQueryResponse is the clientbound packet nbt_query_response (0x55) in play state.
+type NBTQueryResponse struct {
+	TransactionID int32       `mc:"varint"`
+	NBT           interface{} `mc:"nbt"`
+}
+
+// ID return the packet ID of NBTQueryResponse.
+func (NBTQueryResponse) ID() byte { return 0x55 }
+
+// CollectItem is the clientbound packet collect_item (0x56) in play state.
+type CollectItem struct {
+	CollectedEntityID int32 `mc:"varint"`
+	CollectorEntityID int32 `mc:"varint"`
+	PickupItemCount   int32 `mc:"varint"`
+}
+
+// ID return the packet ID of CollectItem.
+func (CollectItem) ID() byte { return 0x56 }
+
+// EntityTeleport is the clientbound packet entity_teleport (0x57) in play state.
+type EntityTeleport struct {
+	EntityID int32 `mc:"varint"`
+	X        float64
+	Y        float64
+	Z        float64
+	Yaw      pk.Angle
+	Pitch    pk.Angle
+	OnGround bool
+}
+
+// ID return the packet ID of EntityTeleport.
+func (EntityTeleport) ID() byte { return 0x57 }
+
+// Advancements is the clientbound packet advancements (0x58) in play state.
+type Advancements struct {
+	ResetClear bool
+	Data       []byte `mc:"rest"` // advancements and progress
+}
+
+// ID return the packet ID of Advancements.
+func (Advancements) ID() byte { return 0x58 }
+
+// EntityProperties is the clientbound packet entity_properties (0x59) in play state.
+type EntityProperties struct {
+	EntityID           int32            `mc:"varint"`
+	NumberOfProperties int32            // must be len(Properties)
+	Properties         []EntityProperty `mc:"rest"`
+}
+
+// ID return the packet ID of EntityProperties.
+func (EntityProperties) ID() byte { return 0x59 }
+
+// EntityEffect is the clientbound packet entity_effect (0x5A) in play state.
+type EntityEffect struct {
+	EntityID  int32 `mc:"varint"`
+	EffectID  int8
+	Amplifier int8
+	Duration  int32 `mc:"varint"`
+	Flags     int8
+}
+
+// ID return the packet ID of EntityEffect.
+func (EntityEffect) ID() byte { return 0x5A }
+
+// DeclareRecipes is the clientbound packet declare_recipes (0x5B) in play state.
+type DeclareRecipes struct {
+	Data []byte `mc:"rest"` // number of recipes and the recipes
+}
+
+// ID return the packet ID of DeclareRecipes.
+func (DeclareRecipes) ID() byte { return 0x5B }
+
+// Tags is the clientbound packet tags (0x5C) in play state.
+type Tags struct {
+	BlockTags  []Tag `mc:"prefixed-array"`
+	ItemTags   []Tag `mc:"prefixed-array"`
+	FluidTags  []Tag `mc:"prefixed-array"`
+	EntityTags []Tag `mc:"prefixed-array"`
+}
+
+// ID return the packet ID of Tags.
+func (Tags) ID() byte { return 0x5C }
+
+// Statistic is a structure used by the packets.
+type Statistic struct {
+	CategoryID  int32 `mc:"varint"`
+	StatisticID int32 `mc:"varint"`
+	Value       int32 `mc:"varint"`
+}
+
+// BlockRecord is a block changed by MultiBlockChange.
+type BlockRecord struct {
+	HorizontalPosition uint8 // X in the high 4 bits, Z in the low 4 bits
+	Y                  uint8
+	BlockID            int32 `mc:"varint"`
+}
+
+// TabCompleteMatch is a structure used by the packets.
+type TabCompleteMatch struct {
+	Match   string
+	Tooltip *string `mc:"optional"`
+}
+
+// MapIcon is a structure used by the packets.
+type MapIcon struct {
+	Type        int32 `mc:"varint"`
+	X           int8
+	Z           int8
+	Direction   int8
+	DisplayName *string `mc:"optional"`
+}
+
+// FacePlayerEntity is a structure used by the packets.
+type FacePlayerEntity struct {
+	EntityID         int32 `mc:"varint"`
+	EntityFeetOrEyes int32 `mc:"varint"`
+}
+
+// EntityProperty is a structure used by the packets.
+type EntityProperty struct {
+	Key       string
+	Value     float64
+	Modifiers []AttributeModifier `mc:"prefixed-array"`
+}
+
+// AttributeModifier is a structure used by the packets.
+type AttributeModifier struct {
+	UUID      uuid.UUID
+	Amount    float64
+	Operation int8
+}
+
+// Tag is a tag of blocks, items, fluids or entities.
+type Tag struct {
+	Name    string
+	Entries []int32 `mc:"prefixed-array,varint"`
+}
+
+// TeleportConfirm is the serverbound packet teleport_confirm (0x00) in play state.
+type TeleportConfirm struct {
+	TeleportID int32 `mc:"varint"`
+}
+
+// ID return the packet ID of TeleportConfirm.
+func (TeleportConfirm) ID() byte { return 0x00 }
+
+// QueryBlockNBT is the serverbound packet query_block_nbt (0x01) in play state.
+type QueryBlockNBT struct {
+	TransactionID int32 `mc:"varint"`
+	Location      pk.Position
+}
+
+// ID return the packet ID of QueryBlockNBT.
+func (QueryBlockNBT) ID() byte { return 0x01 }
+
+// SetDifficulty is the serverbound packet set_difficulty (0x02) in play state.
+type SetDifficulty struct {
+	NewDifficulty int8
+}
+
+// ID return the packet ID of SetDifficulty.
+func (SetDifficulty) ID() byte { return 0x02 }
+
+// ChatMessageServerbound is the serverbound packet chat_message (0x03) in play state.
+type ChatMessageServerbound struct {
+	Message string
+}
+
+// ID return the packet ID of ChatMessageServerbound.
+func (ChatMessageServerbound) ID() byte { return 0x03 }
+
+// ClientStatus is the serverbound packet client_status (0x04) in play state.
+type ClientStatus struct {
+	ActionID int32 `mc:"varint"`
+}
+
+// ID return the packet ID of ClientStatus.
+func (ClientStatus) ID() byte { return 0x04 }
+
+// ClientSettings is the serverbound packet client_settings (0x05) in play state.
+type ClientSettings struct {
+	Locale             string
+	ViewDistance       int8
+	ChatMode           int32 `mc:"varint"`
+	ChatColors         bool
+	DisplayedSkinParts uint8
+	MainHand           int32 `mc:"varint"`
+}
+
+// ID return the packet ID of ClientSettings.
+func (ClientSettings) ID() byte { return 0x05 }
+
+// TabCompleteServerbound is the serverbound packet tab_complete (0x06) in play state.
+type TabCompleteServerbound struct {
+	TransactionID int32 `mc:"varint"`
+	Text          string
+}
+
+// ID return the packet ID of TabCompleteServerbound.
+func (TabCompleteServerbound) ID() byte { return 0x06 }
+
+// ConfirmTransactionServerbound is the serverbound packet confirm_transaction (0x07) in play state.
+type ConfirmTransactionServerbound struct {
+	WindowID     int8
+	ActionNumber int16
+	Accepted     bool
+}
+
+// ID return the packet ID of ConfirmTransactionServerbound.
+func (ConfirmTransactionServerbound) ID() byte { return 0x07 }
+
+// ClickWindowButton is the serverbound packet click_window_button (0x08) in play state.
+type ClickWindowButton struct {
+	WindowID int8
+	ButtonID int8
+}
+
+// ID return the packet ID of ClickWindowButton.
+func (ClickWindowButton) ID() byte { return 0x08 }
+
+// ClickWindow is the serverbound packet click_window (0x09) in play state.
+type ClickWindow struct {
+	WindowID     uint8
+	Slot         int16
+	Button       int8
+	ActionNumber int16
+	Mode         int32 `mc:"varint"`
+	ClickedItem  *Item `mc:"optional"`
+}
+
+// ID return the packet ID of ClickWindow.
+func (ClickWindow) ID() byte { return 0x09 }
+
+// CloseWindowServerbound is the serverbound packet close_window (0x0A) in play state.
+type CloseWindowServerbound struct {
+	WindowID uint8
+}
+
+// ID return the packet ID of CloseWindowServerbound.
+func (CloseWindowServerbound) ID() byte { return 0x0A }
+
+// PluginMessageServerbound is the serverbound packet plugin_message (0x0B) in play state.
+type PluginMessageServerbound struct {
+	Channel string
+	Data    []byte `mc:"rest"`
+}
+
+// ID return the packet ID of PluginMessageServerbound.
+func (PluginMessageServerbound) ID() byte { return 0x0B }
+
+// EditBook is the serverbound packet edit_book (0x0C) in play state.
+type EditBook struct {
+	NewBook   *Item `mc:"optional"`
+	IsSigning bool
+	Hand      int32 `mc:"varint"`
+}
+
+// ID return the packet ID of EditBook.
+func (EditBook) ID() byte { return 0x0C }
+
+// QueryEntityNBT is the serverbound packet query_entity_nbt (0x0D) in play state.
+type QueryEntityNBT struct {
+	TransactionID int32 `mc:"varint"`
+	EntityID      int32 `mc:"varint"`
+}
+
+// ID return the packet ID of QueryEntityNBT.
+func (QueryEntityNBT) ID() byte { return 0x0D }
+
+// UseEntity is the serverbound packet use_entity (0x0E) in play state.
+type UseEntity struct {
+	Target int32  `mc:"varint"`
+	Type   int32  `mc:"varint"`
+	Data   []byte `mc:"rest"` // target position if Type is 2, hand if Type is 0 or 2
+}
+
+// ID return the packet ID of UseEntity.
+func (UseEntity) ID() byte { return 0x0E }
+
+// KeepAliveServerbound is the serverbound packet keep_alive (0x0F) in play state.
+type KeepAliveServerbound struct {
+	KeepAliveID int64
+}
+
+// ID return the packet ID of KeepAliveServerbound.
+func (KeepAliveServerbound) ID() byte { return 0x0F }
+
+// LockDifficulty is the serverbound packet lock_difficulty (0x10) in play state.
+type LockDifficulty struct {
+	Locked bool
+}
+
+// ID return the packet ID of LockDifficulty.
+func (LockDifficulty) ID() byte { return 0x10 }
+
+// PlayerPosition is the serverbound packet player_position (0x11) in play state.
+type PlayerPosition struct {
+	X        float64
+	FeetY    float64
+	Z        float64
+	OnGround bool
+}
+
+// ID return the packet ID of PlayerPosition.
+func (PlayerPosition) ID() byte { return 0x11 }
+
+// PlayerPositionAndLookServerbound is the serverbound packet player_position_and_look (0x12) in play state.
+type PlayerPositionAndLookServerbound struct {
+	X        float64
+	FeetY    float64
+	Z        float64
+	Yaw      float32
+	Pitch    float32
+	OnGround bool
+}
+
+// ID return the packet ID of PlayerPositionAndLookServerbound.
+func (PlayerPositionAndLookServerbound) ID() byte { return 0x12 }
+
+// PlayerLook is the serverbound packet player_look (0x13) in play state.
+type PlayerLook struct {
+	Yaw      float32
+	Pitch    float32
+	OnGround bool
+}
+
+// ID return the packet ID of PlayerLook.
+func (PlayerLook) ID() byte { return 0x13 }
+
+// Player is the serverbound packet player (0x14) in play state.
+type Player struct {
+	OnGround bool
+}
+
+// ID return the packet ID of Player.
+func (Player) ID() byte { return 0x14 }
+
+// VehicleMoveServerbound is the serverbound packet vehicle_move (0x15) in play state.
+type VehicleMoveServerbound struct {
+	X     float64
+	Y     float64
+	Z     float64
+	Yaw   float32
+	Pitch float32
+}
+
+// ID return the packet ID of VehicleMoveServerbound.
+func (VehicleMoveServerbound) ID() byte { return 0x15 }
+
+// SteerBoat is the serverbound packet steer_boat (0x16) in play state.
+type SteerBoat struct {
+	LeftPaddleTurning  bool
+	RightPaddleTurning bool
+}
+
+// ID return the packet ID of SteerBoat.
+func (SteerBoat) ID() byte { return 0x16 }
+
+// PickItem is the serverbound packet pick_item (0x17) in play state.
+type PickItem struct {
+	SlotToUse int32 `mc:"varint"`
+}
+
+// ID return the packet ID of PickItem.
+func (PickItem) ID() byte { return 0x17 }
+
+// CraftRecipeRequest is the serverbound packet craft_recipe_request (0x18) in play state.
+type CraftRecipeRequest struct {
+	WindowID int8
+	Recipe   string
+	MakeAll  bool
+}
+
+// ID return the packet ID of CraftRecipeRequest.
+func (CraftRecipeRequest) ID() byte { return 0x18 }
+
+// PlayerAbilitiesServerbound is the serverbound packet player_abilities (0x19) in play state.
+type PlayerAbilitiesServerbound struct {
+	Flags        int8
+	FlyingSpeed  float32
+	WalkingSpeed float32
+}
+
+// ID return the packet ID of PlayerAbilitiesServerbound.
+func (PlayerAbilitiesServerbound) ID() byte { return 0x19 }
+
+// PlayerDigging is the serverbound packet player_digging (0x1A) in play state.
+type PlayerDigging struct {
+	Status   int32 `mc:"varint"`
+	Location pk.Position
+	Face     int8
+}
+
+// ID return the packet ID of PlayerDigging.
+func (PlayerDigging) ID() byte { return 0x1A }
+
+// EntityAction is the serverbound packet entity_action (0x1B) in play state.
+type EntityAction struct {
+	EntityID  int32 `mc:"varint"`
+	ActionID  int32 `mc:"varint"`
+	JumpBoost int32 `mc:"varint"`
+}
+
+// ID return the packet ID of EntityAction.
+func (EntityAction) ID() byte { return 0x1B }
+
+// SteerVehicle is the serverbound packet steer_vehicle (0x1C) in play state.
+type SteerVehicle struct {
+	Sideways float32
+	Forward  float32
+	Flags    uint8
+}
+
+// ID return the packet ID of SteerVehicle.
+func (SteerVehicle) ID() byte { return 0x1C }
+
+// RecipeBookData is the serverbound packet recipe_book_data (0x1D) in play state.
+type RecipeBookData struct {
+	Type int32  `mc:"varint"`
+	Data []byte `mc:"rest"` // depends on Type
+}
+
+// ID return the packet ID of RecipeBookData.
+func (RecipeBookData) ID() byte { return 0x1D }
+
+// NameItem is the serverbound packet name_item (0x1E) in play state.
+type NameItem struct {
+	ItemName string
+}
+
+// ID return the packet ID of NameItem.
+func (NameItem) ID() byte { return 0x1E }
+
+// ResourcePackStatus is the serverbound packet resource_pack_status (0x1F) in play state.
+type ResourcePackStatus struct {
+	Result int32 `mc:"varint"`
+}
+
+// ID return the packet ID of ResourcePackStatus.
+func (ResourcePackStatus) ID() byte { return 0x1F }
+
+// AdvancementTab is the serverbound packet advancement_tab (0x20) in play state.
+type AdvancementTab struct {
+	Action int32  `mc:"varint"`
+	Data   []byte `mc:"rest"` // tab ID if Action is 0
+}
+
+// ID return the packet ID of AdvancementTab.
+func (AdvancementTab) ID() byte { return 0x20 }
+
+// SelectTrade is the serverbound packet select_trade (0x21) in play state.
+type SelectTrade struct {
+	SelectedSlot int32 `mc:"varint"`
+}
+
+// ID return the packet ID of SelectTrade.
+func (SelectTrade) ID() byte { return 0x21 }
+
+// SetBeaconEffect is the serverbound packet set_beacon_effect (0x22) in play state.
+type SetBeaconEffect struct {
+	PrimaryEffect   int32 `mc:"varint"`
+	SecondaryEffect int32 `mc:"varint"`
+}
+
+// ID return the packet ID of SetBeaconEffect.
+func (SetBeaconEffect) ID() byte { return 0x22 }
+
+// HeldItemChangeServerbound is the serverbound packet held_item_change (0x23) in play state.
+type HeldItemChangeServerbound struct {
+	Slot int16
+}
+
+// ID return the packet ID of HeldItemChangeServerbound.
+func (HeldItemChangeServerbound) ID() byte { return 0x23 }
+
+// UpdateCommandBlock is the serverbound packet update_command_block (0x24) in play state.
+type UpdateCommandBlock struct {
+	Location pk.Position
+	Command  string
+	Mode     int32 `mc:"varint"`
+	Flags    int8
+}
+
+// ID return the packet ID of UpdateCommandBlock.
+func (UpdateCommandBlock) ID() byte { return 0x24 }
+
+// UpdateCommandBlockMinecart is the serverbound packet update_command_block_minecart (0x25) in play state.
+type UpdateCommandBlockMinecart struct {
+	EntityID    int32 `mc:"varint"`
+	Command     string
+	TrackOutput bool
+}
+
+// ID return the packet ID of UpdateCommandBlockMinecart.
+func (UpdateCommandBlockMinecart) ID() byte { return 0x25 }
+
+// CreativeInventoryAction is the serverbound packet creative_inventory_action (0x26) in play state.
+type CreativeInventoryAction struct {
+	Slot        int16
+	ClickedItem *Item `mc:"optional"`
+}
+
+// ID return the packet ID of CreativeInventoryAction.
+func (CreativeInventoryAction) ID() byte { return 0x26 }
+
+// UpdateJigsawBlock is the serverbound packet update_jigsaw_block (0x27) in play state.
+type UpdateJigsawBlock struct {
+	Location       pk.Position
+	AttachmentType string
+	TargetPool     string
+	FinalState     string
+}
+
+// ID return the packet ID of UpdateJigsawBlock.
+func (UpdateJigsawBlock) ID() byte { return 0x27 }
+
+// UpdateStructureBlock is the serverbound packet update_structure_block (0x28) in play state.
+type UpdateStructureBlock struct {
+	Location  pk.Position
+	Action    int32 `mc:"varint"`
+	Mode      int32 `mc:"varint"`
+	Name      string
+	OffsetX   int8
+	OffsetY   int8
+	OffsetZ   int8
+	SizeX     int8
+	SizeY     int8
+	SizeZ     int8
+	Mirror    int32 `mc:"varint"`
+	Rotation  int32 `mc:"varint"`
+	Metadata  string
+	Integrity float32
+	Seed      int64 `mc:"varlong"`
+	Flags     int8
+}
+
+// ID return the packet ID of UpdateStructureBlock.
+func (UpdateStructureBlock) ID() byte { return 0x28 }
+
+// UpdateSign is the serverbound packet update_sign (0x29) in play state.
+type UpdateSign struct {
+	Location pk.Position
+	Line1    string
+	Line2    string
+	Line3    string
+	Line4    string
+}
+
+// ID return the packet ID of UpdateSign.
+func (UpdateSign) ID() byte { return 0x29 }
+
+// AnimationServerbound is the serverbound packet animation (0x2A) in play state.
+type AnimationServerbound struct {
+	Hand int32 `mc:"varint"`
+}
+
+// ID return the packet ID of AnimationServerbound.
+func (AnimationServerbound) ID() byte { return 0x2A }
+
+// Spectate is the serverbound packet spectate (0x2B) in play state.
+type Spectate struct {
+	TargetPlayer uuid.UUID
+}
+
+// ID return the packet ID of Spectate.
+func (Spectate) ID() byte { return 0x2B }
+
+// PlayerBlockPlacement is the serverbound packet player_block_placement (0x2C) in play state.
+type PlayerBlockPlacement struct {
+	Hand            int32 `mc:"varint"`
+	Location        pk.Position
+	Face            int32 `mc:"varint"`
+	CursorPositionX float32
+	CursorPositionY float32
+	CursorPositionZ float32
+	InsideBlock     bool
+}
+
+// ID return the packet ID of PlayerBlockPlacement.
+func (PlayerBlockPlacement) ID() byte { return 0x2C }
+
+// UseItem is the serverbound packet use_item (0x2D) in play state.
+type UseItem struct {
+	Hand int32 `mc:"varint"`
+}
+
+// ID return the packet ID of UseItem.
+func (UseItem) ID() byte { return 0x2D }
+
+var registry = map[data.State]map[data.Direction]map[byte]func() Packet{
+	data.Handshaking: {
+		data.Serverbound: {
+			0x00: func() Packet { return new(Handshake) },
+		},
+	},
+	data.Status: {
+		data.Serverbound: {
+			0x00: func() Packet { return new(Request) },
+			0x01: func() Packet { return new(Ping) },
+		},
+		data.Clientbound: {
+			0x00: func() Packet { return new(Response) },
+			0x01: func() Packet { return new(Pong) },
+		},
+	},
+	data.Login: {
+		data.Serverbound: {
+			0x00: func() Packet { return new(LoginStart) },
+			0x01: func() Packet { return new(EncryptionResponse) },
+			0x02: func() Packet { return new(LoginPluginResponse) },
+		},
+		data.Clientbound: {
+			0x00: func() Packet { return new(DisconnectLogin) },
+			0x01: func() Packet { return new(EncryptionRequest) },
+			0x02: func() Packet { return new(LoginSuccess) },
+			0x03: func() Packet { return new(SetCompression) },
+			0x04: func() Packet { return new(LoginPluginRequest) },
+		},
+	},
+	data.Play: {
+		data.Serverbound: {
+			0x00: func() Packet { return new(TeleportConfirm) },
+			0x01: func() Packet { return new(QueryBlockNBT) },
+			0x02: func() Packet { return new(SetDifficulty) },
+			0x03: func() Packet { return new(ChatMessageServerbound) },
+			0x04: func() Packet { return new(ClientStatus) },
+			0x05: func() Packet { return new(ClientSettings) },
+			0x06: func() Packet { return new(TabCompleteServerbound) },
+			0x07: func() Packet { return new(ConfirmTransactionServerbound) },
+			0x08: func() Packet { return new(ClickWindowButton) },
+			0x09: func() Packet { return new(ClickWindow) },
+			0x0A: func() Packet { return new(CloseWindowServerbound) },
+			0x0B: func() Packet { return new(PluginMessageServerbound) },
+			0x0C: func() Packet { return new(EditBook) },
+			0x0D: func() Packet { return new(QueryEntityNBT) },
+			0x0E: func() Packet { return new(UseEntity) },
+			0x0F: func() Packet { return new(KeepAliveServerbound) },
+			0x10: func() Packet { return new(LockDifficulty) },
+			0x11: func() Packet { return new(PlayerPosition) },
+			0x12: func() Packet { return new(PlayerPositionAndLookServerbound) },
+			0x13: func() Packet { return new(PlayerLook) },
+			0x14: func() Packet { return new(Player) },
+			0x15: func() Packet { return new(VehicleMoveServerbound) },
+			0x16: func() Packet { return new(SteerBoat) },
+			0x17: func() Packet { return new(PickItem) },
+			0x18: func() Packet { return new(CraftRecipeRequest) },
+			0x19: func() Packet { return new(PlayerAbilitiesServerbound) },
+			0x1A: func() Packet { return new(PlayerDigging) },
+			0x1B: func() Packet { return new(EntityAction) },
+			0x1C: func() Packet { return new(SteerVehicle) },
+			0x1D: func() Packet { return new(RecipeBookData) },
+			0x1E: func() Packet { return new(NameItem) },
+			0x1F: func() Packet { return new(ResourcePackStatus) },
+			0x20: func() Packet { return new(AdvancementTab) },
+			0x21: func() Packet { return new(SelectTrade) },
+			0x22: func() Packet { return new(SetBeaconEffect) },
+			0x23: func() Packet { return new(HeldItemChangeServerbound) },
+			0x24: func() Packet { return new(UpdateCommandBlock) },
+			0x25: func() Packet { return new(UpdateCommandBlockMinecart) },
+			0x26: func() Packet { return new(CreativeInventoryAction) },
+			0x27: func() Packet { return new(UpdateJigsawBlock) },
+			0x28: func() Packet { return new(UpdateStructureBlock) },
+			0x29: func() Packet { return new(UpdateSign) },
+			0x2A: func() Packet { return new(AnimationServerbound) },
+			0x2B: func() Packet { return new(Spectate) },
+			0x2C: func() Packet { return new(PlayerBlockPlacement) },
+			0x2D: func() Packet { return new(UseItem) },
+		},
+		data.Clientbound: {
+			0x00: func() Packet { return new(SpawnObject) },
+			0x01: func() Packet { return new(SpawnExperienceOrb) },
+			0x02: func() Packet { return new(SpawnGlobalEntity) },
+			0x03: func() Packet { return new(SpawnMob) },
+			0x04: func() Packet { return new(SpawnPainting) },
+			0x05: func() Packet { return new(SpawnPlayer) },
+			0x06: func() Packet { return new(AnimationClientbound) },
+			0x07: func() Packet { return new(Statistics) },
+			0x08: func() Packet { return new(AcknowledgePlayerDigging) },
+			0x09: func() Packet { return new(BlockBreakAnimation) },
+			0x0A: func() Packet { return new(UpdateBlockEntity) },
+			0x0B: func() Packet { return new(BlockAction) },
+			0x0C: func() Packet { return new(BlockChange) },
+			0x0D: func() Packet { return new(BossBar) },
+			0x0E: func() Packet { return new(ServerDifficulty) },
+			0x0F: func() Packet { return new(ChatMessageClientbound) },
+			0x10: func() Packet { return new(MultiBlockChange) },
+			0x11: func() Packet { return new(TabComplete) },
+			0x12: func() Packet { return new(DeclareCommands) },
+			0x13: func() Packet { return new(ConfirmTransaction) },
+			0x14: func() Packet { return new(CloseWindow) },
+			0x15: func() Packet { return new(WindowItems) },
+			0x16: func() Packet { return new(WindowProperty) },
+			0x17: func() Packet { return new(SetSlot) },
+			0x18: func() Packet { return new(SetCooldown) },
+			0x19: func() Packet { return new(PluginMessageClientbound) },
+			0x1A: func() Packet { return new(NamedSoundEffect) },
+			0x1B: func() Packet { return new(DisconnectPlay) },
+			0x1C: func() Packet { return new(EntityStatus) },
+			0x1D: func() Packet { return new(Explosion) },
+			0x1E: func() Packet { return new(UnloadChunk) },
+			0x1F: func() Packet { return new(ChangeGameState) },
+			0x20: func() Packet { return new(OpenHorseWindow) },
+			0x21: func() Packet { return new(KeepAliveClientbound) },
+			0x22: func() Packet { return new(ChunkData) },
+			0x23: func() Packet { return new(Effect) },
+			0x24: func() Packet { return new(Particle) },
+			0x25: func() Packet { return new(UpdateLight) },
+			0x26: func() Packet { return new(JoinGame) },
+			0x27: func() Packet { return new(MapData) },
+			0x28: func() Packet { return new(TradeList) },
+			0x29: func() Packet { return new(EntityRelativeMove) },
+			0x2A: func() Packet { return new(EntityLookAndRelativeMove) },
+			0x2B: func() Packet { return new(EntityLook) },
+			0x2C: func() Packet { return new(Entity) },
+			0x2D: func() Packet { return new(VehicleMoveClientbound) },
+			0x2E: func() Packet { return new(OpenBook) },
+			0x2F: func() Packet { return new(OpenWindow) },
+			0x30: func() Packet { return new(OpenSignEditor) },
+			0x31: func() Packet { return new(CraftRecipeResponse) },
+			0x32: func() Packet { return new(PlayerAbilitiesClientbound) },
+			0x33: func() Packet { return new(CombatEvent) },
+			0x34: func() Packet { return new(PlayerInfo) },
+			0x35: func() Packet { return new(FacePlayer) },
+			0x36: func() Packet { return new(PlayerPositionAndLookClientbound) },
+			0x37: func() Packet { return new(UnlockRecipes) },
+			0x38: func() Packet { return new(DestroyEntities) },
+			0x39: func() Packet { return new(RemoveEntityEffect) },
+			0x3A: func() Packet { return new(ResourcePackSend) },
+			0x3B: func() Packet { return new(Respawn) },
+			0x3C: func() Packet { return new(EntityHeadLook) },
+			0x3D: func() Packet { return new(SelectAdvancementTab) },
+			0x3E: func() Packet { return new(WorldBorder) },
+			0x3F: func() Packet { return new(Camera) },
+			0x40: func() Packet { return new(HeldItemChangeClientbound) },
+			0x41: func() Packet { return new(UpdateViewPosition) },
+			0x42: func() Packet { return new(UpdateViewDistance) },
+			0x43: func() Packet { return new(DisplayScoreboard) },
+			0x44: func() Packet { return new(EntityMetadata) },
+			0x45: func() Packet { return new(AttachEntity) },
+			0x46: func() Packet { return new(EntityVelocity) },
+			0x47: func() Packet { return new(EntityEquipment) },
+			0x48: func() Packet { return new(SetExperience) },
+			0x49: func() Packet { return new(UpdateHealth) },
+			0x4A: func() Packet { return new(ScoreboardObjective) },
+			0x4B: func() Packet { return new(SetPassengers) },
+			0x4C: func() Packet { return new(Teams) },
+			0x4D: func() Packet { return new(UpdateScore) },
+			0x4E: func() Packet { return new(SpawnPosition) },
+			0x4F: func() Packet { return new(TimeUpdate) },
+			0x50: func() Packet { return new(Title) },
+			0x51: func() Packet { return new(EntitySoundEffect) },
+			0x52: func() Packet { return new(SoundEffect) },
+			0x53: func() Packet { return new(StopSound) },
+			0x54: func() Packet { return new(PlayerListHeaderAndFooter) },
+			0x55: func() Packet { return new(NBTQueryResponse) },
+			0x56: func() Packet { return new(CollectItem) },
+			0x57: func() Packet { return new(EntityTeleport) },
+			0x58: func() Packet { return new(Advancements) },
+			0x59: func() Packet { return new(EntityProperties) },
+			0x5A: func() Packet { return new(EntityEffect) },
+			0x5B: func() Packet { return new(DeclareRecipes) },
+			0x5C: func() Packet { return new(Tags) },
+		},
+	},
+}
@@ -0,0 +1,88 @@
+package packets
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Tnze/go-mc/data"
+	pk "github.com/Tnze/go-mc/net/packet"
+	"github.com/google/uuid"
+)
+
+func TestMarshal(t *testing.T) {
+	p, err := Marshal(KeepAliveServerbound{KeepAliveID: 0x0102030405060708})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := pk.Marshal(data.KeepAliveServerbound, pk.Long(0x0102030405060708))
+	if p.ID != want.ID || !bytes.Equal(p.Data, want.Data) {
+		t.Errorf("keep alive packet should be %v, get %v", want, p)
+	}
+
+	p, err = Marshal(SetSlot{WindowID: 0, Slot: 36, SlotData: &Item{ItemID: 1, Count: 64}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = pk.Marshal(data.SetSlot,
+		pk.Byte(0), pk.Short(36),
+		pk.Boolean(true), pk.VarInt(1), pk.Byte(64), pk.Byte(0), // empty NBT
+	)
+	if p.ID != want.ID || !bytes.Equal(p.Data, want.Data) {
+		t.Errorf("set slot packet should be %v, get %v", want, p)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	id := uuid.MustParse("853c80ef-3c37-49fd-aa49-938b674adae6")
+	p := pk.Marshal(data.SpawnPlayer,
+		pk.VarInt(42), pk.UUID(id),
+		pk.Double(1), pk.Double(2), pk.Double(3),
+		pk.Angle(4), pk.Angle(5),
+	)
+	v, err := Unmarshal(data.Play, data.Clientbound, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp, ok := v.(*SpawnPlayer)
+	if !ok {
+		t.Fatalf("should be *SpawnPlayer, get %T", v)
+	}
+	want := SpawnPlayer{EntityID: 42, PlayerUUID: id, X: 1, Y: 2, Z: 3, Yaw: 4, Pitch: 5}
+	if *sp != want {
+		t.Errorf("should be %+v, get %+v", want, *sp)
+	}
+
+	_, err = Unmarshal(data.Login, data.Clientbound, pk.Marshal(0x05))
+	if !errors.Is(err, data.ErrIllegalPacket) {
+		t.Errorf("unknown packet should be ErrIllegalPacket, get %v", err)
+	}
+}
+
+// Every packet struct should be encoded and decoded without error,
+// and the IDs should match the packet ID tables.
+func TestRegistry(t *testing.T) {
+	for state, dirs := range registry {
+		for dir, packets := range dirs {
+			table := data.Packets(Protocol, state, dir)
+			for id, newPacket := range packets {
+				v := newPacket()
+				if v.ID() != id {
+					t.Errorf("%T has ID 0x%02X but registered as 0x%02X", v, v.ID(), id)
+				}
+				if _, ok := table.Name(id); !ok {
+					t.Errorf("%v %v 0x%02X is not in the packet ID table", state, dir, id)
+				}
+
+				p, err := Marshal(v)
+				if err != nil {
+					t.Errorf("marshal %T fail: %v", v, err)
+					continue
+				}
+				if _, err := Unmarshal(state, dir, p); err != nil {
+					t.Errorf("unmarshal %T fail: %v", v, err)
+				}
+			}
+		}
+	}
+}
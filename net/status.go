@@ -0,0 +1,207 @@
+package net
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+
+	"github.com/Tnze/go-mc/chat"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// StatusResponse is the server status shown in the server list.
+//
+// For more information for JSON format, see https://wiki.vg/Server_List_Ping#Response
+type StatusResponse struct {
+	Version struct {
+		Name     string `json:"name"`
+		Protocol int    `json:"protocol"`
+	} `json:"version"`
+	Players struct {
+		Max    int            `json:"max"`
+		Online int            `json:"online"`
+		Sample []PlayerSample `json:"sample,omitempty"`
+	} `json:"players"`
+	Description chat.Message `json:"description"`
+	// Favicon is the 64*64 icon of the server,
+	// nil if the server doesn't have one or it can't be decoded.
+	Favicon image.Image `json:"-"`
+}
+
+// PlayerSample is an online player listed in the StatusResponse.
+type PlayerSample struct {
+	Name string    `json:"name"`
+	ID   uuid.UUID `json:"id"`
+}
+
+const faviconPrefix = "data:image/png;base64,"
+
+// MarshalJSON encode the status as JSON, with the favicon encoded as PNG data URI.
+func (s StatusResponse) MarshalJSON() ([]byte, error) {
+	type status StatusResponse
+	var favicon string
+	if s.Favicon != nil {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, s.Favicon); err != nil {
+			return nil, fmt.Errorf("encode favicon fail: %v", err)
+		}
+		favicon = faviconPrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	return json.Marshal(struct {
+		status
+		Favicon string `json:"favicon,omitempty"`
+	}{status(s), favicon})
+}
+
+// UnmarshalJSON decode the status from JSON and decode the favicon if there is one.
+// A favicon which can't be decoded is ignored.
+func (s *StatusResponse) UnmarshalJSON(data []byte) error {
+	type status StatusResponse
+	v := struct {
+		*status
+		Favicon string `json:"favicon"`
+	}{status: (*status)(s)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	s.Favicon = nil
+	if !strings.HasPrefix(v.Favicon, faviconPrefix) {
+		return nil
+	}
+	img, err := png.Decode(base64.NewDecoder(base64.StdEncoding,
+		strings.NewReader(strings.TrimPrefix(v.Favicon, faviconPrefix))))
+	if err == nil {
+		s.Favicon = img
+	}
+	return nil
+}
+
+// String return the status in lines, like the server list.
+func (s StatusResponse) String() string {
+	var sb strings.Builder
+	fmt.Fprintln(&sb, "Server:", s.Version.Name)
+	fmt.Fprintln(&sb, "Protocol:", s.Version.Protocol)
+	fmt.Fprintln(&sb, "Description:", s.Description)
+	fmt.Fprintf(&sb, "Players: %d/%d\n", s.Players.Online, s.Players.Max)
+	for _, v := range s.Players.Sample {
+		fmt.Fprintf(&sb, "- [%s] %v\n", v.Name, v.ID)
+	}
+	return sb.String()
+}
+
+func (a *Acceptor) handleStatus(conn *Conn, hs Handshake) error {
+	if a.Status == nil {
+		return nil
+	}
+	for {
+		p, err := conn.ReadPacket()
+		if err != nil {
+			return err
+		}
+		switch p.ID {
+		case 0x00: // Request
+			s, err := a.Status(hs)
+			if err != nil {
+				return err
+			}
+			resp, err := json.Marshal(s)
+			if err != nil {
+				return err
+			}
+			err = conn.WritePacket(pk.Marshal(0x00, pk.String(resp)))
+			if err != nil {
+				return err
+			}
+		case 0x01: // Ping
+			var payload pk.Long
+			if err := p.Scan(&payload); err != nil {
+				return err
+			}
+			// Pong, the client closes the connection after receiving it
+			return conn.WritePacket(pk.Marshal(0x01, payload))
+		default:
+			return fmt.Errorf("unknown status packet: 0x%02X", p.ID)
+		}
+	}
+}
+
+// isLegacyPing check if the first byte sent by the client is 0xFE,
+// which means this is a server list ping of Minecraft 1.6 or earlier.
+func isLegacyPing(conn *Conn) bool {
+	br, ok := conn.ByteReader.(*bufio.Reader)
+	if !ok {
+		return false
+	}
+	b, err := br.Peek(1)
+	return err == nil && b[0] == 0xFE
+}
+
+// legacyPingTimeout is how long handleLegacyPing waits for the byte after 0xFE.
+const legacyPingTimeout = time.Second
+
+// handleLegacyPing answers the server list ping sent by the clients before 1.7.
+// See https://wiki.vg/Server_List_Ping#1.6
+func (a *Acceptor) handleLegacyPing(conn *Conn) error {
+	if _, err := conn.ReadByte(); err != nil { // 0xFE
+		return err
+	}
+	if a.Status == nil {
+		return nil
+	}
+	s, err := a.Status(Handshake{Intention: IntentionStatus})
+	if err != nil {
+		return err
+	}
+
+	// Clients since 1.4 send 0xFE 0x01 and understand the new format,
+	// the older clients send only 0xFE and wait for the response.
+	var newFormat bool
+	if conn.Socket != nil {
+		_ = conn.Socket.SetReadDeadline(time.Now().Add(legacyPingTimeout))
+	}
+	if b, err := conn.ByteReader.(*bufio.Reader).Peek(1); err == nil {
+		newFormat = b[0] == 0x01
+	}
+	if conn.Socket != nil {
+		_ = conn.Socket.SetReadDeadline(time.Time{})
+	}
+
+	var resp string
+	if newFormat {
+		resp = strings.Join([]string{
+			"§1",
+			strconv.Itoa(s.Version.Protocol),
+			s.Version.Name,
+			s.Description.ClearString(),
+			strconv.Itoa(s.Players.Online),
+			strconv.Itoa(s.Players.Max),
+		}, "\x00")
+	} else {
+		resp = strings.Join([]string{
+			s.Description.ClearString(),
+			strconv.Itoa(s.Players.Online),
+			strconv.Itoa(s.Players.Max),
+		}, "§")
+	}
+
+	// Kick packet, with the response encoded as UTF-16BE
+	str := utf16.Encode([]rune(resp))
+	buf := make([]byte, 0, 3+len(str)*2)
+	buf = append(buf, 0xFF, byte(len(str)>>8), byte(len(str)))
+	for _, c := range str {
+		buf = append(buf, byte(c>>8), byte(c))
+	}
+	_, err = conn.Write(buf)
+	return err
+}
@@ -0,0 +1,100 @@
+package net
+
+import (
+	"encoding/json"
+	"image"
+	"io/ioutil"
+	"net"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/Tnze/go-mc/chat"
+)
+
+func TestStatusResponse_JSON(t *testing.T) {
+	var s StatusResponse
+	s.Version.Name = "1.15.2"
+	s.Version.Protocol = 578
+	s.Players.Max = 20
+	s.Players.Online = 1
+	s.Players.Sample = []PlayerSample{{Name: "Tnze", ID: OfflineUUID("Tnze")}}
+	s.Description = chat.Message{Text: "A Minecraft Server"}
+	s.Favicon = image.NewRGBA(image.Rect(0, 0, 64, 64))
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s2 StatusResponse
+	if err := json.Unmarshal(data, &s2); err != nil {
+		t.Fatal(err)
+	}
+	if s2.Version != s.Version || s2.Players.Online != 1 || s2.Players.Sample[0] != s.Players.Sample[0] {
+		t.Errorf("status not match: %s", data)
+	}
+	if s2.Description.Text != "A Minecraft Server" {
+		t.Errorf("description not match: %v", s2.Description)
+	}
+	if s2.Favicon == nil || s2.Favicon.Bounds() != s.Favicon.Bounds() {
+		t.Errorf("favicon not match: %v", s2.Favicon)
+	}
+}
+
+func TestStatusResponse_badFavicon(t *testing.T) {
+	for _, favicon := range []string{"data:image/png;base64,AAAA", "http://example.com/icon.png"} {
+		var s StatusResponse
+		data := `{"version":{"name":"1.15.2","protocol":578},"favicon":"` + favicon + `"}`
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			t.Errorf("favicon %q fails the status: %v", favicon, err)
+		}
+		if s.Version.Protocol != 578 || s.Favicon != nil {
+			t.Errorf("wrong status: %+v", s)
+		}
+	}
+}
+
+func TestAcceptor_legacyPing(t *testing.T) {
+	// 1.4 - 1.6
+	if resp := legacyPing(t, []byte{0xFE, 0x01}); resp != "§1\x00578\x001.15.2\x00Hello\x000\x0020" {
+		t.Errorf("wrong response to 0xFE 0x01: %q", resp)
+	}
+	// Beta 1.8 - 1.3, waiting for the response after 0xFE
+	if resp := legacyPing(t, []byte{0xFE}); resp != "Hello§0§20" {
+		t.Errorf("wrong response to 0xFE: %q", resp)
+	}
+}
+
+// legacyPing sends the ping to an Acceptor and return the string in the kick packet.
+func legacyPing(t *testing.T, ping []byte) string {
+	t.Helper()
+	client, server := net.Pipe()
+	defer client.Close()
+
+	go func() {
+		a := Acceptor{Status: func(Handshake) (s StatusResponse, err error) {
+			s.Version.Name = "1.15.2"
+			s.Version.Protocol = 578
+			s.Players.Max = 20
+			s.Description = chat.Message{Text: "Hello"}
+			return
+		}}
+		_, _ = a.Accept(*WrapConn(server))
+	}()
+
+	if _, err := client.Write(ping); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := ioutil.ReadAll(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp[0] != 0xFF {
+		t.Fatalf("not a kick packet: % x", resp)
+	}
+	str := make([]uint16, int(resp[1])<<8|int(resp[2]))
+	for i := range str {
+		str[i] = uint16(resp[3+i*2])<<8 | uint16(resp[4+i*2])
+	}
+	return string(utf16.Decode(str))
+}
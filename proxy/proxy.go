@@ -0,0 +1,339 @@
+// Package proxy implements a man-in-the-middle proxy for Minecraft protocol.
+//
+// The proxy accepts clients, connects them to the upstream server and
+// forwards the packets in both directions. Handlers can be set to observe,
+// modify, drop or inject packets, which is useful for debugging bots.
+//
+// In online mode, the proxy authenticates the client by itself and logs
+// in to the upstream server with its own account, so the connection is
+// re-encrypted on both sides and the packets are visible as plain text.
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Tnze/go-mc/bot"
+	"github.com/Tnze/go-mc/data"
+	mcnet "github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+// State is the state of a connection
+type State = data.State
+
+// All states of a Minecraft connection
+const (
+	Handshaking = data.Handshaking
+	Status      = data.Status
+	Login       = data.Login
+	Play        = data.Play
+)
+
+// A Handler is called for every packet passing through the proxy.
+// It may modify the packet in place.
+// The packet is forwarded only if forward is true.
+// Returning an error closes the session.
+//
+// Handlers of the same direction are called one by one,
+// but the handlers of different directions may be called at the same time.
+//
+// If the protocol version of the client is known by package data,
+// the packets illegal in current state close the session before reaching the handler.
+type Handler func(s *Session, state State, p *pk.Packet) (forward bool, err error)
+
+// Proxy forward the packets between clients and the upstream server.
+type Proxy struct {
+	// Server is the address of the upstream server, eg. "localhost:25565".
+	Server string
+	// Dialer is used to connect the upstream server. If nil, net.Dial is used.
+	Dialer bot.Dialer
+
+	// OnlineMode makes the proxy authenticate the clients with Mojang.
+	OnlineMode bool
+	// Auth is the account used to log in to an online-mode upstream server.
+	// If Auth.AsTk is set, the name in Login Start packet is replaced by Auth.Name.
+	Auth bot.Auth
+
+	// Serverbound is called for the packets sent from the client to the server.
+	Serverbound Handler
+	// Clientbound is called for the packets sent from the server to the client.
+	Clientbound Handler
+
+	acceptor mcnet.Acceptor
+}
+
+// ErrNoServer is returned when a packet is sent to the server
+// before it's connected, such as in the handler of the handshake.
+var ErrNoServer = errors.New("proxy: the server isn't connected yet")
+
+// Session is a client connected through the proxy.
+type Session struct {
+	Handshake mcnet.Handshake
+
+	client, server     *mcnet.Conn
+	clientMu, serverMu sync.Mutex
+	state              int32
+}
+
+// State return the current state of the session.
+func (s *Session) State() State {
+	return State(atomic.LoadInt32(&s.state))
+}
+
+func (s *Session) setState(state State) {
+	atomic.StoreInt32(&s.state, int32(state))
+}
+
+// SendToServer injects a packet sent to the server.
+// It is safe to be called from any goroutine, including the handlers.
+// The server is connected after the handshake is handled, ErrNoServer is returned before that.
+func (s *Session) SendToServer(p pk.Packet) error {
+	s.serverMu.Lock()
+	defer s.serverMu.Unlock()
+	if s.server == nil {
+		return ErrNoServer
+	}
+	return s.server.WritePacket(p)
+}
+
+// SendToClient injects a packet sent to the client.
+// It is safe to be called from any goroutine, including the handlers.
+func (s *Session) SendToClient(p pk.Packet) error {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
+	return s.client.WritePacket(p)
+}
+
+// Close closes both connections of the session.
+func (s *Session) Close() error {
+	err := s.client.Close()
+	s.serverMu.Lock()
+	defer s.serverMu.Unlock()
+	if s.server != nil {
+		if err2 := s.server.Close(); err == nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// ListenAndServe listen on the addr and serve the clients.
+func (p *Proxy) ListenAndServe(addr string) error {
+	l, err := mcnet.ListenMC(addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	return p.Serve(l)
+}
+
+// Serve accepts the clients on l and handles each of them in a new goroutine.
+// The errors of each session are discarded, use Handle if you need them.
+func (p *Proxy) Serve(l *mcnet.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() { _ = p.Handle(conn) }()
+	}
+}
+
+// Handle forwards the packets of a client connection until one side closes it.
+func (p *Proxy) Handle(conn mcnet.Conn) error {
+	client := &conn
+	defer client.Close()
+
+	// Handshake
+	hp, err := client.ReadPacket()
+	if err != nil {
+		return fmt.Errorf("proxy: read handshake fail: %v", err)
+	}
+	s := &Session{client: client}
+	if p.Serverbound != nil {
+		forward, err := p.Serverbound(s, Handshaking, &hp)
+		if err != nil || !forward {
+			return err
+		}
+	}
+	if err := hp.Scan(&s.Handshake); err != nil {
+		return fmt.Errorf("proxy: scan handshake fail: %v", err)
+	}
+
+	server, err := p.dial()
+	if err != nil {
+		return fmt.Errorf("proxy: connect server fail: %v", err)
+	}
+	defer server.Close()
+	s.serverMu.Lock()
+	s.server = server
+	s.serverMu.Unlock()
+	if err := s.SendToServer(hp); err != nil {
+		return fmt.Errorf("proxy: send handshake fail: %v", err)
+	}
+
+	switch s.Handshake.Intention {
+	case mcnet.IntentionStatus:
+		s.setState(Status)
+	case mcnet.IntentionLogin:
+		s.setState(Login)
+		if err := p.login(s); err != nil {
+			return fmt.Errorf("proxy: login fail: %v", err)
+		}
+		if s.State() != Play {
+			return nil // disconnected by server
+		}
+	default:
+		return fmt.Errorf("proxy: unknown intention: %d", s.Handshake.Intention)
+	}
+
+	errs := make(chan error, 2)
+	go func() { errs <- p.forward(s, data.Serverbound, s.client, s.SendToServer, p.Serverbound) }()
+	go func() { errs <- p.forward(s, data.Clientbound, s.server, s.SendToClient, p.Clientbound) }()
+	err = <-errs
+	s.Close() // stop the other goroutine
+	<-errs
+	return err
+}
+
+func (p *Proxy) dial() (*mcnet.Conn, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if p.Dialer != nil {
+		conn, err = p.Dialer.Dial("tcp", p.Server)
+	} else {
+		conn, err = net.Dial("tcp", p.Server)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mcnet.WrapConn(conn), nil
+}
+
+// call the handler and send the packet if it should be forwarded.
+func (p *Proxy) handle(s *Session, h Handler, send func(pk.Packet) error, pack pk.Packet) error {
+	if h != nil {
+		forward, err := h(s, s.State(), &pack)
+		if err != nil || !forward {
+			return err
+		}
+	}
+	return send(pack)
+}
+
+// forward the packets read from src until error occurs.
+func (p *Proxy) forward(s *Session, dir data.Direction, src *mcnet.Conn, send func(pk.Packet) error, h Handler) error {
+	for {
+		pack, err := src.ReadPacket()
+		if err != nil {
+			return err
+		}
+		state := s.State()
+		if t := data.Packets(int(s.Handshake.Protocol), state, dir); t != nil {
+			if _, ok := t.Name(pack.ID); !ok {
+				return fmt.Errorf("proxy: %w in %v %v: 0x%02X", data.ErrIllegalPacket, dir, state, pack.ID)
+			}
+		}
+		if err := p.handle(s, h, send, pack); err != nil {
+			return err
+		}
+	}
+}
+
+// login forwards the packets of the login state.
+// Login is a request-response process, so it is handled in a single goroutine.
+// The encryption packets are not forwarded, but handled by each side of the proxy.
+func (p *Proxy) login(s *Session) error {
+	// Login Start
+	pack, err := s.client.ReadPacket()
+	if err != nil {
+		return err
+	}
+	if pack.ID != 0x00 {
+		return fmt.Errorf("not a login start packet: 0x%02X", pack.ID)
+	}
+	var name pk.String
+	if err := pack.Scan(&name); err != nil {
+		return err
+	}
+	if p.OnlineMode {
+		_, _, _, err := p.acceptor.Encrypt(s.client, string(name))
+		if err != nil {
+			_ = s.client.LoginDisconnect("Failed to verify username!")
+			return fmt.Errorf("authenticate client fail: %v", err)
+		}
+	}
+	if p.Auth.AsTk != "" {
+		pack = pk.Marshal(0x00, pk.String(p.Auth.Name))
+	}
+	if err := p.handle(s, p.Serverbound, s.SendToServer, pack); err != nil {
+		return err
+	}
+
+	for {
+		pack, err := s.server.ReadPacket()
+		if err != nil {
+			return err
+		}
+
+		switch pack.ID {
+		case 0x00: // Disconnect
+			return p.handle(s, p.Clientbound, s.SendToClient, pack)
+
+		case 0x01: // Encryption Request
+			if p.Auth.AsTk == "" {
+				return errors.New("server is in online mode but no account is set")
+			}
+			s.serverMu.Lock()
+			err := bot.HandleEncryptionRequest(s.server, p.Auth, pack)
+			s.serverMu.Unlock()
+			if err != nil {
+				return fmt.Errorf("encryption fail: %v", err)
+			}
+
+		case 0x02: // Login Success
+			if err := p.handle(s, p.Clientbound, s.SendToClient, pack); err != nil {
+				return err
+			}
+			s.setState(Play)
+			return nil
+
+		case 0x03: // Set Compression
+			var threshold pk.VarInt
+			if err := pack.Scan(&threshold); err != nil {
+				return err
+			}
+			s.serverMu.Lock()
+			s.server.SetThreshold(int(threshold))
+			s.serverMu.Unlock()
+			if err := p.handle(s, p.Clientbound, s.SendToClient, pack); err != nil {
+				return err
+			}
+			s.clientMu.Lock()
+			s.client.SetThreshold(int(threshold))
+			s.clientMu.Unlock()
+
+		case 0x04: // Login Plugin Request
+			if err := p.handle(s, p.Clientbound, s.SendToClient, pack); err != nil {
+				return err
+			}
+			// Login Plugin Response
+			resp, err := s.client.ReadPacket()
+			if err != nil {
+				return err
+			}
+			if err := p.handle(s, p.Serverbound, s.SendToServer, resp); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unknown login packet: 0x%02X", pack.ID)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package proxy
+
+import (
+	"net"
+	"testing"
+
+	mcnet "github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+func TestProxy_Handle(t *testing.T) {
+	// upstream server
+	l, err := mcnet.ListenMC("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		a := mcnet.Acceptor{Threshold: 16}
+		player, err := a.Accept(conn)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer player.Close()
+		_ = player.WritePacket(pk.Marshal(0x0E, pk.String("Hello, "+player.Name)))
+	}()
+
+	var states []State
+	p := Proxy{
+		Server: l.Addr().String(),
+		Serverbound: func(s *Session, state State, p *pk.Packet) (bool, error) {
+			states = append(states, state)
+			if state == Handshaking {
+				if err := s.SendToServer(pk.Marshal(0x00)); err != ErrNoServer {
+					t.Errorf("send to the server in handshake: %v", err)
+				}
+			}
+			return true, nil
+		},
+		Clientbound: func(s *Session, state State, p *pk.Packet) (bool, error) {
+			if state == Play && p.ID == 0x0E {
+				*p = pk.Marshal(0x0E, pk.String("modified"))
+			}
+			return true, nil
+		},
+	}
+
+	client, server := net.Pipe()
+	defer client.Close()
+	go func() { _ = p.Handle(*mcnet.WrapConn(server)) }()
+
+	c := mcnet.WrapConn(client)
+	if err := c.WritePacket(pk.Marshal(0x00,
+		pk.VarInt(578), pk.String("localhost"), pk.UnsignedShort(25565), pk.VarInt(mcnet.IntentionLogin),
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WritePacket(pk.Marshal(0x00, pk.String("Tnze"))); err != nil {
+		t.Fatal(err)
+	}
+
+	for {
+		pack, err := c.ReadPacket()
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch pack.ID {
+		case 0x03: // Set Compression
+			var threshold pk.VarInt
+			if err := pack.Scan(&threshold); err != nil {
+				t.Fatal(err)
+			}
+			c.SetThreshold(int(threshold))
+			continue
+		case 0x02: // Login Success
+			continue
+		}
+
+		var msg pk.String
+		if err := pack.Scan(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg != "modified" {
+			t.Errorf("packet should be modified, get %q", msg)
+		}
+		break
+	}
+
+	if len(states) != 2 || states[0] != Handshaking || states[1] != Login {
+		t.Errorf("unexpected states: %v", states)
+	}
+}
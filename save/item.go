@@ -0,0 +1,287 @@
+package save
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/Tnze/go-mc/chat"
+)
+
+// ItemTag is the typed model of the "tag" compound of an item,
+// shared by the Item in the saves and the Slot in the network protocol.
+// The tags not listed here are kept in Other, as decoded by the nbt package.
+type ItemTag struct {
+	Damage          int32
+	Unbreakable     bool
+	RepairCost      int32
+	CustomModelData int32
+	HideFlags       int32
+
+	Enchantments       []Enchantment
+	StoredEnchantments []Enchantment // the enchantments stored in an enchanted book
+
+	Name *chat.Message  // the custom name in display, nil if not renamed
+	Lore []chat.Message // the lore in display
+
+	CanDestroy []string // the blocks can be broken in adventure mode
+	CanPlaceOn []string // the blocks can be placed on in adventure mode
+
+	// BlockEntityTag is the block entity of the placed block, such as the items in a shulker box.
+	BlockEntityTag map[string]interface{}
+
+	Other map[string]interface{}
+}
+
+// Enchantment is an enchantment of an item.
+type Enchantment struct {
+	ID    string // such as "minecraft:efficiency"
+	Level int16
+}
+
+// EnchantmentLevel return the level of the enchantment, 0 if the item isn't enchanted with it.
+// The namespace of id can be omitted, such as "efficiency".
+func (t ItemTag) EnchantmentLevel(id string) int {
+	id = namespaced(id)
+	for _, e := range t.Enchantments {
+		if e.ID == id {
+			return int(e.Level)
+		}
+	}
+	return 0
+}
+
+// ItemTag return the typed Tag of the item.
+func (i Item) ItemTag() ItemTag {
+	return ParseItemTag(i.Tag)
+}
+
+// SetItemTag replace the Tag of the item.
+func (i *Item) SetItemTag(t ItemTag) {
+	i.Tag = t.Map()
+}
+
+// ParseItemTag convert the "tag" compound decoded by the nbt package to ItemTag.
+// The tags of unexpected types are kept in Other.
+func ParseItemTag(tag map[string]interface{}) (t ItemTag) {
+	for k, v := range tag {
+		var ok bool
+		switch k {
+		case "Damage":
+			t.Damage, ok = v.(int32)
+		case "Unbreakable":
+			var b byte
+			b, ok = v.(byte)
+			t.Unbreakable = b != 0
+		case "RepairCost":
+			t.RepairCost, ok = v.(int32)
+		case "CustomModelData":
+			t.CustomModelData, ok = v.(int32)
+		case "HideFlags":
+			t.HideFlags, ok = v.(int32)
+		case "Enchantments":
+			t.Enchantments, ok = parseEnchantments(v)
+		case "StoredEnchantments":
+			t.StoredEnchantments, ok = parseEnchantments(v)
+		case "CanDestroy":
+			t.CanDestroy, ok = parseStrings(v)
+		case "CanPlaceOn":
+			t.CanPlaceOn, ok = parseStrings(v)
+		case "BlockEntityTag":
+			t.BlockEntityTag, ok = v.(map[string]interface{})
+		case "display":
+			var display map[string]interface{}
+			if display, ok = v.(map[string]interface{}); ok {
+				if rest := t.parseDisplay(display); len(rest) > 0 {
+					t.setOther(k, rest) // such as the color of leather armor
+				}
+			}
+		}
+		if !ok {
+			t.setOther(k, v)
+		}
+	}
+	return
+}
+
+// parseDisplay read the Name and Lore and return the other tags in display.
+func (t *ItemTag) parseDisplay(display map[string]interface{}) map[string]interface{} {
+	rest := make(map[string]interface{})
+	for k, v := range display {
+		switch k {
+		case "Name":
+			if s, ok := v.(string); ok {
+				msg := chat.ParseText(s)
+				t.Name = &msg
+				continue
+			}
+		case "Lore":
+			if lines, ok := parseStrings(v); ok {
+				t.Lore = make([]chat.Message, len(lines))
+				for i, s := range lines {
+					t.Lore[i] = chat.ParseText(s)
+				}
+				continue
+			}
+		}
+		rest[k] = v
+	}
+	return rest
+}
+
+func (t *ItemTag) setOther(k string, v interface{}) {
+	if t.Other == nil {
+		t.Other = make(map[string]interface{})
+	}
+	t.Other[k] = v
+}
+
+// Map convert the ItemTag to the "tag" compound for the nbt package.
+// It returns nil if no tags are set.
+func (t ItemTag) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(t.Other))
+	for k, v := range t.Other {
+		m[k] = v
+	}
+	for k, v := range map[string]int32{
+		"Damage":          t.Damage,
+		"RepairCost":      t.RepairCost,
+		"CustomModelData": t.CustomModelData,
+		"HideFlags":       t.HideFlags,
+	} {
+		if v != 0 {
+			m[k] = v
+		}
+	}
+	if t.Unbreakable {
+		m["Unbreakable"] = byte(1)
+	}
+	if len(t.Enchantments) > 0 {
+		m["Enchantments"] = enchantmentsList(t.Enchantments)
+	}
+	if len(t.StoredEnchantments) > 0 {
+		m["StoredEnchantments"] = enchantmentsList(t.StoredEnchantments)
+	}
+	if len(t.CanDestroy) > 0 {
+		m["CanDestroy"] = stringsList(t.CanDestroy)
+	}
+	if len(t.CanPlaceOn) > 0 {
+		m["CanPlaceOn"] = stringsList(t.CanPlaceOn)
+	}
+	if t.BlockEntityTag != nil {
+		m["BlockEntityTag"] = t.BlockEntityTag
+	}
+
+	display := make(map[string]interface{})
+	if d, ok := m["display"].(map[string]interface{}); ok {
+		for k, v := range d {
+			display[k] = v
+		}
+	}
+	if t.Name != nil {
+		display["Name"] = textJSON(*t.Name)
+	}
+	if len(t.Lore) > 0 {
+		lore := make([]interface{}, len(t.Lore))
+		for i, l := range t.Lore {
+			lore[i] = textJSON(l)
+		}
+		display["Lore"] = lore
+	}
+	if len(display) > 0 {
+		m["display"] = display
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+func parseEnchantments(v interface{}) ([]Enchantment, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	enchantments := make([]Enchantment, len(list))
+	for i, e := range list {
+		c, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		id, ok1 := c["id"].(string)
+		lvl, ok2 := intTag(c["lvl"])
+		if !ok1 || !ok2 {
+			return nil, false
+		}
+		// 原版用getInt读取lvl，/give命令给出的是TagInt，id也可以省略命名空间
+		if lvl > math.MaxInt16 {
+			lvl = math.MaxInt16
+		} else if lvl < math.MinInt16 {
+			lvl = math.MinInt16
+		}
+		enchantments[i] = Enchantment{ID: namespaced(id), Level: int16(lvl)}
+	}
+	return enchantments, true
+}
+
+// intTag read any integer tag, as the getInt of vanilla.
+func intTag(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case byte:
+		return int64(int8(v)), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+// namespaced add the default namespace "minecraft:" to the id without one.
+func namespaced(id string) string {
+	if !strings.Contains(id, ":") {
+		return "minecraft:" + id
+	}
+	return id
+}
+
+func enchantmentsList(enchantments []Enchantment) []interface{} {
+	list := make([]interface{}, len(enchantments))
+	for i, e := range enchantments {
+		list[i] = map[string]interface{}{"id": e.ID, "lvl": e.Level}
+	}
+	return list
+}
+
+func parseStrings(v interface{}) ([]string, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, len(list))
+	for i, s := range list {
+		if strs[i], ok = s.(string); !ok {
+			return nil, false
+		}
+	}
+	return strs, true
+}
+
+func stringsList(strs []string) []interface{} {
+	list := make([]interface{}, len(strs))
+	for i, s := range strs {
+		list[i] = s
+	}
+	return list
+}
+
+func textJSON(msg chat.Message) string {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,75 @@
+package save
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Tnze/go-mc/chat"
+)
+
+func TestItemTag(t *testing.T) {
+	tag := map[string]interface{}{
+		"Damage":      int32(12),
+		"Unbreakable": byte(1),
+		"Enchantments": []interface{}{
+			map[string]interface{}{"id": "minecraft:efficiency", "lvl": int16(5)},
+			map[string]interface{}{"id": "minecraft:unbreaking", "lvl": int16(3)},
+		},
+		"display": map[string]interface{}{
+			"Name":  `{"text":"Digger"}`,
+			"Lore":  []interface{}{`"line 1"`},
+			"color": int32(0xFF0000),
+		},
+		"CanDestroy":     []interface{}{"minecraft:stone"},
+		"BlockEntityTag": map[string]interface{}{"Lock": "key"},
+		"Fireworks":      map[string]interface{}{"Flight": byte(2)},
+	}
+
+	item := Item{ID: "minecraft:diamond_pickaxe", Count: 1, Tag: tag}
+	it := item.ItemTag()
+	if it.Damage != 12 || !it.Unbreakable || it.EnchantmentLevel("minecraft:efficiency") != 5 {
+		t.Errorf("wrong tag: %+v", it)
+	}
+	if it.Name == nil || it.Name.Text != "Digger" || len(it.Lore) != 1 || it.Lore[0].Text != "line 1" {
+		t.Errorf("wrong display: %v, %v", it.Name, it.Lore)
+	}
+	if _, ok := it.Other["Fireworks"]; !ok {
+		t.Error("unknown tags are dropped")
+	}
+
+	item.SetItemTag(it)
+	if got := ParseItemTag(item.Tag); !reflect.DeepEqual(got, it) {
+		t.Errorf("round trip fail:\n get  %+v\n want %+v", got, it)
+	}
+	if color := item.Tag["display"].(map[string]interface{})["color"]; color != int32(0xFF0000) {
+		t.Errorf("the color in display is lost: %v", color)
+	}
+
+	name := chat.Message{Text: "Renamed"}
+	it = ItemTag{Name: &name}
+	if m := it.Map(); m["display"].(map[string]interface{})["Name"] != `{"text":"Renamed"}` {
+		t.Errorf("wrong name: %v", m)
+	}
+	if (ItemTag{}).Map() != nil {
+		t.Error("empty tag should be nil")
+	}
+}
+
+func TestItemTag_enchantmentsByCommand(t *testing.T) {
+	// /give @p diamond_pickaxe{Enchantments:[{id:efficiency,lvl:5}]}
+	it := ParseItemTag(map[string]interface{}{
+		"Enchantments": []interface{}{
+			map[string]interface{}{"id": "efficiency", "lvl": int32(5)},
+			map[string]interface{}{"id": "minecraft:unbreaking", "lvl": byte(3)},
+		},
+	})
+	if it.Other != nil {
+		t.Fatalf("enchantments are not parsed: %v", it.Other)
+	}
+	if lvl := it.EnchantmentLevel("minecraft:efficiency"); lvl != 5 {
+		t.Errorf("efficiency level: %d, want 5", lvl)
+	}
+	if lvl := it.EnchantmentLevel("unbreaking"); lvl != 3 {
+		t.Errorf("unbreaking level: %d, want 3", lvl)
+	}
+}